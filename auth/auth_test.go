@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"stock_service/config"
+	"stock_service/errno"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+const jwtSecret = "jwt-secret"
+
+func incoming(kv ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+func token(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return BearerPrefix + s
+}
+
+func TestAuthenticateChain(t *testing.T) {
+	t.Cleanup(func() { Init(nil) })
+	err := Init(&config.AuthConfig{
+		Enable:      true,
+		SkipMethods: []string{"/stock.v2.Stock/GetStock"},
+		APIKeys:     []config.APIKeyConfig{{Key: "k1", Caller: "report", Roles: []string{"viewer"}}},
+		JWT:         &config.JWTConfig{Secret: jwtSecret, Issuer: "sso", Audience: "stock"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	id, err := Authenticate(incoming(APIKeyHeader, "k1"), testMethod)
+	if err != nil || id.Caller != "report" || id.Scheme != SchemeAPIKey {
+		t.Fatalf("api key: %+v, %v", id, err)
+	}
+
+	bearer := token(t, jwt.SigningMethodHS256, []byte(jwtSecret), jwt.MapClaims{
+		"sub": "order_srv", "iss": "sso", "aud": "stock", "exp": exp, "roles": "order admin",
+	})
+	id, err = Authenticate(incoming(AuthorizationHeader, bearer), testMethod)
+	if err != nil || id.Caller != "order_srv" || id.Scheme != SchemeJWT || len(id.Roles) != 2 {
+		t.Fatalf("jwt: %+v, %v", id, err)
+	}
+
+	// 不需要认证的方法
+	if id, err := Authenticate(context.Background(), "/stock.v2.Stock/GetStock"); id != nil || err != nil {
+		t.Fatalf("skipped method: %+v, %v", id, err)
+	}
+
+	for name, ctx := range map[string]context.Context{
+		"missing credentials": context.Background(),
+		"unknown api key":     incoming(APIKeyHeader, "k2"),
+		"wrong jwt secret": incoming(AuthorizationHeader, token(t, jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{
+			"sub": "order_srv", "iss": "sso", "aud": "stock", "exp": exp,
+		})),
+		"expired jwt": incoming(AuthorizationHeader, token(t, jwt.SigningMethodHS256, []byte(jwtSecret), jwt.MapClaims{
+			"sub": "order_srv", "iss": "sso", "aud": "stock", "exp": time.Now().Add(-time.Minute).Unix(),
+		})),
+		"jwt without exp": incoming(AuthorizationHeader, token(t, jwt.SigningMethodHS256, []byte(jwtSecret), jwt.MapClaims{
+			"sub": "order_srv", "iss": "sso", "aud": "stock",
+		})),
+		"wrong audience": incoming(AuthorizationHeader, token(t, jwt.SigningMethodHS256, []byte(jwtSecret), jwt.MapClaims{
+			"sub": "order_srv", "iss": "sso", "aud": "order", "exp": exp,
+		})),
+		"jwt without caller": incoming(AuthorizationHeader, token(t, jwt.SigningMethodHS256, []byte(jwtSecret), jwt.MapClaims{
+			"iss": "sso", "aud": "stock", "exp": exp,
+		})),
+		"alg none": incoming(AuthorizationHeader, token(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{
+			"sub": "order_srv", "iss": "sso", "aud": "stock", "exp": exp,
+		})),
+	} {
+		t.Run(name, func(t *testing.T) {
+			id, err := Authenticate(ctx, testMethod)
+			if !errors.Is(err, errno.ErrUnauthenticated) || id != nil {
+				t.Fatalf("Authenticate = %+v, %v, want ErrUnauthenticated", id, err)
+			}
+		})
+	}
+}
+
+func TestInitInvalidConfigKeepsCurrent(t *testing.T) {
+	t.Cleanup(func() { Init(nil) })
+	if err := Init(&config.AuthConfig{Enable: true, APIKeys: []config.APIKeyConfig{{Key: "k1", Caller: "report"}}}); err != nil {
+		t.Fatal(err)
+	}
+	for name, cfg := range map[string]*config.AuthConfig{
+		"no authenticators":      {Enable: true},
+		"duplicate api key":      {Enable: true, APIKeys: []config.APIKeyConfig{{Key: "k", Caller: "a"}, {Key: "k", Caller: "b"}}},
+		"api key without caller": {Enable: true, APIKeys: []config.APIKeyConfig{{Key: "k"}}},
+		"hmac without secret":    {Enable: true, HMAC: &config.HMACConfig{Keys: []config.HMACKeyConfig{{Caller: "a"}}}},
+		"jwt with both keys":     {Enable: true, JWT: &config.JWTConfig{Secret: "s", PublicKeyFile: "key.pem"}},
+	} {
+		if err := Init(cfg); err == nil {
+			t.Errorf("%s: Init accepted an invalid config", name)
+		}
+	}
+	if id, err := Authenticate(incoming(APIKeyHeader, "k1"), testMethod); err != nil || id.Caller != "report" {
+		t.Fatalf("previous config no longer in effect: %+v, %v", id, err)
+	}
+}
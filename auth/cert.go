@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	"stock_service/config"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// certAuth mTLS 客户端证书认证：按证书的 CN 或 SAN（DNS、URI、邮箱）找到对应的调用方。
+// 证书已经在 TLS 握手时由 client_ca_file 校验过，这里只做映射；没有对应调用方的证书视为没有凭证。
+// 经过 HTTP 网关的请求，TLS 连接的对端是网关（使用服务端证书），改用网关转发的 HTTP 客户端证书。
+type certAuth struct {
+	names map[string]*Identity // 证书中的名字 -> 调用方
+}
+
+func newCertAuth(cfgs []config.ClientCertConfig) (*certAuth, error) {
+	a := &certAuth{names: make(map[string]*Identity, len(cfgs))}
+	for i, c := range cfgs {
+		if c.Name == "" {
+			return nil, fmt.Errorf("auth: client cert %d: name is required", i)
+		}
+		if _, ok := a.names[c.Name]; ok {
+			return nil, fmt.Errorf("auth: client cert %d: duplicate name %q", i, c.Name)
+		}
+		caller := c.Caller
+		if caller == "" {
+			caller = c.Name
+		}
+		a.names[c.Name] = &Identity{Caller: caller, Scheme: SchemeMTLS, Roles: c.Roles}
+	}
+	return a, nil
+}
+
+func (a *certAuth) Authenticate(ctx context.Context, _ string, md metadata.MD) (*Identity, error) {
+	cert, err := clientCert(ctx, md)
+	if err != nil || cert == nil {
+		return nil, err
+	}
+	for _, name := range certNames(cert) {
+		if id, ok := a.names[name]; ok {
+			return id, nil
+		}
+	}
+	return nil, nil
+}
+
+// clientCert 返回经过校验的客户端证书，没有时返回 nil
+func clientCert(ctx context.Context, md metadata.MD) (*x509.Certificate, error) {
+	if FromGateway(ctx) {
+		der := first(md, GatewayClientCertHeader)
+		if der == "" {
+			return nil, nil
+		}
+		cert, err := x509.ParseCertificate([]byte(der))
+		if err != nil {
+			return nil, fmt.Errorf("parse gateway client cert: %w", err)
+		}
+		return cert, nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+	return info.State.VerifiedChains[0][0], nil
+}
+
+// certNames 证书中可以对应调用方的名字，依次为 CN、DNS、URI、邮箱
+func certNames(cert *x509.Certificate) []string {
+	names := make([]string, 0, 1+len(cert.DNSNames)+len(cert.URIs)+len(cert.EmailAddresses))
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	names = append(names, cert.DNSNames...)
+	for _, u := range cert.URIs {
+		names = append(names, u.String())
+	}
+	return append(names, cert.EmailAddresses...)
+}
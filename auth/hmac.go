@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stock_service/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// HMACPrefix HMAC 签名的 authorization 前缀，完整格式为 "HMAC-SHA256 <调用方>:<Unix 秒>:<随机数>:<签名>"
+const HMACPrefix = "HMAC-SHA256 "
+
+// ContentDigestHeader 请求消息的 SHA-256（十六进制），包含在签名中
+const ContentDigestHeader = "x-content-sha256"
+
+// defaultMaxSkew 签名时间允许的默认偏差
+const defaultMaxSkew = 5 * time.Minute
+
+// 随机数缓存的后端
+const (
+	ReplayLocal = "local" // 每个实例单独记录
+	ReplayRedis = "redis" // 所有实例共享
+)
+
+// Sign 计算 HMAC 签名：对 "方法名\n调用方\nUnix 秒\n随机数\n请求摘要" 做 HMAC-SHA256，结果为十六进制。
+// 签名包含方法名和请求摘要，截获的签名不能用来调用其他方法或修改请求；随机数只能使用一次，不能重放。
+func Sign(secret, caller, method string, ts int64, nonce, digest string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method + "\n" + caller + "\n" + strconv.FormatInt(ts, 10) + "\n" + nonce + "\n" + digest))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Digest 计算请求消息的摘要：对确定性序列化（map 按 key 排序）的 protobuf 编码做 SHA-256。
+// 同一个版本的 protobuf 库序列化的结果相同，客户端和服务端需要使用相同的 proto 定义。
+func Digest(msg any) (string, error) {
+	var b []byte
+	if m, ok := msg.(proto.Message); ok && m != nil {
+		var err error
+		if b, err = (proto.MarshalOptions{Deterministic: true}).Marshal(m); err != nil {
+			return "", err
+		}
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyContent 校验请求消息与签名中的摘要一致；id 为 nil 或认证方式不签名请求时不做校验
+func VerifyContent(id *Identity, msg any) error {
+	if id == nil || id.digest == "" {
+		return nil
+	}
+	digest, err := Digest(msg)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(digest), []byte(id.digest)) {
+		return ErrContentMismatch
+	}
+	return nil
+}
+
+// ErrContentMismatch 请求消息与签名中的摘要不一致
+var ErrContentMismatch = errors.New("request content does not match the signed digest")
+
+// hmacKey 一个调用方的密钥
+type hmacKey struct {
+	secret string
+	id     Identity
+}
+
+// hmacAuth HMAC 签名认证
+type hmacAuth struct {
+	maxSkew time.Duration
+	keys    map[string]hmacKey // 调用方 -> 密钥
+	replay  replayCache
+}
+
+var (
+	replayMu sync.Mutex
+	// localNonces 本机的随机数缓存，修改认证配置后保留，重新加载配置期间也不能重放
+	localNonces *localReplay
+)
+
+func newHMACAuth(cfg *config.HMACConfig) (*hmacAuth, error) {
+	a := &hmacAuth{maxSkew: cfg.MaxSkew, keys: make(map[string]hmacKey, len(cfg.Keys))}
+	if a.maxSkew <= 0 {
+		a.maxSkew = defaultMaxSkew
+	}
+	switch cfg.ReplayBackend {
+	case "", ReplayLocal:
+		replayMu.Lock()
+		if localNonces == nil {
+			localNonces = newLocalReplay()
+		}
+		a.replay = localNonces
+		replayMu.Unlock()
+	case ReplayRedis:
+		a.replay = redisReplay{}
+	default:
+		return nil, fmt.Errorf("auth: hmac: unknown replay_backend %q", cfg.ReplayBackend)
+	}
+	for i, k := range cfg.Keys {
+		if err := requireCaller("hmac key", i, k.Caller); err != nil {
+			return nil, err
+		}
+		if k.Secret == "" {
+			return nil, fmt.Errorf("auth: hmac key %d: secret is required", i)
+		}
+		if _, ok := a.keys[k.Caller]; ok {
+			return nil, fmt.Errorf("auth: hmac key %d: duplicate caller %q", i, k.Caller)
+		}
+		a.keys[k.Caller] = hmacKey{
+			secret: k.Secret,
+			id:     Identity{Caller: k.Caller, Scheme: SchemeHMAC, Roles: k.Roles},
+		}
+	}
+	return a, nil
+}
+
+// Authenticate 校验签名和随机数。请求消息此时还没有读取，签名中的摘要由 VerifyContent 与请求消息比较
+func (a *hmacAuth) Authenticate(ctx context.Context, method string, md metadata.MD) (*Identity, error) {
+	v := first(md, AuthorizationHeader)
+	if !strings.HasPrefix(v, HMACPrefix) {
+		return nil, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(v, HMACPrefix), ":")
+	if len(parts) != 4 {
+		return nil, errors.New("malformed hmac authorization")
+	}
+	caller, nonce, sig := parts[0], parts[2], parts[3]
+	ts, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("malformed hmac timestamp")
+	}
+	if nonce == "" {
+		return nil, errors.New("missing hmac nonce")
+	}
+	digest := first(md, ContentDigestHeader)
+	if digest == "" {
+		return nil, fmt.Errorf("missing %s", ContentDigestHeader)
+	}
+	if skew := time.Since(time.Unix(ts, 0)); skew > a.maxSkew || skew < -a.maxSkew {
+		return nil, fmt.Errorf("hmac timestamp skew %s exceeds %s", skew.Round(time.Second), a.maxSkew)
+	}
+	k, ok := a.keys[caller]
+	if !ok {
+		return nil, fmt.Errorf("unknown hmac caller %q", caller)
+	}
+	if !hmac.Equal([]byte(sig), []byte(Sign(k.secret, caller, method, ts, nonce, digest))) {
+		return nil, fmt.Errorf("hmac signature mismatch for caller %q", caller)
+	}
+
+	// 签名校验通过后才记录随机数，伪造的请求不占用缓存；时间窗口前后各 maxSkew，
+	// 随机数过期时签名时间也已经超出窗口
+	fresh, err := a.replay.claim(ctx, caller+":"+nonce, 2*a.maxSkew)
+	if err != nil {
+		return nil, fmt.Errorf("check hmac nonce: %w", err)
+	}
+	if !fresh {
+		return nil, fmt.Errorf("hmac nonce replayed by caller %q", caller)
+	}
+
+	id := k.id
+	id.digest = digest
+	return &id, nil
+}
+
+// newNonce 生成随机数
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hmacContext 在请求的 metadata 中加入当前时间、随机数和请求摘要的签名
+func hmacContext(ctx context.Context, caller, secret, method string, req any) (context.Context, error) {
+	digest, err := Digest(req)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := newNonce()
+	if err != nil {
+		return nil, err
+	}
+	ts := time.Now().Unix()
+	v := fmt.Sprintf("%s%s:%d:%s:%s", HMACPrefix, caller, ts, nonce, Sign(secret, caller, method, ts, nonce, digest))
+	return metadata.AppendToOutgoingContext(ctx, AuthorizationHeader, v, ContentDigestHeader, digest), nil
+}
+
+// HMACUnaryClientInterceptor 客户端拦截器，为每个请求签名。
+// 签名包含方法名和请求消息，PerRPCCredentials 拿不到这些信息，所以用拦截器实现：
+//
+//	grpc.WithChainUnaryInterceptor(auth.HMACUnaryClientInterceptor("order_srv", secret))
+func HMACUnaryClientInterceptor(caller, secret string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := hmacContext(ctx, caller, secret, method, req)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// HMACStreamClientInterceptor 流式接口的客户端签名拦截器。
+// 签名需要请求消息，流在发送第一条消息时才建立，签名只覆盖第一条消息（服务端流式接口只有这一条）。
+func HMACStreamClientInterceptor(caller, secret string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &signingStream{ctx: ctx, open: func(m any) (grpc.ClientStream, error) {
+			ctx, err := hmacContext(ctx, caller, secret, method, m)
+			if err != nil {
+				return nil, err
+			}
+			return streamer(ctx, desc, cc, method, opts...)
+		}}, nil
+	}
+}
+
+// errStreamNotOpen 流还没有发送第一条消息
+var errStreamNotOpen = errors.New("auth: hmac stream is not open until the first message is sent")
+
+// signingStream 发送第一条消息时签名并建立流
+type signingStream struct {
+	grpc.ClientStream // 建立之前为 nil
+	ctx               context.Context
+	open              func(m any) (grpc.ClientStream, error)
+}
+
+func (s *signingStream) SendMsg(m any) error {
+	if s.ClientStream == nil {
+		cs, err := s.open(m)
+		if err != nil {
+			return err
+		}
+		s.ClientStream = cs
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *signingStream) Context() context.Context {
+	if s.ClientStream == nil {
+		return s.ctx
+	}
+	return s.ClientStream.Context()
+}
+
+func (s *signingStream) Header() (metadata.MD, error) {
+	if s.ClientStream == nil {
+		return nil, errStreamNotOpen
+	}
+	return s.ClientStream.Header()
+}
+
+func (s *signingStream) Trailer() metadata.MD {
+	if s.ClientStream == nil {
+		return nil
+	}
+	return s.ClientStream.Trailer()
+}
+
+func (s *signingStream) CloseSend() error {
+	if s.ClientStream == nil {
+		return errStreamNotOpen
+	}
+	return s.ClientStream.CloseSend()
+}
+
+func (s *signingStream) RecvMsg(m any) error {
+	if s.ClientStream == nil {
+		return errStreamNotOpen
+	}
+	return s.ClientStream.RecvMsg(m)
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"stock_service/config"
+	"stock_service/dao/redis"
+	"stock_service/errno"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	testMethod = "/stock.v2.Stock/ReduceStock"
+	testSecret = "s3cret"
+)
+
+func initHMAC(t *testing.T, backend string) {
+	t.Helper()
+	t.Cleanup(func() { Init(nil) })
+	err := Init(&config.AuthConfig{
+		Enable: true,
+		HMAC: &config.HMACConfig{
+			MaxSkew:       time.Minute,
+			ReplayBackend: backend,
+			Keys:          []config.HMACKeyConfig{{Caller: "order_srv", Secret: testSecret, Roles: []string{"order"}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// signedContext 返回携带签名的服务端 ctx，签名的方法为 method，签名时间为 ts
+func signedContext(t *testing.T, method string, ts time.Time, nonce string, req any) context.Context {
+	t.Helper()
+	digest, err := Digest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := Sign(testSecret, "order_srv", method, ts.Unix(), nonce, digest)
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		AuthorizationHeader, fmt.Sprintf("%sorder_srv:%d:%s:%s", HMACPrefix, ts.Unix(), nonce, sig),
+		ContentDigestHeader, digest,
+	))
+}
+
+// nonceSeq 每个测试使用不同的随机数，本机的随机数缓存在测试之间共享
+var nonceSeq int
+
+func nextNonce() string {
+	nonceSeq++
+	return fmt.Sprintf("nonce-%d", nonceSeq)
+}
+
+func TestHMACAuthenticate(t *testing.T) {
+	initHMAC(t, ReplayLocal)
+	req := wrapperspb.Int64(1001)
+
+	ctx := signedContext(t, testMethod, time.Now(), nextNonce(), req)
+	id, err := Authenticate(ctx, testMethod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Caller != "order_srv" || id.Scheme != SchemeHMAC || len(id.Roles) != 1 {
+		t.Fatalf("identity = %+v", id)
+	}
+	if err := VerifyContent(id, req); err != nil {
+		t.Fatalf("VerifyContent: %v", err)
+	}
+
+	// 签名后修改了请求消息
+	if err := VerifyContent(id, wrapperspb.Int64(1002)); !errors.Is(err, ErrContentMismatch) {
+		t.Fatalf("VerifyContent with a modified request: %v, want ErrContentMismatch", err)
+	}
+}
+
+func TestHMACRejects(t *testing.T) {
+	initHMAC(t, ReplayLocal)
+	req := wrapperspb.Int64(1001)
+
+	replayed := nextNonce()
+	if _, err := Authenticate(signedContext(t, testMethod, time.Now(), replayed, req), testMethod); err != nil {
+		t.Fatal(err)
+	}
+	tamperedDigest := signedContext(t, testMethod, time.Now(), nextNonce(), req)
+	md, _ := metadata.FromIncomingContext(tamperedDigest)
+	md = md.Copy()
+	md.Set(ContentDigestHeader, strings.Repeat("0", 64))
+
+	for name, ctx := range map[string]context.Context{
+		"replayed nonce":   signedContext(t, testMethod, time.Now(), replayed, req),
+		"old timestamp":    signedContext(t, testMethod, time.Now().Add(-2*time.Minute), nextNonce(), req),
+		"future timestamp": signedContext(t, testMethod, time.Now().Add(2*time.Minute), nextNonce(), req),
+		"other method":     signedContext(t, "/stock.v2.Stock/GetStock", time.Now(), nextNonce(), req),
+		"digest mismatch":  metadata.NewIncomingContext(context.Background(), md),
+		"missing digest": metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			AuthorizationHeader, HMACPrefix+"order_srv:1:n:sig")),
+		"unknown caller": metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			AuthorizationHeader, fmt.Sprintf("%sreport:%d:n:sig", HMACPrefix, time.Now().Unix()),
+			ContentDigestHeader, "d")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			id, err := Authenticate(ctx, testMethod)
+			if !errors.Is(err, errno.ErrUnauthenticated) || id != nil {
+				t.Fatalf("Authenticate = %+v, %v, want ErrUnauthenticated", id, err)
+			}
+		})
+	}
+}
+
+func TestHMACRejectedNonceNotClaimed(t *testing.T) {
+	initHMAC(t, ReplayLocal)
+	req := wrapperspb.Int64(1001)
+	nonce := nextNonce()
+
+	// 签名错误的请求不记录随机数，之后正确签名的请求可以使用
+	if _, err := Authenticate(signedContext(t, "/stock.v2.Stock/GetStock", time.Now(), nonce, req), testMethod); err == nil {
+		t.Fatal("request signed for another method accepted")
+	}
+	if _, err := Authenticate(signedContext(t, testMethod, time.Now(), nonce, req), testMethod); err != nil {
+		t.Fatalf("valid request after a forged one: %v", err)
+	}
+}
+
+func TestHMACRedisReplay(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rc := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rc.Close() })
+	redis.Use(rc, &config.RedisConfig{})
+	initHMAC(t, ReplayRedis)
+	req := wrapperspb.Int64(1001)
+
+	nonce := nextNonce()
+	if _, err := Authenticate(signedContext(t, testMethod, time.Now(), nonce, req), testMethod); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Authenticate(signedContext(t, testMethod, time.Now(), nonce, req), testMethod); err == nil {
+		t.Fatal("replayed nonce accepted")
+	}
+
+	// 随机数在两倍的时间窗口后过期，此时签名时间也已经超出窗口
+	mr.FastForward(2*time.Minute + time.Second)
+	if _, err := Authenticate(signedContext(t, testMethod, time.Now(), nonce, req), testMethod); err != nil {
+		t.Fatalf("nonce after expiry: %v", err)
+	}
+
+	// Redis 不可用时拒绝请求
+	mr.Close()
+	if _, err := Authenticate(signedContext(t, testMethod, time.Now(), nextNonce(), req), testMethod); err == nil {
+		t.Fatal("request accepted with Redis down")
+	}
+}
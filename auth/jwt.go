@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"stock_service/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+)
+
+// BearerPrefix JWT 的 authorization 前缀
+const BearerPrefix = "Bearer "
+
+// 默认的 claim
+const (
+	defaultCallerClaim = "sub"
+	defaultRolesClaim  = "roles"
+)
+
+// jwtAuth JWT 认证，要求 exp；配置了 issuer、audience 时同时校验
+type jwtAuth struct {
+	key         any
+	parser      *jwt.Parser
+	callerClaim string
+	rolesClaim  string
+}
+
+func newJWTAuth(cfg *config.JWTConfig) (*jwtAuth, error) {
+	a := &jwtAuth{callerClaim: cfg.CallerClaim, rolesClaim: cfg.RolesClaim}
+	if a.callerClaim == "" {
+		a.callerClaim = defaultCallerClaim
+	}
+	if a.rolesClaim == "" {
+		a.rolesClaim = defaultRolesClaim
+	}
+
+	var methods []string
+	switch {
+	case cfg.Secret != "" && cfg.PublicKeyFile != "":
+		return nil, errors.New("auth: jwt: secret and public_key_file are mutually exclusive")
+	case cfg.Secret != "":
+		a.key = []byte(cfg.Secret)
+		methods = []string{jwt.SigningMethodHS256.Alg()}
+	default:
+		key, err := loadPublicKey(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		a.key = key
+		methods = []string{
+			jwt.SigningMethodRS256.Alg(), jwt.SigningMethodPS256.Alg(),
+			jwt.SigningMethodES256.Alg(), jwt.SigningMethodEdDSA.Alg(),
+		}
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	a.parser = jwt.NewParser(opts...)
+	return a, nil
+}
+
+// loadPublicKey 读取 PEM 格式的 RSA、ECDSA 或 Ed25519 公钥
+func loadPublicKey(file string) (crypto.PublicKey, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("auth: jwt: %w", err)
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("auth: jwt: unsupported public key in %s", file)
+}
+
+func (a *jwtAuth) Authenticate(_ context.Context, _ string, md metadata.MD) (*Identity, error) {
+	v := first(md, AuthorizationHeader)
+	if !strings.HasPrefix(v, BearerPrefix) {
+		return nil, nil
+	}
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(strings.TrimPrefix(v, BearerPrefix), claims, func(*jwt.Token) (any, error) {
+		return a.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	caller, _ := claims[a.callerClaim].(string)
+	if caller == "" {
+		return nil, fmt.Errorf("jwt claim %q is missing", a.callerClaim)
+	}
+	return &Identity{Caller: caller, Scheme: SchemeJWT, Roles: roles(claims[a.rolesClaim])}, nil
+}
+
+// roles 解析角色 claim，支持字符串列表和空格分隔的字符串（OAuth2 scope 的格式）
+func roles(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, r := range v {
+			if s, ok := r.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
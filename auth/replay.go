@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"stock_service/dao/redis"
+)
+
+// replayCache 记录签名时间窗口内用过的随机数，拒绝重放的请求
+type replayCache interface {
+	// claim 第一次使用 nonce 时返回 true，ttl 内再次使用时返回 false
+	claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// maxLocalNonces 本机记录的随机数超过这个值时清理已经过期的
+const maxLocalNonces = 100000
+
+// localReplay 本机的随机数缓存，只能拒绝发给同一个实例的重放请求
+type localReplay struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time // 随机数 -> 过期时间
+}
+
+func newLocalReplay() *localReplay {
+	return &localReplay{nonces: make(map[string]time.Time)}
+}
+
+func (c *localReplay) claim(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if exp, ok := c.nonces[nonce]; ok && now.Before(exp) {
+		return false, nil
+	}
+	if len(c.nonces) >= maxLocalNonces {
+		for k, exp := range c.nonces {
+			if !now.Before(exp) {
+				delete(c.nonces, k)
+			}
+		}
+	}
+	c.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// redisReplay 所有实例共享的随机数缓存，Redis 不可用时拒绝请求
+type redisReplay struct{}
+
+func (redisReplay) claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return redis.ClaimNonce(ctx, nonce, ttl)
+}
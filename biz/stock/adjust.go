@@ -0,0 +1,18 @@
+package stock
+
+import (
+	"context"
+
+	"stock_service/dao/mysql"
+	"stock_service/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AdjustStock 按增量调整库存数量，返回调整后的库存
+func AdjustStock(ctx context.Context, goodsId, delta int64, reason, operator string) (res *Result, err error) {
+	ctx, span := startSpan(ctx, "AdjustStock", goodsAttr(goodsId), attribute.Int64("stock.delta", delta))
+	defer func() { tracing.End(span, err) }()
+	data, err := mysql.AdjustStock(ctx, goodsId, delta, reason, operator)
+	return newResult(ctx, goodsId, 0, data, nil, err)
+}
@@ -0,0 +1,74 @@
+package stock
+
+import (
+	"context"
+	"errors"
+
+	"stock_service/dao/mysql"
+	"stock_service/dao/redis"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+	"stock_service/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// rememberMiss 查询结果为商品不存在时写入缓存，vers 为查询前读取的版本号
+func rememberMiss(ctx context.Context, err error, vers redis.MissVersions, goodsIds ...int64) {
+	if !errors.Is(err, errno.ErrQueryEmpty) {
+		return
+	}
+	if err := redis.SetStockMiss(ctx, vers, goodsIds...); err != nil {
+		logger.Ctx(ctx).Warn("SetStockMiss failed", zap.Int64s("goods_ids", goodsIds), zap.Error(err))
+	}
+}
+
+// forgetMiss 库存创建或恢复后删除不存在的缓存
+func forgetMiss(ctx context.Context, goodsId int64) {
+	if err := redis.DelStockMiss(ctx, goodsId); err != nil {
+		logger.Ctx(ctx).Warn("DelStockMiss failed", zap.Int64("goods_id", goodsId), zap.Error(err))
+	}
+}
+
+// BatchGetStock 批量查询库存，按请求顺序返回，不存在的商品对应的元素为 nil
+func BatchGetStock(ctx context.Context, goodsIds []int64) (_ []*model.Stock, err error) {
+	ctx, span := startSpan(ctx, "BatchGetStock", attribute.Int("stock.goods_count", len(goodsIds)))
+	defer func() { tracing.End(span, err) }()
+	// 已缓存为不存在的商品不再查询数据库
+	misses, vers := redis.StockMisses(ctx, goodsIds)
+	query := make([]int64, 0, len(goodsIds))
+	for _, id := range goodsIds {
+		if !misses[id] {
+			query = append(query, id)
+		}
+	}
+
+	found := make(map[int64]*model.Stock, len(query))
+	if len(query) > 0 {
+		list, err := mysql.GetStocksByGoodsIds(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range list {
+			found[s.GoodsId] = s
+		}
+
+		var unknown []int64
+		for _, id := range query {
+			if _, ok := found[id]; !ok {
+				unknown = append(unknown, id)
+			}
+		}
+		if len(unknown) > 0 {
+			rememberMiss(ctx, errno.ErrQueryEmpty, vers, unknown...)
+		}
+	}
+
+	data := make([]*model.Stock, len(goodsIds))
+	for i, id := range goodsIds {
+		data[i] = found[id]
+	}
+	return data, nil
+}
@@ -0,0 +1,68 @@
+package stock
+
+import (
+	"context"
+
+	"stock_service/dao/mysql"
+	"stock_service/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CheckItem 预检的商品和数量
+type CheckItem struct {
+	GoodsId int64
+	Num     int64
+}
+
+// Verdict 单个商品的预检结果
+type Verdict struct {
+	CheckItem
+	Err       error // 扣减会失败的原因，nil 表示可以扣减
+	Available int64 // 可用库存，库存不存在时为 0
+	Shortfall int64 // 还差的数量，可以扣减时为 0
+}
+
+// CheckStock 预检一组商品能否扣减，不加分布式锁也不修改任何数据，
+// 校验规则与 ReduceStock 相同（mysql.ReduceRules），buyer 用于限购和直播间配额的校验。
+// 同一个商品出现多次时按累计数量校验；结果只反映查询时刻的库存，不保证随后的扣减一定成功。
+func CheckStock(ctx context.Context, items []CheckItem, buyer mysql.Buyer) (_ []*Verdict, err error) {
+	ctx, span := startSpan(ctx, "CheckStock", attribute.Int("stock.goods_count", len(items)))
+	defer func() { tracing.End(span, err) }()
+	goodsIds := make([]int64, len(items))
+	for i, item := range items {
+		goodsIds[i] = item.GoodsId
+	}
+	stocks, err := BatchGetStock(ctx, goodsIds)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := mysql.LoadReduceRules(ctx, goodsIds, buyer)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[int64]int64, len(items))
+	verdicts := make([]*Verdict, len(items))
+	for i, item := range items {
+		s := stocks[i]
+		// 数量不合法的项单独校验（返回参数错误），不计入累计数量，否则负数会抵消同一商品的其他项
+		num := item.Num
+		if num > 0 {
+			requested[item.GoodsId] += num
+			num = requested[item.GoodsId]
+		}
+
+		v := &Verdict{CheckItem: item}
+		v.Err = rules.Check(s, item.GoodsId, num)
+		if s != nil {
+			v.Available = s.StockNum
+		}
+		if v.Err != nil && item.Num > 0 {
+			// 前面的同一商品已经用掉的数量不再计入
+			v.Shortfall = min(item.Num, requested[item.GoodsId]-rules.Allowance(s, item.GoodsId))
+		}
+		verdicts[i] = v
+	}
+	return verdicts, nil
+}
@@ -0,0 +1,32 @@
+package stock
+
+import (
+	"stock_service/model"
+	"stock_service/proto"
+)
+
+// model -> proto 的转换
+
+// toStockDetail 将库存 model 转换为 proto.StockDetail
+func toStockDetail(s *model.Stock) *proto.StockDetail {
+	return &proto.StockDetail{
+		GoodsId:   s.GoodsId,
+		Stock:     s.StockNum,
+		Lock:      s.Lock,
+		UpdateAt:  s.UpdateAt.Unix(),
+		Available: s.StockNum, // 预扣时已从 stocknum 中减去
+	}
+}
+
+// toStockRecordInfo 将库存记录 model 转换为 proto.StockRecordInfo
+func toStockRecordInfo(r *model.StockRecord) *proto.StockRecordInfo {
+	return &proto.StockRecordInfo{
+		Id:       int64(r.ID),
+		OrderId:  r.OrderId,
+		GoodsId:  r.GoodsId,
+		Num:      r.Num,
+		Status:   proto.ReservationStatus(r.Status),
+		CreateAt: r.CreateAt.Unix(),
+		UpdateAt: r.UpdateAt.Unix(),
+	}
+}
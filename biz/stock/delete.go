@@ -0,0 +1,37 @@
+package stock
+
+import (
+	"context"
+	"time"
+
+	"stock_service/dao/mysql"
+	"stock_service/tracing"
+)
+
+// DeleteStock 软删除商品库存
+func DeleteStock(ctx context.Context, goodsId int64) (res *Result, err error) {
+	ctx, span := startSpan(ctx, "DeleteStock", goodsAttr(goodsId))
+	defer func() { tracing.End(span, err) }()
+	data, err := mysql.DeleteStock(ctx, goodsId)
+	return newResult(ctx, goodsId, 0, data, nil, err)
+}
+
+// UndeleteStock 恢复已删除的商品库存
+func UndeleteStock(ctx context.Context, goodsId int64) (res *Result, err error) {
+	ctx, span := startSpan(ctx, "UndeleteStock", goodsAttr(goodsId))
+	defer func() { tracing.End(span, err) }()
+	data, err := mysql.UndeleteStock(ctx, goodsId)
+	res, err = newResult(ctx, goodsId, 0, data, nil, err)
+	if err != nil {
+		return nil, err
+	}
+	forgetMiss(ctx, goodsId)
+	return res, nil
+}
+
+// PurgeDeletedStock 物理删除已软删除超过 retention 的库存
+func PurgeDeletedStock(ctx context.Context, retention time.Duration) (n int64, err error) {
+	ctx, span := startSpan(ctx, "PurgeDeletedStock")
+	defer func() { tracing.End(span, err) }()
+	return mysql.PurgeDeletedStock(ctx, time.Now().Add(-retention))
+}
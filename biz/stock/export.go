@@ -0,0 +1,39 @@
+package stock
+
+import (
+	"context"
+	"time"
+
+	"stock_service/dao/mysql"
+	"stock_service/model"
+	"stock_service/proto"
+	"stock_service/tracing"
+)
+
+// ExportStock 导出库存快照，每导出一条就调用一次 send
+func ExportStock(ctx context.Context, req *proto.ExportStockReq, send func(*proto.ExportStockItem) error) (err error) {
+	ctx, span := startSpan(ctx, "ExportStock")
+	defer func() { tracing.End(span, err) }()
+	f := mysql.ExportFilter{
+		MinGoodsId:  req.GetMinGoodsId(),
+		MaxGoodsId:  req.GetMaxGoodsId(),
+		OnlyNonZero: req.GetOnlyNonZero(),
+		WithRecords: req.GetWithRecords(),
+	}
+	if req.GetUpdatedSince() > 0 {
+		f.UpdatedSince = time.Unix(req.GetUpdatedSince(), 0)
+	}
+
+	return mysql.ExportStock(ctx, f,
+		func(s *model.Stock) error {
+			return send(&proto.ExportStockItem{
+				Item: &proto.ExportStockItem_Stock{Stock: toStockDetail(s)},
+			})
+		},
+		func(r *model.StockRecord) error {
+			return send(&proto.ExportStockItem{
+				Item: &proto.ExportStockItem_Record{Record: toStockRecordInfo(r)},
+			})
+		},
+	)
+}
@@ -0,0 +1,99 @@
+package stock
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"stock_service/dao/mysql"
+	"stock_service/errno"
+	"stock_service/proto"
+	"stock_service/tracing"
+)
+
+// listCursor 游标内容，包含排序方式以免翻页时被替换
+type listCursor struct {
+	Sort proto.StockSortField `json:"s"`
+	Desc bool                 `json:"d"`
+	Key  int64                `json:"k"`
+	ID   uint                 `json:"i"`
+}
+
+func encodeCursor(c listCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (*listCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errno.ErrInvalidCursor
+	}
+	var c listCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, errno.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// ListStock 按条件游标分页查询库存
+func ListStock(ctx context.Context, req *proto.ListStockReq) (_ *proto.ListStockResp, err error) {
+	ctx, span := startSpan(ctx, "ListStock")
+	defer func() { tracing.End(span, err) }()
+	f := mysql.ListStockFilter{
+		LockedOnly: req.GetLockedOnly(),
+		GoodsIds:   req.GetGoodsIds(),
+	}
+	if req.AvailableBelow != nil {
+		below := req.GetAvailableBelow()
+		f.AvailableBelow = &below
+	}
+	if req.GetUpdatedSince() > 0 {
+		f.UpdatedSince = time.Unix(req.GetUpdatedSince(), 0)
+	}
+
+	var after *mysql.StockCursor
+	if req.GetCursor() != "" {
+		c, err := decodeCursor(req.GetCursor())
+		if err != nil {
+			return nil, err
+		}
+		if c.Sort != req.GetSortBy() || c.Desc != req.GetDesc() {
+			return nil, errno.ErrInvalidCursor
+		}
+		after = &mysql.StockCursor{Key: c.Key, ID: c.ID}
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	sort := mysql.StockSort(req.GetSortBy())
+	// 多查一条用来判断是否还有下一页
+	list, err := mysql.ListStock(ctx, f, sort, req.GetDesc(), after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ListStockResp{}
+	if len(list) > pageSize {
+		list = list[:pageSize]
+		last := list[len(list)-1]
+		resp.NextCursor = encodeCursor(listCursor{
+			Sort: req.GetSortBy(),
+			Desc: req.GetDesc(),
+			Key:  sort.Key(last),
+			ID:   last.ID,
+		})
+	}
+	resp.Data = make([]*proto.StockDetail, 0, len(list))
+	for _, s := range list {
+		resp.Data = append(resp.Data, toStockDetail(s))
+	}
+	return resp, nil
+}
@@ -0,0 +1,71 @@
+package stock
+
+import (
+	"context"
+	"errors"
+
+	"stock_service/dao/mysql"
+	"stock_service/errno"
+	"stock_service/model"
+)
+
+// 修改库存的公共逻辑：幂等键和修改结果
+
+// Result 修改库存的结果
+type Result struct {
+	Stock    *model.Stock       // 修改后的库存，库存已删除等查不到时为 nil
+	Record   *model.StockRecord // 关联的库存记录，没有时为 nil
+	Replayed bool               // 是否为重复请求，重复请求不再修改库存
+	Response []byte             // 重复请求时首次请求保存的响应，没有保存时为 nil，此时 Stock 和 Record 为当前的值
+}
+
+// WithIdempotencyKey 返回带有幂等键的 ctx，同一方法相同幂等键的修改只执行一次。
+// 幂等键复用消息去重的消费记录表，op 为方法名，requestHash 为请求内容的哈希，
+// 相同幂等键的请求内容不同时返回 ErrIdempotencyKeyReused。key 为空时不做幂等控制。
+func WithIdempotencyKey(ctx context.Context, op, key, requestHash string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return mysql.WithIdempotencyKey(ctx, key, "rpc:"+op, requestHash)
+}
+
+// SaveResponse 保存首次请求的响应，相同幂等键的重复请求返回这个响应
+func SaveResponse(ctx context.Context, resp []byte) error {
+	return mysql.SaveResponse(ctx, resp)
+}
+
+// newResult 根据 dao 的返回值构造修改结果：重复请求视为成功，有保存的响应时直接返回；
+// dao 没有返回库存或库存记录时（重复请求、记录已处理过等），查询当前的值补全。
+func newResult(ctx context.Context, goodsId, orderId int64, s *model.Stock, r *model.StockRecord, err error) (*Result, error) {
+	replayed := errors.Is(err, errno.ErrDuplicateMessage)
+	if err != nil && !replayed {
+		return nil, err
+	}
+
+	res := &Result{Stock: s, Record: r, Replayed: replayed}
+	if replayed {
+		resp, err := mysql.GetResponse(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if resp != nil {
+			res.Response = resp
+			return res, nil
+		}
+	}
+	if res.Stock == nil {
+		s, err := mysql.GetStockByGoodsId(ctx, goodsId)
+		if err != nil && !errors.Is(err, errno.ErrQueryEmpty) {
+			return nil, err
+		}
+		res.Stock = s
+	}
+	if res.Record == nil && orderId > 0 {
+		r, err := mysql.GetRecord(ctx, orderId, goodsId)
+		if err != nil && !errors.Is(err, errno.ErrQueryEmpty) {
+			return nil, err
+		}
+		res.Record = r
+	}
+	return res, nil
+}
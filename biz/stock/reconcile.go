@@ -0,0 +1,46 @@
+package stock
+
+import (
+	"context"
+
+	"stock_service/dao/mysql"
+	"stock_service/logger"
+	"stock_service/proto"
+	"stock_service/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// ReconcileStock 核对预扣库存与状态为 1 的库存记录，repair 为 true 时逐个修复不一致的商品。
+// 单个商品修复失败不影响其他商品，该商品在结果中 repaired 为 false。
+func ReconcileStock(ctx context.Context, goodsIds []int64, repair bool, operator string) (_ []*proto.LockMismatch, err error) {
+	ctx, span := startSpan(ctx, "ReconcileStock", attribute.Bool("stock.repair", repair))
+	defer func() { tracing.End(span, err) }()
+	list, err := mysql.FindLockMismatches(ctx, goodsIds)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*proto.LockMismatch, 0, len(list))
+	for _, m := range list {
+		if repair {
+			fixed, err := mysql.RepairLock(ctx, m.GoodsId, operator)
+			if err != nil {
+				logger.Ctx(ctx).Error("RepairLock failed", zap.Int64("goods_id", m.GoodsId), zap.Error(err))
+			} else if fixed == nil {
+				// 重新计算后已一致（期间有并发扣减或回滚），无需上报
+				continue
+			} else {
+				m = fixed
+			}
+		}
+		resp = append(resp, &proto.LockMismatch{
+			GoodsId:      m.GoodsId,
+			Lock:         m.Lock,
+			ExpectedLock: m.ExpectedLock,
+			Repaired:     m.Repaired,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package stock
+
+import (
+	"context"
+	"time"
+
+	"stock_service/config"
+	"stock_service/dao/mysql"
+	"stock_service/dao/redis"
+	"stock_service/logger"
+	"stock_service/metrics"
+	"stock_service/model"
+	"stock_service/tracing"
+
+	"go.uber.org/zap"
+)
+
+// 超时自动释放：扣减成功后往延迟队列里放一个释放任务，
+// 订单确认或回滚时删除任务，到期仍未处理的由 job.RunReleaseWorkers 释放库存。
+
+// scheduleRelease 添加超时释放任务，未启用时不做处理。
+// seconds 大于 0 时覆盖配置的默认超时时间。
+func scheduleRelease(ctx context.Context, goodsId, num, orderId, seconds int64) {
+	cfg := config.Conf.ReleaseConfig
+	if cfg == nil || !cfg.Enable {
+		return
+	}
+	timeout := cfg.Timeout
+	if seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	if timeout <= 0 {
+		return
+	}
+
+	job := redis.ReleaseJob{OrderId: orderId, GoodsId: goodsId, Num: num}
+	if err := redis.AddReleaseJob(ctx, job, time.Now().Add(timeout)); err != nil {
+		// 库存已扣减成功，这里只记录日志，由订单服务回滚或对账兜底
+		logger.Ctx(ctx).Error("AddReleaseJob failed", zap.Any("job", job), zap.Error(err))
+	}
+}
+
+// cancelRelease 删除超时释放任务
+func cancelRelease(ctx context.Context, goodsId, num, orderId int64) {
+	job := redis.ReleaseJob{OrderId: orderId, GoodsId: goodsId, Num: num}
+	if err := redis.RemoveReleaseJob(ctx, job); err != nil {
+		// 任务到期后释放时会因为记录状态不是 1 而跳过，不影响正确性
+		logger.Ctx(ctx).Warn("RemoveReleaseJob failed", zap.Any("job", job), zap.Error(err))
+	}
+}
+
+// ConfirmStock 确认扣减库存，并取消超时释放任务
+func ConfirmStock(ctx context.Context, goodsId, orderId int64) (res *Result, err error) {
+	ctx, span := startSpan(ctx, "ConfirmStock", goodsAttr(goodsId), orderAttr(orderId))
+	defer func() { tracing.End(span, err) }()
+	data, record, err := mysql.ConfirmStock(ctx, goodsId, orderId)
+	// 重复确认时库存为 nil，不计入指标
+	if err == nil && data != nil && record != nil {
+		metrics.AddUnits(metrics.EventConfirmed, goodsId, record.Num)
+	}
+	res, err = newResult(ctx, goodsId, orderId, data, record, err)
+	if err != nil {
+		return nil, err
+	}
+	if res.Record != nil {
+		cancelRelease(ctx, goodsId, res.Record.Num, orderId)
+	}
+	return res, nil
+}
+
+// ReleaseExpired 释放超时未确认的库存，成功后删除任务
+func ReleaseExpired(ctx context.Context, job redis.ReleaseJob) (err error) {
+	ctx, span := startSpan(ctx, "ReleaseExpired", goodsAttr(job.GoodsId), orderAttr(job.OrderId))
+	defer func() { tracing.End(span, err) }()
+	_, record, err := mysql.ReleaseStock(ctx, model.StockRecord{
+		GoodsId: job.GoodsId,
+		Num:     job.Num,
+		OrderId: job.OrderId,
+	})
+	if err != nil {
+		return err
+	}
+	if record != nil {
+		metrics.AddUnits(metrics.EventReleased, job.GoodsId, record.Num)
+	}
+	return redis.RemoveReleaseJob(ctx, job)
+}
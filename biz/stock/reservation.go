@@ -0,0 +1,71 @@
+package stock
+
+import (
+	"context"
+	"time"
+
+	"stock_service/dao/mysql"
+	"stock_service/model"
+	"stock_service/proto"
+	"stock_service/tracing"
+)
+
+const (
+	defaultPageSize = 20  // 默认每页条数
+	maxPageSize     = 100 // 每页最大条数
+)
+
+// GetOrderReservations 查询订单的库存预占记录
+func GetOrderReservations(ctx context.Context, orderId int64) (_ *proto.ReservationList, err error) {
+	ctx, span := startSpan(ctx, "GetOrderReservations", orderAttr(orderId))
+	defer func() { tracing.End(span, err) }()
+	list, err := mysql.GetRecordsByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	return toReservationList(list, int64(len(list))), nil
+}
+
+// ListReservations 分页查询库存预占记录
+func ListReservations(ctx context.Context, req *proto.ListReservationsReq) (_ *proto.ReservationList, err error) {
+	ctx, span := startSpan(ctx, "ListReservations")
+	defer func() { tracing.End(span, err) }()
+	f := mysql.RecordFilter{
+		GoodsId: req.GetGoodsId(),
+		Status:  int32(req.GetStatus()),
+	}
+	if req.GetStartTime() > 0 {
+		f.StartTime = time.Unix(req.GetStartTime(), 0)
+	}
+	if req.GetEndTime() > 0 {
+		f.EndTime = time.Unix(req.GetEndTime(), 0)
+	}
+
+	page, pageSize := int(req.GetPage()), int(req.GetPageSize())
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	list, total, err := mysql.ListRecords(ctx, f, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return toReservationList(list, total), nil
+}
+
+func toReservationList(list []*model.StockRecord, total int64) *proto.ReservationList {
+	resp := &proto.ReservationList{
+		Data:  make([]*proto.StockRecordInfo, 0, len(list)),
+		Total: total,
+	}
+	for _, r := range list {
+		resp.Data = append(resp.Data, toStockRecordInfo(r))
+	}
+	return resp
+}
@@ -2,56 +2,114 @@ package stock
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"stock_service/dao/mysql"
+	"stock_service/dao/redis"
 	"stock_service/errno"
-	"stock_service/proto"
+	"stock_service/metrics"
+	"stock_service/model"
+	"stock_service/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // biz层业务代码
 // biz -> dao
 
 // SetStock 设置库存
-func SetStock(ctx context.Context, goodsId, num int64) (*proto.Response, error) {
+func SetStock(ctx context.Context, goodsId, num int64) (res *Result, err error) {
+	ctx, span := startSpan(ctx, "SetStock", goodsAttr(goodsId), attribute.Int64("stock.num", num))
+	defer func() { tracing.End(span, err) }()
 	// 1. 调用 mysql 包中的 SetStock 方法，设置库存数量
-	err := mysql.SetStock(ctx, goodsId, num)
+	data, err := mysql.SetStock(ctx, goodsId, num)
+	res, err = newResult(ctx, goodsId, 0, data, nil, err)
 	if err != nil {
-		// 如果设置库存失败，返回错误
-		return nil, errno.ErrSetstockFailed
+		// 如果设置库存失败，返回错误（库存已删除时需要先恢复）
+		return nil, errno.Or(err, errno.ErrSetstockFailed)
 	}
-	// 2. 设置成功，返回 nil 表示操作成功
-	return nil, nil
+	// 2. 库存可能是新创建的，删除不存在的缓存
+	forgetMiss(ctx, goodsId)
+	// 3. 设置成功，返回设置后的库存
+	return res, nil
 }
 
 // GetStockByGoodsId 根据商品 ID 查询库存信息。
-func GetStockByGoodsId(ctx context.Context, goodsId int64) (*proto.GoodsStockInfo, error) {
-	// 1. 先去 xx_stock 表根据 goods_id 查询出库存数
+// 商品不存在时返回 ErrQueryEmpty，并缓存一段时间，期间的查询不再访问数据库。
+func GetStockByGoodsId(ctx context.Context, goodsId int64) (_ *model.Stock, err error) {
+	ctx, span := startSpan(ctx, "GetStockByGoodsId", goodsAttr(goodsId))
+	defer func() { tracing.End(span, err) }()
+	// 1. 已缓存为不存在的商品直接返回
+	miss, vers := redis.IsStockMiss(ctx, goodsId)
+	if miss {
+		return nil, errno.ErrQueryEmpty.WithMeta("goods_id", goodsId)
+	}
+
+	// 2. 去 xx_stock 表根据 goods_id 查询出库存数
 	// 调用 mysql 包中的 GetStockByGoodsId 方法，从数据库中查询库存信息。
 	data, err := mysql.GetStockByGoodsId(ctx, goodsId)
 	if err != nil {
-		// 如果查询失败，返回错误。
+		// 如果查询失败，返回错误；商品不存在时写入缓存。
+		rememberMiss(ctx, err, vers, goodsId)
 		return nil, err
 	}
-
-	// 2. 处理数据
-	// 将查询结果封装到 Protobuf 消息 GoodsStockInfo 中。
-	resp := &proto.GoodsStockInfo{
-		GoodsId: data.GoodsId,  // 设置商品 ID
-		Stock:   data.StockNum, // 设置库存数量
-	}
-
-	// 返回封装好的 Protobuf 消息和 nil 错误。
-	return resp, nil
+	return data, nil
 }
 
 // 分布式程序中，本机加锁只能保证这一台机器不会并发修改数据，不能保证别的机器
 // 批量扣减库存要用到事务，比如a买10件，b买15件这种业务场景
-func ReduceStock(ctx context.Context, goodsId, num, orderId int64) (*proto.Response, error) {
+// releaseAfter 为超时未确认自动释放的秒数，0 表示使用服务端配置；buyer 用于限购和直播间配额的校验
+func ReduceStock(ctx context.Context, goodsId, num, orderId, releaseAfter int64, buyer mysql.Buyer) (res *Result, err error) {
+	ctx, span := startSpan(ctx, "ReduceStock", goodsAttr(goodsId), orderAttr(orderId), attribute.Int64("stock.num", num))
+	defer func() { tracing.End(span, err) }()
+	// 已缓存为不存在的商品直接返回，不去抢分布式锁
+	miss, vers := redis.IsStockMiss(ctx, goodsId)
+	if miss {
+		return nil, errno.ErrQueryEmpty.WithMeta("goods_id", goodsId)
+	}
+
 	// 数据层返回的model数据
-	data, err := mysql.ReduceStock(ctx, goodsId, num, orderId)
-	fmt.Printf("data:%v err:%v\n", data, err)
+	data, record, err := mysql.ReduceStock(ctx, goodsId, num, orderId, buyer)
+	// 业务指标：只统计实际扣减的请求，重复请求不计入
+	if err == nil {
+		metrics.AddUnits(metrics.EventReserved, goodsId, num)
+	} else if errors.Is(err, errno.ErrUnderstock) {
+		metrics.IncUnderstock(goodsId)
+	}
+	res, err = newResult(ctx, goodsId, orderId, data, record, err)
+	if err != nil {
+		rememberMiss(ctx, err, vers, goodsId)
+		// 库存不足、商品不存在、数据库故障等分别返回对应的业务错误
+		return nil, errno.Or(err, errno.ErrReducestockFailed)
+	}
+	// 超时未确认自动释放，重复请求不再重复添加
+	if !res.Replayed {
+		scheduleRelease(ctx, goodsId, num, orderId, releaseAfter)
+	}
+	return res, nil
+}
+
+// RollbackStock 回滚订单预扣的库存，RPC 和 MQ 消费者共用这一逻辑
+// 库存记录不是预扣状态（已回滚、已确认等）时不做修改，返回当前的库存和记录。
+// 回滚的数量以库存记录为准，num 为 0 时回滚整条记录，与记录不一致时返回 ErrInvalidParam。
+func RollbackStock(ctx context.Context, goodsId, num, orderId int64) (res *Result, err error) {
+	ctx, span := startSpan(ctx, "RollbackStock", goodsAttr(goodsId), orderAttr(orderId), attribute.Int64("stock.num", num))
+	defer func() { tracing.End(span, err) }()
+	data, record, err := mysql.RollbackStockByMsg(ctx, model.StockRecord{
+		GoodsId: goodsId,
+		Num:     num,
+		OrderId: orderId,
+	})
+	// 没有待回滚的记录时 record 为 nil，不计入指标
+	if err == nil && record != nil {
+		metrics.AddUnits(metrics.EventRolledBack, goodsId, record.Num)
+	}
+	res, err = newResult(ctx, goodsId, orderId, data, record, err)
 	if err != nil {
-		return nil, errno.ErrUnderstock
+		return nil, errno.Or(err, errno.ErrRollbackstockFailed)
+	}
+	// 超时释放任务按预扣时的数量添加，取消时也使用记录中的数量
+	if res.Record != nil {
+		cancelRelease(ctx, goodsId, res.Record.Num, orderId)
 	}
-	return &proto.Response{}, nil
+	return res, nil
 }
@@ -0,0 +1,28 @@
+package stock
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// biz 层的每个导出函数创建一个 span，名称为 stock.<函数名>，结束时由 tracing.End 记录错误
+
+var tracer = otel.Tracer("stock_service/biz/stock")
+
+// startSpan 创建 biz 层调用的 span
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "stock."+name, trace.WithAttributes(attrs...))
+}
+
+// goodsAttr 商品ID属性
+func goodsAttr(goodsId int64) attribute.KeyValue {
+	return attribute.Int64("stock.goods_id", goodsId)
+}
+
+// orderAttr 订单ID属性
+func orderAttr(orderId int64) attribute.KeyValue {
+	return attribute.Int64("stock.order_id", orderId)
+}
@@ -0,0 +1,133 @@
+// Package breaker MySQL、Redis 的熔断器：连续出错或失败率过高时熔断，熔断期间的调用直接返回 ErrUnavailable，
+// 不再排队等待连接池和超时；熔断一段时间后进入半开状态，放行少量探测请求，探测成功后恢复。
+// 熔断器的状态通过 Prometheus 指标 stock_breaker_state 导出。
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"stock_service/config"
+	"stock_service/errno"
+
+	"github.com/sony/gobreaker/v2"
+	"go.uber.org/zap"
+)
+
+// settings 熔断参数，Init 之后创建的熔断器使用
+var settings = config.BreakerConfig{
+	ConsecutiveFailures: 5,
+	MinRequests:         20,
+	FailureRatio:        0.5,
+	Interval:            10 * time.Second,
+	OpenTimeout:         5 * time.Second,
+	HalfOpenRequests:    3,
+}
+
+var (
+	mu       sync.Mutex
+	breakers []*Breaker // 已创建的熔断器，用于导出状态
+)
+
+// Init 设置熔断参数，需要在初始化 MySQL 和 Redis 之前调用；cfg 为 nil 或未启用时不熔断
+func Init(cfg *config.BreakerConfig) {
+	if cfg == nil {
+		return
+	}
+	settings.Enable = cfg.Enable
+	if cfg.ConsecutiveFailures > 0 {
+		settings.ConsecutiveFailures = cfg.ConsecutiveFailures
+	}
+	if cfg.MinRequests > 0 {
+		settings.MinRequests = cfg.MinRequests
+	}
+	if cfg.FailureRatio > 0 {
+		settings.FailureRatio = cfg.FailureRatio
+	}
+	if cfg.Interval > 0 {
+		settings.Interval = cfg.Interval
+	}
+	if cfg.OpenTimeout > 0 {
+		settings.OpenTimeout = cfg.OpenTimeout
+	}
+	if cfg.HalfOpenRequests > 0 {
+		settings.HalfOpenRequests = cfg.HalfOpenRequests
+	}
+}
+
+// Breaker 一个依赖的熔断器，未启用熔断时为 nil，nil 的方法都直接放行
+type Breaker struct {
+	name string
+	cb   *gobreaker.TwoStepCircuitBreaker[struct{}]
+}
+
+// New 创建名为 name 的熔断器，isFailure 判断调用返回的错误是否说明依赖出了问题
+// （连接失败、超时等；查不到数据、唯一键冲突等说明依赖是正常的）。ctx 取消导致的错误不计入统计。
+func New(name string, isFailure func(error) bool) *Breaker {
+	if !settings.Enable {
+		return nil
+	}
+	s := settings
+	b := &Breaker{name: name}
+	b.cb = gobreaker.NewTwoStepCircuitBreaker[struct{}](gobreaker.Settings{
+		Name:        name,
+		MaxRequests: s.HalfOpenRequests,
+		Interval:    s.Interval,
+		Timeout:     s.OpenTimeout,
+		ReadyToTrip: func(c gobreaker.Counts) bool {
+			if c.ConsecutiveFailures >= s.ConsecutiveFailures {
+				return true
+			}
+			return c.Requests >= s.MinRequests && float64(c.TotalFailures)/float64(c.Requests) >= s.FailureRatio
+		},
+		OnStateChange: onStateChange,
+		IsSuccessful: func(err error) bool {
+			return err == nil || !isFailure(err)
+		},
+		IsExcluded: func(err error) bool {
+			return errors.Is(err, context.Canceled)
+		},
+	})
+
+	mu.Lock()
+	breakers = append(breakers, b)
+	mu.Unlock()
+	return b
+}
+
+// Allow 判断能否调用依赖，放行时返回的 done 需要在调用结束后传入调用的错误；
+// 熔断中返回 ErrUnavailable
+func (b *Breaker) Allow() (done func(error), err error) {
+	if b == nil {
+		return func(error) {}, nil
+	}
+	done, err = b.cb.Allow()
+	if err != nil {
+		return nil, b.unavailable()
+	}
+	return done, nil
+}
+
+// Err 熔断中（open 状态）返回 ErrUnavailable，否则返回 nil，不占用半开状态的探测名额。
+// 调用方自带重试（例如 redsync 加锁）时先用它判断，避免熔断期间反复重试。
+func (b *Breaker) Err() error {
+	if b == nil || b.cb.State() != gobreaker.StateOpen {
+		return nil
+	}
+	return b.unavailable()
+}
+
+func (b *Breaker) unavailable() error {
+	return errno.ErrUnavailable.WithMeta("dependency", b.name)
+}
+
+// onStateChange 记录状态变化
+func onStateChange(name string, from, to gobreaker.State) {
+	if to == gobreaker.StateOpen {
+		zap.L().Error("circuit breaker opened", zap.String("name", name), zap.String("from", from.String()))
+		return
+	}
+	zap.L().Warn("circuit breaker state changed", zap.String("name", name), zap.String("from", from.String()), zap.String("to", to.String()))
+}
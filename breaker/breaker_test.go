@@ -0,0 +1,122 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"stock_service/config"
+	"stock_service/errno"
+)
+
+var (
+	errDown     = errors.New("connection refused")
+	errNotFound = errors.New("not found")
+)
+
+// newTestBreaker 连续失败 3 次熔断，熔断 50ms 后半开，半开时放行 1 个探测请求
+func newTestBreaker(t *testing.T) *Breaker {
+	t.Helper()
+	saved := settings
+	t.Cleanup(func() { settings = saved })
+	Init(&config.BreakerConfig{Enable: true, ConsecutiveFailures: 3, OpenTimeout: 50 * time.Millisecond, HalfOpenRequests: 1})
+	return New("test", func(err error) bool { return err != errNotFound })
+}
+
+// call 经过熔断器调用一次依赖，调用结果为 result
+func call(b *Breaker, result error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	done(result)
+	return nil
+}
+
+func TestBreakerTripAndHalfOpen(t *testing.T) {
+	b := newTestBreaker(t)
+
+	// 说明依赖正常的错误和 ctx 取消不计入失败
+	for i := 0; i < 5; i++ {
+		call(b, errNotFound)
+		call(b, context.Canceled)
+	}
+	call(b, errDown)
+	call(b, errDown)
+	if err := b.Err(); err != nil {
+		t.Fatalf("breaker open after 2 failures: %v", err)
+	}
+
+	call(b, errDown)
+	if err := call(b, nil); !errors.Is(err, errno.ErrUnavailable) {
+		t.Fatalf("call after 3 failures: %v, want ErrUnavailable", err)
+	}
+	if err := b.Err(); !errors.Is(err, errno.ErrUnavailable) {
+		t.Fatalf("Err() = %v, want ErrUnavailable", err)
+	}
+
+	// 半开状态只放行一个探测请求，探测失败重新熔断
+	time.Sleep(60 * time.Millisecond)
+	if err := b.Err(); err != nil {
+		t.Fatalf("Err() in half-open = %v, want nil", err)
+	}
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	if err := call(b, nil); !errors.Is(err, errno.ErrUnavailable) {
+		t.Fatalf("second request in half-open: %v, want ErrUnavailable", err)
+	}
+	done(errDown)
+	if err := b.Err(); !errors.Is(err, errno.ErrUnavailable) {
+		t.Fatalf("Err() after a failed probe = %v, want ErrUnavailable", err)
+	}
+
+	// 探测成功后恢复
+	time.Sleep(60 * time.Millisecond)
+	if err := call(b, nil); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := call(b, nil); err != nil {
+			t.Fatalf("call after recovery: %v", err)
+		}
+	}
+}
+
+func TestBreakerFailureRatio(t *testing.T) {
+	saved := settings
+	t.Cleanup(func() { settings = saved })
+	Init(&config.BreakerConfig{Enable: true, ConsecutiveFailures: 100, MinRequests: 10, FailureRatio: 0.5})
+	b := New("ratio", func(err error) bool { return err != nil })
+
+	// 失败和成功交替，连续失败次数不够，按失败率熔断
+	for i := 0; i < 10; i++ {
+		var result error
+		if i%2 == 1 {
+			result = errDown
+		}
+		if err := call(b, result); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if err := b.Err(); !errors.Is(err, errno.ErrUnavailable) {
+		t.Fatalf("Err() = %v, want ErrUnavailable", err)
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	saved := settings
+	t.Cleanup(func() { settings = saved })
+	Init(&config.BreakerConfig{Enable: false})
+	b := New("disabled", func(error) bool { return true })
+	if b != nil {
+		t.Fatal("New returned a breaker with breaking disabled")
+	}
+	for i := 0; i < 10; i++ {
+		if err := call(b, errDown); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package breaker
+
+import (
+	"stock_service/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// stateDesc 熔断器状态：0 闭合，1 半开，2 熔断
+var stateDesc = prometheus.NewDesc(
+	"stock_breaker_state",
+	"Circuit breaker state by dependency (0 closed, 1 half-open, 2 open).",
+	[]string{"name"}, nil,
+)
+
+// collector 抓取时读取熔断器的状态，熔断超时后的半开状态也能及时反映出来
+type collector struct{}
+
+func init() {
+	metrics.Registry.MustRegister(collector{})
+}
+
+func (collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- stateDesc
+}
+
+func (collector) Collect(ch chan<- prometheus.Metric) {
+	mu.Lock()
+	list := append([]*Breaker(nil), breakers...)
+	mu.Unlock()
+	for _, b := range list {
+		ch <- prometheus.MustNewConstMetric(stateDesc, prometheus.GaugeValue, float64(b.cb.State()), b.name)
+	}
+}
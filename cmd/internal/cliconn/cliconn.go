@@ -0,0 +1,146 @@
+// Package cliconn 命令行工具（stockctl、stockexport、stockload）共用的连接参数：地址或 Consul 发现、TLS/mTLS、Token 和 API Key。
+package cliconn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"stock_service/registry"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Flags 连接相关的参数
+type Flags struct {
+	addr       string // gRPC 地址
+	consul     string // Consul 地址，与 service 一起使用时从 Consul 选择实例
+	service    string // Consul 中的服务名
+	useTLS     bool
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+	skipVerify bool
+	token      string // Bearer Token
+	apiKey     string // API Key
+}
+
+// Register 在 fs 中注册连接参数
+func (c *Flags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&c.addr, "addr", "127.0.0.1:8387", "库存服务 gRPC 地址")
+	fs.StringVar(&c.consul, "consul", "", "Consul 地址，例如 127.0.0.1:8500，设置后按 -service 从 Consul 选择实例")
+	fs.StringVar(&c.service, "service", "stock_srv", "Consul 中的服务名")
+	fs.BoolVar(&c.useTLS, "tls", false, "使用 TLS 连接")
+	fs.StringVar(&c.caFile, "ca", "", "CA 证书文件，为空时使用系统证书")
+	fs.StringVar(&c.certFile, "cert", "", "客户端证书文件（mTLS）")
+	fs.StringVar(&c.keyFile, "key", "", "客户端私钥文件（mTLS）")
+	fs.StringVar(&c.serverName, "server-name", "", "校验服务端证书时使用的域名")
+	fs.BoolVar(&c.skipVerify, "insecure-skip-verify", false, "不校验服务端证书（仅用于测试）")
+	fs.StringVar(&c.token, "token", os.Getenv("STOCKCTL_TOKEN"), "Bearer Token，默认读取环境变量 STOCKCTL_TOKEN")
+	fs.StringVar(&c.apiKey, "api-key", os.Getenv("STOCKCTL_API_KEY"), "API Key，默认读取环境变量 STOCKCTL_API_KEY")
+}
+
+// target 返回要连接的地址：设置了 Consul 时从健康的实例中随机选择一个
+func (c *Flags) target() (string, error) {
+	if c.consul == "" {
+		return c.addr, nil
+	}
+	if err := registry.Init(c.consul); err != nil {
+		return "", err
+	}
+	services, err := registry.Reg.ListService(c.service)
+	if err != nil {
+		return "", err
+	}
+	addrs := make([]string, 0, len(services))
+	for _, s := range services {
+		addrs = append(addrs, fmt.Sprintf("%s:%d", s.Address, s.Port))
+	}
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("consul 中没有服务 %s 的实例", c.service)
+	}
+	return addrs[rand.Intn(len(addrs))], nil
+}
+
+// transportCredentials 根据参数创建传输层凭证
+func (c *Flags) transportCredentials() (credentials.TransportCredentials, error) {
+	if !c.useTLS {
+		if c.certFile != "" || c.caFile != "" {
+			return nil, errors.New("-ca/-cert/-key 需要同时指定 -tls")
+		}
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{
+		ServerName:         c.serverName,
+		InsecureSkipVerify: c.skipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if c.caFile != "" {
+		pem, err := os.ReadFile(c.caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("无效的 CA 证书: %s", c.caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if c.certFile != "" || c.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// Dial 按参数建立 gRPC 连接
+func (c *Flags) Dial() (*grpc.ClientConn, error) {
+	target, err := c.target()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := c.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if c.token != "" || c.apiKey != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(authCreds{token: c.token, apiKey: c.apiKey, secure: c.useTLS}))
+	}
+	return grpc.NewClient(target, opts...)
+}
+
+// authCreds 在每个请求的 metadata 中携带认证信息
+type authCreds struct {
+	token  string
+	apiKey string
+	secure bool
+}
+
+func (a authCreds) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	md := make(map[string]string, 2)
+	if a.token != "" {
+		md["authorization"] = "Bearer " + a.token
+	}
+	if a.apiKey != "" {
+		md["x-api-key"] = a.apiKey
+	}
+	return md, nil
+}
+
+// RequireTransportSecurity 使用 TLS 时才要求安全连接，方便在测试环境中使用明文连接
+func (a authCreds) RequireTransportSecurity() bool {
+	return a.secure
+}
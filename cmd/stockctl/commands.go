@@ -0,0 +1,613 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
+)
+
+var commands = map[string]*command{}
+
+func init() {
+	for _, c := range []*command{
+		{name: "get", usage: "get <goods_id>", run: runGet},
+		{name: "batch", usage: "batch <goods_id>[,<goods_id>...]", run: runBatch},
+		{name: "check", usage: "check [-user=<id>] [-room=<id>] <goods_id>:<num> [<goods_id>:<num>...]", run: runCheck},
+		{name: "set", usage: "set -goods=<id> -total=<n> [-dry-run]", dryRun: true, run: runSet},
+		{name: "adjust", usage: "adjust -goods=<id> -delta=<n> -reason=<原因> [-dry-run]", dryRun: true, run: runAdjust},
+		{name: "reduce", usage: "reduce -goods=<id> -order=<id> -num=<n> [-user=<id>] [-room=<id>] [-release=<秒>] [-dry-run]", dryRun: true, run: runReduce},
+		{name: "rollback", usage: "rollback -goods=<id> -order=<id> -num=<n> [-dry-run]", dryRun: true, run: runRollback},
+		{name: "confirm", usage: "confirm -goods=<id> -order=<id> [-dry-run]", dryRun: true, run: runConfirm},
+		{name: "delete", usage: "delete -goods=<id> [-dry-run]", dryRun: true, run: runDelete},
+		{name: "undelete", usage: "undelete -goods=<id>", run: runUndelete},
+		{name: "list", usage: "list [-below=<n>] [-locked] [-since=<时间>] [-sort=goods_id|available|update_at] [-desc] [-cursor=<c>] [-size=<n>] [-all]", run: runList},
+		{name: "reservations", usage: "reservations [-order=<id>] [-goods=<id>] [-status=<状态>] [-page=<n>] [-size=<n>]", run: runReservations},
+		{name: "export", usage: "export [-min=<id>] [-max=<id>] [-since=<时间>] [-non-zero] [-records]", run: runExport},
+		{name: "reconcile", usage: "reconcile [-goods=<id>,...] [-dry-run]", dryRun: true, run: runReconcile},
+	} {
+		commands[c.name] = c
+	}
+}
+
+// metaFlags 修改类请求的元数据参数
+type metaFlags struct {
+	requestId      string
+	idempotencyKey string
+}
+
+func (m *metaFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&m.requestId, "request-id", "", "请求ID，为空时由服务端生成")
+	fs.StringVar(&m.idempotencyKey, "idempotency-key", "", "幂等键，相同幂等键的请求只执行一次")
+}
+
+func (m *metaFlags) meta() *stockv2.RequestMeta {
+	return &stockv2.RequestMeta{RequestId: m.requestId, IdempotencyKey: m.idempotencyKey}
+}
+
+// requirePositive 校验必填的正整数参数
+func requirePositive(name string, v int64) error {
+	if v <= 0 {
+		return fmt.Errorf("-%s 必须大于 0", name)
+	}
+	return nil
+}
+
+// parseIds 解析逗号或空格分隔的商品ID列表
+func parseIds(args []string) ([]int64, error) {
+	var ids []int64
+	for _, arg := range args {
+		for _, s := range strings.Split(arg, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			id, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("无效的商品ID: %s", s)
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// parseTime 解析 RFC3339 时间或 Unix 秒
+func parseTime(s string) (time.Time, error) {
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// note 在标准错误输出提示信息，不影响 JSON 输出
+func note(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func runGet(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	fs.Parse(args)
+	ids, err := parseIds(fs.Args())
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	resp, err := e.v2.GetStock(ctx, &stockv2.GetStockReq{GoodsId: ids[0]})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runBatch(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	fs.Parse(args)
+	ids, err := parseIds(fs.Args())
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	resp, err := e.v2.BatchGetStock(ctx, &stockv2.BatchGetStockReq{GoodsIds: ids})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runCheck(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	req := &stockv2.CheckStockReq{}
+	fs.Int64Var(&req.UserId, "user", 0, "用户ID，校验限购")
+	fs.Int64Var(&req.RoomId, "room", 0, "直播间ID，校验直播间配额")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	for _, arg := range fs.Args() {
+		goods, num, ok := strings.Cut(arg, ":")
+		if !ok {
+			num = "1"
+		}
+		item := &stockv2.CheckItem{}
+		var err error
+		if item.GoodsId, err = strconv.ParseInt(goods, 10, 64); err != nil {
+			return fmt.Errorf("无效的商品ID: %s", goods)
+		}
+		if item.Num, err = strconv.ParseInt(num, 10, 64); err != nil {
+			return fmt.Errorf("无效的数量: %s", num)
+		}
+		req.Items = append(req.Items, item)
+	}
+	return check(ctx, e, req)
+}
+
+// check 调用预检接口并输出结果，不能扣减时返回错误
+func check(ctx context.Context, e *env, req *stockv2.CheckStockReq) error {
+	resp, err := e.v2.CheckStock(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := e.out.print(resp); err != nil {
+		return err
+	}
+	if !resp.Ok {
+		return errors.New("预检未通过")
+	}
+	return nil
+}
+
+func runSet(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		m       metaFlags
+		goodsId int64
+		total   int64
+	)
+	m.register(fs)
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID")
+	fs.Int64Var(&total, "total", -1, "可用库存数量（不含已预扣的数量）")
+	fs.Parse(args)
+	if err := requirePositive("goods", goodsId); err != nil {
+		return err
+	}
+	if total < 0 {
+		return errors.New("-total 不能小于 0")
+	}
+
+	if e.dryRun {
+		cur, err := e.v2.GetStock(ctx, &stockv2.GetStockReq{GoodsId: goodsId})
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(cur); err != nil {
+			return err
+		}
+		note("dry-run: 库存总数 %d -> %d，可用库存 %d -> %d", cur.Total, total+cur.Locked, cur.Available, total)
+		return nil
+	}
+
+	resp, err := e.v2.SetStock(ctx, &stockv2.SetStockReq{Meta: m.meta(), GoodsId: goodsId, Total: total})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runAdjust(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		m       metaFlags
+		goodsId int64
+		delta   int64
+		reason  string
+	)
+	m.register(fs)
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID")
+	fs.Int64Var(&delta, "delta", 0, "库存增量，负数表示减少")
+	fs.StringVar(&reason, "reason", "", "调整原因")
+	fs.Parse(args)
+	if err := requirePositive("goods", goodsId); err != nil {
+		return err
+	}
+	if delta == 0 {
+		return errors.New("-delta 不能为 0")
+	}
+	if reason == "" {
+		return errors.New("-reason 不能为空")
+	}
+
+	if e.dryRun {
+		cur, err := e.v2.GetStock(ctx, &stockv2.GetStockReq{GoodsId: goodsId})
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(cur); err != nil {
+			return err
+		}
+		note("dry-run: 库存总数 %d -> %d，可用库存 %d -> %d", cur.Total, cur.Total+delta, cur.Available, cur.Available+delta)
+		if cur.Available+delta < 0 {
+			return fmt.Errorf("可用库存不足，最多减少 %d", cur.Available)
+		}
+		return nil
+	}
+
+	resp, err := e.v2.AdjustStock(ctx, &stockv2.AdjustStockReq{Meta: m.meta(), GoodsId: goodsId, Delta: delta, Reason: reason})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runReduce(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		m       metaFlags
+		goodsId int64
+		orderId int64
+		num     int64
+		release int64
+		userId  int64
+		roomId  int64
+	)
+	m.register(fs)
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID")
+	fs.Int64Var(&orderId, "order", 0, "订单ID")
+	fs.Int64Var(&num, "num", 0, "预扣数量")
+	fs.Int64Var(&userId, "user", 0, "用户ID，校验限购")
+	fs.Int64Var(&roomId, "room", 0, "直播间ID，校验直播间配额")
+	fs.Int64Var(&release, "release", 0, "超时未确认自动释放的秒数，0 表示使用服务端配置")
+	fs.Parse(args)
+	for name, v := range map[string]int64{"goods": goodsId, "order": orderId, "num": num} {
+		if err := requirePositive(name, v); err != nil {
+			return err
+		}
+	}
+
+	if e.dryRun {
+		// 预检接口与 ReduceStock 使用相同的校验规则，但不加锁也不修改库存
+		return check(ctx, e, &stockv2.CheckStockReq{
+			Items:  []*stockv2.CheckItem{{GoodsId: goodsId, Num: num}},
+			UserId: userId,
+			RoomId: roomId,
+		})
+	}
+
+	resp, err := e.v2.ReduceStock(ctx, &stockv2.ReduceStockReq{
+		Meta:               m.meta(),
+		GoodsId:            goodsId,
+		Num:                num,
+		OrderId:            orderId,
+		AutoReleaseSeconds: release,
+		UserId:             userId,
+		RoomId:             roomId,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+// openReservation 查询订单在商品上状态为预扣减的记录，用于 rollback 和 confirm 的 dry-run
+func openReservation(ctx context.Context, e *env, orderId, goodsId int64) error {
+	list, err := e.v1.GetOrderReservations(ctx, &proto.GetOrderReservationsReq{OrderId: orderId})
+	if err != nil {
+		return err
+	}
+	open := &proto.ReservationList{}
+	for _, r := range list.Data {
+		if r.GoodsId == goodsId && r.Status == proto.ReservationStatus_RESERVATION_RESERVED {
+			open.Data = append(open.Data, r)
+		}
+	}
+	open.Total = int64(len(open.Data))
+	if err := e.out.print(open); err != nil {
+		return err
+	}
+	if len(open.Data) == 0 {
+		note("dry-run: 订单 %d 在商品 %d 上没有预扣减的记录，不会修改库存", orderId, goodsId)
+	}
+	return nil
+}
+
+func runRollback(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		m       metaFlags
+		goodsId int64
+		orderId int64
+		num     int64
+	)
+	m.register(fs)
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID")
+	fs.Int64Var(&orderId, "order", 0, "订单ID")
+	fs.Int64Var(&num, "num", 0, "回滚数量")
+	fs.Parse(args)
+	for name, v := range map[string]int64{"goods": goodsId, "order": orderId, "num": num} {
+		if err := requirePositive(name, v); err != nil {
+			return err
+		}
+	}
+
+	if e.dryRun {
+		return openReservation(ctx, e, orderId, goodsId)
+	}
+
+	resp, err := e.v2.RollbackStock(ctx, &stockv2.RollbackStockReq{Meta: m.meta(), GoodsId: goodsId, Num: num, OrderId: orderId})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runConfirm(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		m       metaFlags
+		goodsId int64
+		orderId int64
+	)
+	m.register(fs)
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID")
+	fs.Int64Var(&orderId, "order", 0, "订单ID")
+	fs.Parse(args)
+	for name, v := range map[string]int64{"goods": goodsId, "order": orderId} {
+		if err := requirePositive(name, v); err != nil {
+			return err
+		}
+	}
+
+	if e.dryRun {
+		return openReservation(ctx, e, orderId, goodsId)
+	}
+
+	resp, err := e.v2.ConfirmStock(ctx, &stockv2.ConfirmStockReq{Meta: m.meta(), GoodsId: goodsId, OrderId: orderId})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runDelete(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		m       metaFlags
+		goodsId int64
+	)
+	m.register(fs)
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID")
+	fs.Parse(args)
+	if err := requirePositive("goods", goodsId); err != nil {
+		return err
+	}
+
+	if e.dryRun {
+		cur, err := e.v2.GetStock(ctx, &stockv2.GetStockReq{GoodsId: goodsId})
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(cur); err != nil {
+			return err
+		}
+		if cur.Locked > 0 {
+			return fmt.Errorf("存在预扣库存 %d，不允许删除", cur.Locked)
+		}
+		note("dry-run: 商品 %d 的库存可以删除", goodsId)
+		return nil
+	}
+
+	resp, err := e.v2.DeleteStock(ctx, &stockv2.DeleteStockReq{Meta: m.meta(), GoodsId: goodsId})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runUndelete(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		m       metaFlags
+		goodsId int64
+	)
+	m.register(fs)
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID")
+	fs.Parse(args)
+	if err := requirePositive("goods", goodsId); err != nil {
+		return err
+	}
+	resp, err := e.v2.UndeleteStock(ctx, &stockv2.UndeleteStockReq{Meta: m.meta(), GoodsId: goodsId})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runList(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		below  int64
+		locked bool
+		since  string
+		ids    string
+		sortBy string
+		desc   bool
+		cursor string
+		size   int
+		all    bool
+	)
+	fs.Int64Var(&below, "below", -1, "只返回可用库存小于该值的商品，-1 表示不限")
+	fs.BoolVar(&locked, "locked", false, "只返回预扣库存大于 0 的商品")
+	fs.StringVar(&since, "since", "", "只返回该时间之后更新过的商品，RFC3339 格式或 Unix 秒")
+	fs.StringVar(&ids, "goods", "", "商品ID列表，逗号分隔")
+	fs.StringVar(&sortBy, "sort", "goods_id", "排序字段：goods_id、available 或 update_at")
+	fs.BoolVar(&desc, "desc", false, "倒序")
+	fs.StringVar(&cursor, "cursor", "", "上一页返回的 next_cursor")
+	fs.IntVar(&size, "size", 20, "每页条数，最大 100")
+	fs.BoolVar(&all, "all", false, "自动翻页直到没有更多数据")
+	fs.Parse(args)
+
+	req := &proto.ListStockReq{LockedOnly: locked, Desc: desc, Cursor: cursor, PageSize: int32(size)}
+	if below >= 0 {
+		req.AvailableBelow = &below
+	}
+	if since != "" {
+		ts, err := parseTime(since)
+		if err != nil {
+			return err
+		}
+		req.UpdatedSince = ts.Unix()
+	}
+	var err error
+	if req.GoodsIds, err = parseIds([]string{ids}); err != nil {
+		return err
+	}
+	v, ok := proto.StockSortField_value["STOCK_SORT_"+strings.ToUpper(sortBy)]
+	if !ok {
+		return fmt.Errorf("不支持的排序字段: %s", sortBy)
+	}
+	req.SortBy = proto.StockSortField(v)
+
+	for {
+		resp, err := e.v1.ListStock(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(resp); err != nil {
+			return err
+		}
+		if !all || resp.NextCursor == "" {
+			return nil
+		}
+		req.Cursor = resp.NextCursor
+	}
+}
+
+func runReservations(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		orderId int64
+		goodsId int64
+		st      string
+		start   string
+		end     string
+		page    int
+		size    int
+	)
+	fs.Int64Var(&orderId, "order", 0, "订单ID，设置后查询该订单的全部记录，忽略其他条件")
+	fs.Int64Var(&goodsId, "goods", 0, "商品ID，0 表示不限")
+	fs.StringVar(&st, "status", "", "状态：reserved、confirmed、rolled_back 或 expired，为空表示不限")
+	fs.StringVar(&start, "start", "", "创建时间下界（含），RFC3339 格式或 Unix 秒")
+	fs.StringVar(&end, "end", "", "创建时间上界（不含），RFC3339 格式或 Unix 秒")
+	fs.IntVar(&page, "page", 1, "页码，从 1 开始")
+	fs.IntVar(&size, "size", 20, "每页条数，最大 100")
+	fs.Parse(args)
+
+	if orderId > 0 {
+		resp, err := e.v1.GetOrderReservations(ctx, &proto.GetOrderReservationsReq{OrderId: orderId})
+		if err != nil {
+			return err
+		}
+		return e.out.print(resp)
+	}
+
+	req := &proto.ListReservationsReq{GoodsId: goodsId, Page: int32(page), PageSize: int32(size)}
+	if st != "" {
+		v, ok := proto.ReservationStatus_value["RESERVATION_"+strings.ToUpper(st)]
+		if !ok {
+			return fmt.Errorf("不支持的状态: %s", st)
+		}
+		req.Status = proto.ReservationStatus(v)
+	}
+	for s, dst := range map[string]*int64{start: &req.StartTime, end: &req.EndTime} {
+		if s == "" {
+			continue
+		}
+		ts, err := parseTime(s)
+		if err != nil {
+			return err
+		}
+		*dst = ts.Unix()
+	}
+	resp, err := e.v1.ListReservations(ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
+
+func runExport(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var (
+		req   proto.ExportStockReq
+		since string
+	)
+	fs.Int64Var(&req.MinGoodsId, "min", 0, "商品ID下界（含）")
+	fs.Int64Var(&req.MaxGoodsId, "max", 0, "商品ID上界（含）")
+	fs.StringVar(&since, "since", "", "只导出该时间之后更新过的库存，RFC3339 格式或 Unix 秒")
+	fs.BoolVar(&req.OnlyNonZero, "non-zero", false, "只导出库存或预扣库存不为 0 的商品")
+	fs.BoolVar(&req.WithRecords, "records", false, "同时导出状态为 1（预扣减）的库存记录")
+	fs.Parse(args)
+	if since != "" {
+		ts, err := parseTime(since)
+		if err != nil {
+			return err
+		}
+		req.UpdatedSince = ts.Unix()
+	}
+
+	stream, err := e.v1.ExportStock(ctx, &req)
+	if err != nil {
+		return err
+	}
+	// 表格输出时库存和库存记录分别汇总成表格，JSON 输出时逐条输出
+	var stocks []*proto.StockDetail
+	var records []*proto.StockRecordInfo
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if e.out.format == "json" {
+			if err := e.out.print(item); err != nil {
+				return err
+			}
+			continue
+		}
+		if s := item.GetStock(); s != nil {
+			stocks = append(stocks, s)
+		} else if r := item.GetRecord(); r != nil {
+			records = append(records, r)
+		}
+	}
+	if e.out.format == "json" {
+		return nil
+	}
+	if err := e.out.print(&proto.ListStockResp{Data: stocks}); err != nil {
+		return err
+	}
+	if req.WithRecords {
+		fmt.Fprintln(e.out.w)
+		return e.out.print(&proto.ReservationList{Data: records, Total: int64(len(records))})
+	}
+	return nil
+}
+
+func runReconcile(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error {
+	var ids string
+	fs.StringVar(&ids, "goods", "", "需要核对的商品ID，逗号分隔，为空表示全部商品")
+	fs.Parse(args)
+	goodsIds, err := parseIds([]string{ids})
+	if err != nil {
+		return err
+	}
+	// dry-run 时只核对不修复
+	resp, err := e.v1.ReconcileStock(ctx, &proto.ReconcileStockReq{GoodsIds: goodsIds, Repair: !e.dryRun})
+	if err != nil {
+		return err
+	}
+	return e.out.print(resp)
+}
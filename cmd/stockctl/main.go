@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"stock_service/cmd/internal/cliconn"
+	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
+
+	"google.golang.org/grpc/status"
+)
+
+// stockctl 库存服务的管理命令行工具
+// 例如：
+//   stockctl -addr=127.0.0.1:8387 get 1001
+//   stockctl -consul=127.0.0.1:8500 -output=json adjust -goods=1001 -delta=-5 -reason=盘点
+//   stockctl reduce -goods=1001 -order=9001 -num=2 -dry-run
+
+// env 子命令的运行环境
+type env struct {
+	v1     proto.StockClient
+	v2     stockv2.StockClient
+	out    *printer
+	dryRun bool
+}
+
+// command 子命令
+type command struct {
+	name   string
+	usage  string
+	dryRun bool // 是否支持 -dry-run
+	run    func(ctx context.Context, e *env, fs *flag.FlagSet, args []string) error
+}
+
+func main() {
+	var (
+		conn    cliconn.Flags
+		output  string
+		timeout time.Duration
+	)
+	conn.Register(flag.CommandLine)
+	flag.StringVar(&output, "output", "table", "输出格式：table 或 json")
+	flag.DurationVar(&timeout, "timeout", 10*time.Second, "请求超时时间，export 不受限制")
+	flag.Usage = usage
+	flag.Parse()
+
+	if output != "table" && output != "json" {
+		fatal(fmt.Errorf("不支持的输出格式: %s", output))
+	}
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "未知的子命令: %s\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	e := &env{out: &printer{w: os.Stdout, format: output}}
+	fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "用法: stockctl [全局参数] %s\n\n", cmd.usage)
+		fs.PrintDefaults()
+	}
+	if cmd.dryRun {
+		fs.BoolVar(&e.dryRun, "dry-run", false, "只校验并展示将要执行的修改，不修改库存")
+	}
+
+	cc, err := conn.Dial()
+	if err != nil {
+		fatal(err)
+	}
+	defer cc.Close()
+	e.v1 = proto.NewStockClient(cc)
+	e.v2 = stockv2.NewStockClient(cc)
+
+	ctx := context.Background()
+	if cmd.name != "export" {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := cmd.run(ctx, e, fs, flag.Args()[1:]); err != nil {
+		fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "用法: stockctl [全局参数] <子命令> [参数]")
+	fmt.Fprintln(os.Stderr, "\n子命令:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(os.Stderr, "\n全局参数:")
+	flag.PrintDefaults()
+}
+
+// fatal 输出错误并退出，gRPC 错误额外输出状态码和错误详情
+func fatal(err error) {
+	if st, ok := status.FromError(err); ok {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", st.Code(), st.Message())
+		for _, d := range st.Details() {
+			fmt.Fprintf(os.Stderr, "  %v\n", d)
+		}
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
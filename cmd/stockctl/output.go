@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// printer 按 table 或 json 格式输出响应
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+var jsonOpts = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// print 输出一个响应
+func (p *printer) print(m protobuf.Message) error {
+	if p.format == "json" {
+		b, err := jsonOpts.Marshal(m)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(b))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	if list := listField(m.ProtoReflect()); list != nil {
+		// 列表类响应：列表输出为表格，其余字段输出在表格后面
+		printRows(tw, m.ProtoReflect().Get(list).List())
+		tw.Flush()
+		fields := m.ProtoReflect().Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			if fd := fields.Get(i); fd != list {
+				fmt.Fprintf(tw, "%s:\t%s\n", fd.Name(), formatValue(fd, m.ProtoReflect().Get(fd)))
+			}
+		}
+		return tw.Flush()
+	}
+
+	// 单个响应：每个字段一行
+	cols := columns(m.ProtoReflect().Descriptor(), "")
+	for _, c := range cols {
+		fmt.Fprintf(tw, "%s:\t%s\n", c.name, c.value(m.ProtoReflect()))
+	}
+	return tw.Flush()
+}
+
+// listField 返回消息中唯一的 repeated message 字段，没有或有多个时返回 nil
+func listField(m protoreflect.Message) protoreflect.FieldDescriptor {
+	var found protoreflect.FieldDescriptor
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsList() && fd.Kind() == protoreflect.MessageKind {
+			if found != nil {
+				return nil
+			}
+			found = fd
+		}
+	}
+	return found
+}
+
+// column 表格中的一列，嵌套的消息字段展开为 parent.child
+type column struct {
+	name string
+	path []protoreflect.FieldDescriptor
+}
+
+func (c column) value(m protoreflect.Message) string {
+	for _, fd := range c.path[:len(c.path)-1] {
+		if !m.Has(fd) {
+			return ""
+		}
+		m = m.Get(fd).Message()
+	}
+	fd := c.path[len(c.path)-1]
+	return formatValue(fd, m.Get(fd))
+}
+
+// columns 返回消息的全部列，嵌套的消息字段展开一层
+func columns(md protoreflect.MessageDescriptor, prefix string) []column {
+	var cols []column
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := prefix + string(fd.Name())
+		if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() && prefix == "" {
+			for _, sub := range columns(fd.Message(), name+".") {
+				sub.path = append([]protoreflect.FieldDescriptor{fd}, sub.path...)
+				cols = append(cols, sub)
+			}
+			continue
+		}
+		cols = append(cols, column{name: name, path: []protoreflect.FieldDescriptor{fd}})
+	}
+	return cols
+}
+
+// printRows 把列表输出为表格
+func printRows(w io.Writer, list protoreflect.List) {
+	if list.Len() == 0 {
+		fmt.Fprintln(w, "(empty)")
+		return
+	}
+	cols := columns(list.Get(0).Message().Descriptor(), "")
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = strings.ToUpper(c.name)
+	}
+	fmt.Fprintln(w, strings.Join(names, "\t"))
+	for i := 0; i < list.Len(); i++ {
+		m := list.Get(i).Message()
+		vals := make([]string, len(cols))
+		for j, c := range cols {
+			vals[j] = c.value(m)
+		}
+		fmt.Fprintln(w, strings.Join(vals, "\t"))
+	}
+}
+
+// formatValue 格式化字段的值，*_at 和 *_time 字段按时间输出
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.IsList() {
+		l := v.List()
+		items := make([]string, l.Len())
+		for i := 0; i < l.Len(); i++ {
+			items[i] = v.List().Get(i).String()
+		}
+		return strings.Join(items, ",")
+	}
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case protoreflect.MessageKind:
+		b, _ := protojson.Marshal(v.Message().Interface())
+		return string(b)
+	case protoreflect.Int64Kind:
+		name := string(fd.Name())
+		if (strings.HasSuffix(name, "_at") || strings.HasSuffix(name, "_time")) && v.Int() > 0 {
+			return time.Unix(v.Int(), 0).Format(time.RFC3339)
+		}
+	}
+	return v.String()
+}
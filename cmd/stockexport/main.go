@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"stock_service/cmd/internal/cliconn"
+	"stock_service/proto"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// stockexport 调用 ExportStock 接口，把库存快照导出为 CSV 或 NDJSON
+// 例如：stockexport -addr=127.0.0.1:8387 -tls -ca=ca.pem -token=$TOKEN -format=csv -out=stock.csv -records
+// 连接参数（-addr、-consul、-tls、-ca、-cert、-key、-token、-api-key 等）与 stockctl 相同
+
+// csvHeader CSV 表头，库存和库存记录共用一套列，用 type 列区分
+var csvHeader = []string{"type", "goods_id", "stock", "lock", "record_id", "order_id", "num", "status", "create_at", "update_at"}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run 执行导出，出错时返回错误，由 main 退出，保证打开的文件和连接都已关闭
+func run() error {
+	var (
+		conn        cliconn.Flags
+		format      string
+		out         string
+		minGoodsId  int64
+		maxGoodsId  int64
+		since       string
+		onlyNonZero bool
+		withRecords bool
+	)
+	conn.Register(flag.CommandLine)
+	flag.StringVar(&format, "format", "csv", "导出格式：csv 或 ndjson")
+	flag.StringVar(&out, "out", "-", "输出文件路径，- 表示标准输出")
+	flag.Int64Var(&minGoodsId, "min", 0, "商品ID下界（含）")
+	flag.Int64Var(&maxGoodsId, "max", 0, "商品ID上界（含）")
+	flag.StringVar(&since, "since", "", "只导出该时间之后更新过的库存，RFC3339 格式或 Unix 秒")
+	flag.BoolVar(&onlyNonZero, "non-zero", false, "只导出库存或预扣库存不为 0 的商品")
+	flag.BoolVar(&withRecords, "records", false, "同时导出状态为 1（预扣减）的库存记录")
+	flag.Parse()
+
+	req := &proto.ExportStockReq{
+		MinGoodsId:  minGoodsId,
+		MaxGoodsId:  maxGoodsId,
+		OnlyNonZero: onlyNonZero,
+		WithRecords: withRecords,
+	}
+	if since != "" {
+		ts, err := parseTime(since)
+		if err != nil {
+			return err
+		}
+		req.UpdatedSince = ts.Unix()
+	}
+	if format != "csv" && format != "ndjson" {
+		return fmt.Errorf("不支持的导出格式: %s", format)
+	}
+
+	cc, err := conn.Dial()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	var w io.Writer = os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var write func(*proto.ExportStockItem) error
+	var flush func() error
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		write = func(item *proto.ExportStockItem) error { return cw.Write(csvRow(item)) }
+		flush = func() error { cw.Flush(); return cw.Error() }
+	case "ndjson":
+		bw := bufio.NewWriter(w)
+		opts := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+		write = func(item *proto.ExportStockItem) error {
+			b, err := opts.Marshal(item)
+			if err != nil {
+				return err
+			}
+			bw.Write(b)
+			return bw.WriteByte('\n')
+		}
+		flush = bw.Flush
+	}
+
+	stream, err := proto.NewStockClient(cc).ExportStock(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	var n int
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := write(item); err != nil {
+			return err
+		}
+		n++
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "导出完成，共 %d 条\n", n)
+	return nil
+}
+
+// csvRow 将导出条目转换为一行 CSV
+func csvRow(item *proto.ExportStockItem) []string {
+	i := strconv.FormatInt
+	if s := item.GetStock(); s != nil {
+		return []string{"stock", i(s.GoodsId, 10), i(s.Stock, 10), i(s.Lock, 10), "", "", "", "", "", formatUnix(s.UpdateAt)}
+	}
+	r := item.GetRecord()
+	return []string{"record", i(r.GoodsId, 10), "", "", i(r.Id, 10), i(r.OrderId, 10), i(r.Num, 10),
+		strconv.Itoa(int(r.Status)), formatUnix(r.CreateAt), formatUnix(r.UpdateAt)}
+}
+
+// parseTime 解析 RFC3339 时间或 Unix 秒
+func parseTime(s string) (time.Time, error) {
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func formatUnix(sec int64) string {
+	return time.Unix(sec, 0).Format(time.RFC3339)
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"stock_service/cmd/internal/cliconn"
+	stockv2 "stock_service/proto/v2"
+)
+
+// stockload 库存接口的压测工具，按比例混合调用 GetStock、ReduceStock、RollbackStock，
+// 输出延迟分位数、按 gRPC 状态码统计的错误数，并检查是否超卖。
+// 例如：stockload -addr=127.0.0.1:8387 -goods=1001-1100 -init-stock=1000 -c=50 -d=1m -mix=get=60,reduce=30,rollback=10
+// 连接参数（-addr、-consul、-tls、-ca、-cert、-key、-token、-api-key 等）与 stockctl 相同。
+//
+// 超卖检查假设压测期间没有其他流量修改这些商品，且预扣在压测期间不会超时释放（见 -release）。
+// 服务端开启了上架校验（stock_rule.check_on_shelf）时，这些商品需要在商品表中为上架状态。
+
+// 操作类型
+const (
+	opGet = iota
+	opReduce
+	opRollback
+	opCount
+)
+
+var opNames = [opCount]string{"get", "reduce", "rollback"}
+
+// config 压测参数
+type config struct {
+	rps       int
+	conc      int
+	duration  time.Duration
+	timeout   time.Duration
+	mix       [opCount]int
+	minGoods  int64
+	maxGoods  int64
+	skew      float64
+	num       int64
+	initStock int64
+	orderBase int64
+	release   int64
+}
+
+func main() {
+	var (
+		conn  cliconn.Flags
+		cfg   config
+		goods string
+		mix   string
+	)
+	conn.Register(flag.CommandLine)
+	flag.IntVar(&cfg.rps, "rps", 0, "每秒请求数，0 表示不限速，由 -c 个并发循环发送")
+	flag.IntVar(&cfg.conc, "c", 10, "并发数")
+	flag.DurationVar(&cfg.duration, "d", 30*time.Second, "压测时长")
+	flag.DurationVar(&cfg.timeout, "timeout", 5*time.Second, "单个请求的超时时间")
+	flag.StringVar(&mix, "mix", "get=60,reduce=30,rollback=10", "各操作的比例")
+	flag.StringVar(&goods, "goods", "1-100", "商品ID范围，例如 1001-1100")
+	flag.Float64Var(&cfg.skew, "skew", 1.1, "热点分布（Zipf）的倾斜度，必须大于 1，越大越集中；不大于 1 时均匀分布")
+	flag.Int64Var(&cfg.num, "num", 1, "每次预扣和回滚的数量")
+	flag.Int64Var(&cfg.initStock, "init-stock", 0, "压测前把每个商品的库存设置为该值，0 表示使用当前库存")
+	flag.Int64Var(&cfg.orderBase, "order-base", time.Now().Unix()*1000, "订单ID的起始值，每次预扣使用新的订单ID")
+	flag.Int64Var(&cfg.release, "release", 3600, "预扣超时自动释放的秒数，应大于压测时长，否则超卖检查不准确")
+	flag.Parse()
+
+	var err error
+	if cfg.mix, err = parseMix(mix); err != nil {
+		fatal(err)
+	}
+	if cfg.minGoods, cfg.maxGoods, err = parseRange(goods); err != nil {
+		fatal(err)
+	}
+	if cfg.conc <= 0 || cfg.num <= 0 || cfg.duration <= 0 {
+		fatal(errors.New("-c、-num、-d 必须大于 0"))
+	}
+
+	cc, err := conn.Dial()
+	if err != nil {
+		fatal(err)
+	}
+	defer cc.Close()
+	client := stockv2.NewStockClient(cc)
+
+	before, err := snapshot(client, &cfg)
+	if err != nil {
+		fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.duration)
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sig
+		cancel()
+	}()
+
+	fmt.Fprintf(os.Stderr, "压测开始：%d 个商品，并发 %d，时长 %s\n", len(before), cfg.conc, cfg.duration)
+	start := time.Now()
+	r := newRunner(client, &cfg)
+	r.run(ctx)
+	elapsed := time.Since(start)
+
+	after, err := snapshot(client, &config{minGoods: cfg.minGoods, maxGoods: cfg.maxGoods})
+	if err != nil {
+		fatal(err)
+	}
+	if !r.report(os.Stdout, elapsed, before, after) {
+		os.Exit(1)
+	}
+}
+
+// snapshot 读取（或先设置）全部商品的库存
+func snapshot(client stockv2.StockClient, cfg *config) (map[int64]*stockv2.StockState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	states := make(map[int64]*stockv2.StockState)
+	for id := cfg.minGoods; id <= cfg.maxGoods; id++ {
+		if cfg.initStock > 0 {
+			resp, err := client.SetStock(ctx, &stockv2.SetStockReq{GoodsId: id, Total: cfg.initStock})
+			if err != nil {
+				return nil, fmt.Errorf("设置商品 %d 的库存失败: %w", id, err)
+			}
+			states[id] = resp.Stock
+			continue
+		}
+		s, err := client.GetStock(ctx, &stockv2.GetStockReq{GoodsId: id})
+		if err != nil {
+			return nil, fmt.Errorf("查询商品 %d 的库存失败: %w", id, err)
+		}
+		states[id] = s
+	}
+	return states, nil
+}
+
+// reservation 压测中预扣成功、尚未回滚的订单
+type reservation struct {
+	orderId int64
+	goodsId int64
+}
+
+// runner 执行压测并记录结果
+type runner struct {
+	client stockv2.StockClient
+	cfg    *config
+	nextId atomic.Int64
+
+	mu       sync.Mutex
+	reserved []reservation   // 可回滚的预扣，越靠后越新
+	reduced  map[int64]int64 // 每个商品预扣成功的数量
+	rolled   map[int64]int64 // 每个商品回滚成功的数量
+	stats    [opCount]*opStats
+	dropped  int64 // 限速模式下因并发不足没有发出的请求数
+}
+
+func newRunner(client stockv2.StockClient, cfg *config) *runner {
+	r := &runner{
+		client:  client,
+		cfg:     cfg,
+		reduced: make(map[int64]int64),
+		rolled:  make(map[int64]int64),
+	}
+	r.nextId.Store(cfg.orderBase)
+	for i := range r.stats {
+		r.stats[i] = newOpStats()
+	}
+	return r
+}
+
+// run 启动 cfg.conc 个 worker，限速时由 pacer 按固定间隔派发请求
+func (r *runner) run(ctx context.Context) {
+	var tokens chan struct{}
+	if r.cfg.rps > 0 {
+		tokens = make(chan struct{}, r.cfg.conc)
+		go r.pace(ctx, tokens)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.cfg.conc; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			w := newWorker(r, seed)
+			for {
+				if tokens != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tokens:
+					}
+				} else if ctx.Err() != nil {
+					return
+				}
+				w.do(ctx)
+			}
+		}(time.Now().UnixNano() + int64(i))
+	}
+	wg.Wait()
+}
+
+// pace 按 rps 派发请求，worker 都在忙时丢弃并计数。
+// 每个 tick 按已经过的时间补齐应发出的请求数，避免高 rps 时 ticker 合并导致实际速率偏低。
+func (r *runner) pace(ctx context.Context, tokens chan<- struct{}) {
+	interval := time.Second / time.Duration(r.cfg.rps)
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	var sent int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			due := int64(now.Sub(start).Seconds()*float64(r.cfg.rps)) - sent
+			for ; due > 0; due-- {
+				sent++
+				select {
+				case tokens <- struct{}{}:
+				default:
+					atomic.AddInt64(&r.dropped, 1)
+				}
+			}
+		}
+	}
+}
+
+// worker 每个并发一个，持有自己的随机数生成器
+type worker struct {
+	r     *runner
+	rnd   *rand.Rand
+	goods func() int64
+	total int
+}
+
+func newWorker(r *runner, seed int64) *worker {
+	w := &worker{r: r, rnd: rand.New(rand.NewSource(seed))}
+	for _, n := range r.cfg.mix {
+		w.total += n
+	}
+	w.goods = hotKey(w.rnd, r.cfg.skew, uint64(r.cfg.maxGoods-r.cfg.minGoods), r.cfg.minGoods)
+	return w
+}
+
+// hotKey 返回按 Zipf 分布从 [base, base+n] 中取值的函数，skew 不大于 1 时均匀分布
+func hotKey(rnd *rand.Rand, skew float64, n uint64, base int64) func() int64 {
+	if skew <= 1 || n == 0 {
+		return func() int64 { return base + rnd.Int63n(int64(n)+1) }
+	}
+	z := rand.NewZipf(rnd, skew, 1, n)
+	// 热点商品分散在范围内，避免总是集中在最小的几个ID上
+	perm := rnd.Perm(int(n) + 1)
+	return func() int64 { return base + int64(perm[z.Uint64()]) }
+}
+
+// pick 按比例选择操作
+func (w *worker) pick() int {
+	n := w.rnd.Intn(w.total)
+	for op, weight := range w.r.cfg.mix {
+		if n < weight {
+			return op
+		}
+		n -= weight
+	}
+	return opGet
+}
+
+func (w *worker) do(parent context.Context) {
+	op := w.pick()
+	ctx, cancel := context.WithTimeout(parent, w.r.cfg.timeout)
+	defer cancel()
+
+	var err error
+	start := time.Now()
+	switch op {
+	case opGet:
+		_, err = w.r.client.GetStock(ctx, &stockv2.GetStockReq{GoodsId: w.goods()})
+	case opReduce:
+		err = w.reduce(ctx)
+	case opRollback:
+		err = w.rollback(ctx)
+	}
+	// 压测结束时被中断的请求不计入结果
+	if parent.Err() != nil {
+		return
+	}
+	w.r.stats[op].record(time.Since(start), err)
+}
+
+// reduce 用新的订单ID预扣一个热点商品
+func (w *worker) reduce(ctx context.Context) error {
+	res := reservation{orderId: w.r.nextId.Add(1), goodsId: w.goods()}
+	resp, err := w.r.client.ReduceStock(ctx, &stockv2.ReduceStockReq{
+		GoodsId:            res.goodsId,
+		Num:                w.r.cfg.num,
+		OrderId:            res.orderId,
+		AutoReleaseSeconds: w.r.cfg.release,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.RecordStatus == stockv2.RecordStatus_RECORD_STATUS_RESERVED {
+		w.r.mu.Lock()
+		w.r.reduced[res.goodsId] += w.r.cfg.num
+		w.r.reserved = append(w.r.reserved, res)
+		w.r.mu.Unlock()
+	}
+	return nil
+}
+
+// rollback 回滚一个压测中预扣的订单，按热点分布偏向最近的订单；没有可回滚的订单时改为查询
+func (w *worker) rollback(ctx context.Context) error {
+	w.r.mu.Lock()
+	n := len(w.r.reserved)
+	if n == 0 {
+		w.r.mu.Unlock()
+		_, err := w.r.client.GetStock(ctx, &stockv2.GetStockReq{GoodsId: w.goods()})
+		return err
+	}
+	i := n - 1
+	if w.r.cfg.skew > 1 && n > 1 {
+		i -= int(rand.NewZipf(w.rnd, w.r.cfg.skew, 1, uint64(n-1)).Uint64())
+	} else {
+		i = w.rnd.Intn(n)
+	}
+	res := w.r.reserved[i]
+	w.r.reserved[i] = w.r.reserved[n-1]
+	w.r.reserved = w.r.reserved[:n-1]
+	w.r.mu.Unlock()
+
+	resp, err := w.r.client.RollbackStock(ctx, &stockv2.RollbackStockReq{
+		GoodsId: res.goodsId,
+		Num:     w.r.cfg.num,
+		OrderId: res.orderId,
+	})
+	if err != nil {
+		// 回滚失败的订单放回去，之后可能再次回滚
+		w.r.mu.Lock()
+		w.r.reserved = append(w.r.reserved, res)
+		w.r.mu.Unlock()
+		return err
+	}
+	if resp.RecordStatus == stockv2.RecordStatus_RECORD_STATUS_ROLLED_BACK {
+		w.r.mu.Lock()
+		w.r.rolled[res.goodsId] += w.r.cfg.num
+		w.r.mu.Unlock()
+	}
+	return nil
+}
+
+// parseMix 解析操作比例，例如 get=60,reduce=30,rollback=10
+func parseMix(s string) ([opCount]int, error) {
+	var mix [opCount]int
+	total := 0
+	for _, part := range strings.Split(s, ",") {
+		name, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return mix, fmt.Errorf("无效的比例: %s", part)
+		}
+		op := -1
+		for i, n := range opNames {
+			if n == name {
+				op = i
+			}
+		}
+		if op < 0 {
+			return mix, fmt.Errorf("未知的操作: %s", name)
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return mix, fmt.Errorf("无效的比例: %s", part)
+		}
+		mix[op] = n
+		total += n
+	}
+	if total == 0 {
+		return mix, errors.New("比例之和必须大于 0")
+	}
+	return mix, nil
+}
+
+// parseRange 解析商品ID范围，例如 1001-1100 或单个 1001
+func parseRange(s string) (int64, int64, error) {
+	lo, hi, ok := strings.Cut(s, "-")
+	if !ok {
+		hi = lo
+	}
+	min, err1 := strconv.ParseInt(lo, 10, 64)
+	max, err2 := strconv.ParseInt(hi, 10, 64)
+	if err1 != nil || err2 != nil || min <= 0 || max < min {
+		return 0, 0, fmt.Errorf("无效的商品ID范围: %s", s)
+	}
+	return min, max, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
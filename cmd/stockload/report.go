@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	stockv2 "stock_service/proto/v2"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// opStats 单个操作的统计
+type opStats struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errs      map[codes.Code]int64
+}
+
+func newOpStats() *opStats {
+	return &opStats{errs: make(map[codes.Code]int64)}
+}
+
+func (s *opStats) record(d time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies = append(s.latencies, d)
+	if err != nil {
+		s.errs[status.Code(err)]++
+	}
+}
+
+// percentile 返回已排序的延迟中的 p 分位数
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+// histogramBounds 延迟直方图的桶上界
+var histogramBounds = []time.Duration{
+	time.Millisecond, 2 * time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 20 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 200 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2 * time.Second, 5 * time.Second,
+}
+
+// report 输出压测结果，存在超卖或库存不一致时返回 false
+func (r *runner) report(w io.Writer, elapsed time.Duration, before, after map[int64]*stockv2.StockState) bool {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	var all []time.Duration
+	var total, failed int64
+
+	fmt.Fprintf(w, "时长 %s，并发 %d", elapsed.Round(time.Millisecond), r.cfg.conc)
+	if r.cfg.rps > 0 {
+		fmt.Fprintf(w, "，目标 %d rps，未发出 %d 个请求", r.cfg.rps, atomic.LoadInt64(&r.dropped))
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "\n== 延迟 ==")
+	fmt.Fprintln(tw, "op\tcount\terrors\trps\tp50\tp90\tp99\tp999\tmax\t")
+	for op, s := range r.stats {
+		lat := append([]time.Duration(nil), s.latencies...)
+		if len(lat) == 0 {
+			continue
+		}
+		sort.Slice(lat, func(i, j int) bool { return lat[i] < lat[j] })
+		all = append(all, lat...)
+		var errs int64
+		for _, n := range s.errs {
+			errs += n
+		}
+		total += int64(len(lat))
+		failed += errs
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\t\n", opNames[op], len(lat), errs,
+			float64(len(lat))/elapsed.Seconds(),
+			fmtDuration(percentile(lat, 0.5)), fmtDuration(percentile(lat, 0.9)),
+			fmtDuration(percentile(lat, 0.99)), fmtDuration(percentile(lat, 0.999)),
+			fmtDuration(lat[len(lat)-1]))
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "共 %d 个请求，失败 %d 个，%.1f rps\n", total, failed, float64(total)/elapsed.Seconds())
+
+	if len(all) > 0 {
+		fmt.Fprintln(w, "\n== 延迟分布 ==")
+		printHistogram(w, all)
+	}
+
+	if failed > 0 {
+		fmt.Fprintln(w, "\n== 错误 ==")
+		fmt.Fprintln(tw, "op\tcode\tcount\t")
+		for op, s := range r.stats {
+			codeList := make([]codes.Code, 0, len(s.errs))
+			for c := range s.errs {
+				codeList = append(codeList, c)
+			}
+			sort.Slice(codeList, func(i, j int) bool { return codeList[i] < codeList[j] })
+			for _, c := range codeList {
+				fmt.Fprintf(tw, "%s\t%s\t%d\t\n", opNames[op], c, s.errs[c])
+			}
+		}
+		tw.Flush()
+	}
+
+	return r.checkOversell(w, before, after)
+}
+
+// checkOversell 对每个商品比较压测期间成功预扣的数量与压测前的可用库存，
+// 并检查压测后的可用库存是否等于压测前的可用库存减去净预扣数量、预扣库存是否增加了净预扣数量（检测丢失的更新）。
+// 预扣和回滚只在可用库存和预扣库存之间转移，库存总数不变。
+func (r *runner) checkOversell(w io.Writer, before, after map[int64]*stockv2.StockState) bool {
+	fmt.Fprintln(w, "\n== 超卖检查 ==")
+	ids := make([]int64, 0, len(before))
+	for id := range before {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	var oversold, inconsistent, reducedTotal int64
+	header := false
+	for _, id := range ids {
+		b, a := before[id], after[id]
+		net := r.reduced[id] - r.rolled[id]
+		reducedTotal += r.reduced[id]
+
+		var problems []string
+		if net > b.Available || a.Available < 0 {
+			oversold++
+			problems = append(problems, "超卖")
+		}
+		if a.Available != b.Available-net || a.Locked != b.Locked+net || a.Total != b.Total {
+			inconsistent++
+			problems = append(problems, "库存不一致")
+		}
+		if len(problems) == 0 {
+			continue
+		}
+		if !header {
+			header = true
+			fmt.Fprintln(tw, "goods_id\tavailable\treduced\trolled_back\tavailable_after\texpected_available\tlocked\texpected_locked\tproblem\t")
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\t\n", id, b.Available, r.reduced[id], r.rolled[id],
+			a.Available, b.Available-net, a.Locked, b.Locked+net, strings.Join(problems, ","))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "%d 个商品，成功预扣 %d 件，超卖 %d 个商品，库存不一致 %d 个商品\n",
+		len(ids), reducedTotal, oversold, inconsistent)
+
+	// 超时的修改请求可能已在服务端生效，这时库存一致性检查会误报
+	uncertain := r.stats[opReduce].errs[codes.DeadlineExceeded] + r.stats[opRollback].errs[codes.DeadlineExceeded]
+	if uncertain > 0 && inconsistent > 0 {
+		fmt.Fprintf(w, "注意：%d 个修改请求超时，结果未知，库存不一致可能是误报\n", uncertain)
+	}
+	return oversold == 0 && inconsistent == 0
+}
+
+// printHistogram 按 histogramBounds 输出延迟直方图
+func printHistogram(w io.Writer, latencies []time.Duration) {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	const width = 40
+	counts := make([]int, len(histogramBounds)+1)
+	i := 0
+	for b, bound := range histogramBounds {
+		for i < len(latencies) && latencies[i] <= bound {
+			counts[b]++
+			i++
+		}
+	}
+	counts[len(histogramBounds)] = len(latencies) - i
+
+	max := 0
+	for _, c := range counts {
+		if c > max {
+			max = c
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 1, ' ', 0)
+	for b, c := range counts {
+		if c == 0 {
+			continue
+		}
+		label := "> " + fmtDuration(histogramBounds[len(histogramBounds)-1])
+		if b < len(histogramBounds) {
+			label = "<= " + fmtDuration(histogramBounds[b])
+		}
+		bar := strings.Repeat("#", (c*width+max-1)/max)
+		fmt.Fprintf(tw, "%s\t%d\t%5.1f%%\t%s\n", label, c, float64(c)*100/float64(len(latencies)), bar)
+	}
+	tw.Flush()
+}
+
+func fmtDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+	default:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+}
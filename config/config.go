@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -27,10 +29,25 @@ type SrvConfig struct {
 	RpcPort  int    `mapstructure:"rpcPort"`
 	HttpPort int    `mapstructure:"httpPort"`
 
-	*LogConfig    `mapstructure:"log"`
-	*MySQLConfig  `mapstructure:"mysql"`
-	*RedisConfig  `mapstructure:"redis"`
-	*ConsulConfig `mapstructure:"consul"`
+	*LogConfig       `mapstructure:"log"`
+	*MySQLConfig     `mapstructure:"mysql"`
+	*RedisConfig     `mapstructure:"redis"`
+	*ConsulConfig    `mapstructure:"consul"`
+	*ReconcileConfig `mapstructure:"reconcile"`
+	*PurgeConfig     `mapstructure:"purge"`
+	*MQConfig        `mapstructure:"mq"`
+	*OutboxConfig    `mapstructure:"outbox"`
+	*ReleaseConfig   `mapstructure:"release"`
+	*StockRuleConfig `mapstructure:"stock_rule"`
+	*MetricsConfig   `mapstructure:"metrics"`
+	*TracingConfig   `mapstructure:"tracing"`
+	*RateLimitConfig `mapstructure:"ratelimit"`
+	*BreakerConfig   `mapstructure:"breaker"`
+	*LoadShedConfig  `mapstructure:"loadshed"`
+	*TLSConfig       `mapstructure:"tls"`
+	*AuthConfig      `mapstructure:"auth"`
+	*RBACConfig      `mapstructure:"rbac"`
+	*GatewayConfig   `mapstructure:"gateway"`
 }
 
 type MySQLConfig struct {
@@ -50,6 +67,8 @@ type RedisConfig struct {
 	DB           int    `mapstructure:"db"`
 	PoolSize     int    `mapstructure:"pool_size"`
 	MinIdleConns int    `mapstructure:"min_idle_conns"`
+
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"` // 不存在的商品的缓存时长，为 0 时不缓存，最长 1 分钟
 }
 
 type LogConfig struct {
@@ -64,6 +83,181 @@ type ConsulConfig struct {
 	Addr string `mapstructure:"addr"`
 }
 
+// MQConfig 消息队列配置
+type MQConfig struct {
+	Type            string   `mapstructure:"type"`              // rocketmq 或 memory，为空时不启动消费者
+	NameServers     []string `mapstructure:"name_servers"`      // RocketMQ NameServer 地址
+	Group           string   `mapstructure:"group"`             // 消费者组
+	RollbackTopic   string   `mapstructure:"rollback_topic"`    // 订单取消消息主题
+	ConfirmTopic    string   `mapstructure:"confirm_topic"`     // 订单支付消息主题，为空时不订阅
+	AdjustTopic     string   `mapstructure:"adjust_topic"`      // 库存调整消息主题，为空时不订阅
+	DeadLetterTopic string   `mapstructure:"dead_letter_topic"` // 死信主题
+	MaxRetries      int32    `mapstructure:"max_retries"`       // 最大重试次数，超过后转入死信主题
+}
+
+// ReconcileConfig 库存对账定时任务配置
+type ReconcileConfig struct {
+	Interval time.Duration `mapstructure:"interval"` // 执行间隔，为 0 时不启用
+	Repair   bool          `mapstructure:"repair"`   // 是否自动修复不一致的预扣库存
+}
+
+// PurgeConfig 过期数据清理定时任务配置
+type PurgeConfig struct {
+	Interval         time.Duration `mapstructure:"interval"`          // 执行间隔，为 0 时不启用
+	StockRetention   time.Duration `mapstructure:"stock_retention"`   // 已删除库存的保留时长
+	OutboxRetention  time.Duration `mapstructure:"outbox_retention"`  // 已投递事件的保留时长
+	MessageRetention time.Duration `mapstructure:"message_retention"` // 消息消费记录的保留时长
+}
+
+// StockRuleConfig 扣减规则配置，ReduceStock 和 CheckStock 共用
+type StockRuleConfig struct {
+	CheckOnShelf bool `mapstructure:"check_on_shelf"` // 是否校验商品（xx_goods）为上架状态，商品表不在同一个库时关闭
+}
+
+// ReleaseConfig 未确认订单超时自动释放库存配置
+type ReleaseConfig struct {
+	Enable       bool          `mapstructure:"enable"`        // 是否启用
+	Timeout      time.Duration `mapstructure:"timeout"`       // 默认超时时间
+	Workers      int           `mapstructure:"workers"`       // 处理任务的协程数
+	PollInterval time.Duration `mapstructure:"poll_interval"` // 轮询到期任务的间隔
+	BatchSize    int           `mapstructure:"batch_size"`    // 每次最多领取的任务数
+	Lease        time.Duration `mapstructure:"lease"`         // 任务租约，超时未处理完会被重新领取
+}
+
+// MetricsConfig Prometheus 监控指标配置
+type MetricsConfig struct {
+	Port     int    `mapstructure:"port"`      // 单独监听的端口，为 0 时挂在 HTTP 网关（httpPort）上
+	Path     string `mapstructure:"path"`      // 指标路径，默认 /metrics
+	MaxGoods int    `mapstructure:"max_goods"` // 按商品统计的指标最多记录的商品数，超过后计入 other
+}
+
+// TracingConfig OpenTelemetry 链路追踪配置
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter"`     // otlp、stdout 或 none，为空时等同 none
+	Endpoint    string  `mapstructure:"endpoint"`     // exporter 为 otlp 时的 gRPC 地址，例如 tempo:4317
+	Insecure    bool    `mapstructure:"insecure"`     // otlp 不使用 TLS
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 采样率（0~1），未配置时全部采样；上游已决定是否采样的请求跟随上游
+}
+
+// GatewayConfig HTTP 网关配置
+type GatewayConfig struct {
+	TrustedProxies []string `mapstructure:"trusted_proxies"` // 可信的反向代理（IP 或 CIDR），只有来自这些地址的请求才采用 X-Forwarded-For 中的客户端地址
+	SwaggerUIURL   string   `mapstructure:"swagger_ui_url"`  // Swagger UI 静态资源（swagger-ui-dist）的地址，内网部署时指向内部镜像，默认 https://unpkg.com/swagger-ui-dist@5
+}
+
+// RateLimitConfig 限流配置，修改配置文件后自动生效
+type RateLimitConfig struct {
+	Backend string          `mapstructure:"backend"` // local（每个实例单独计数）或 redis（所有实例共享），为空时不限流
+	Rules   []RateLimitRule `mapstructure:"rules"`   // 令牌桶规则，请求匹配的每条规则都要拿到令牌
+}
+
+// RateLimitRule 一条令牌桶规则
+type RateLimitRule struct {
+	Method string  `mapstructure:"method"` // gRPC 完整方法名，例如 /stock.v2.Stock/ReduceStock，* 表示所有方法共用一组桶
+	Caller string  `mapstructure:"caller"` // 只对这个调用方生效，为空时对所有调用方生效
+	Key    string  `mapstructure:"key"`    // 分桶方式：method（每个方法一个桶）、caller（每个调用方一个桶）、goods（每个商品一个桶）
+	Rate   float64 `mapstructure:"rate"`   // 每秒放入的令牌数
+	Burst  int     `mapstructure:"burst"`  // 桶的容量，为 0 时取 rate（至少为 1）
+}
+
+// BreakerConfig MySQL、Redis 熔断配置，两者使用相同的参数，零值字段使用默认值
+type BreakerConfig struct {
+	Enable              bool          `mapstructure:"enable"`               // 是否启用
+	ConsecutiveFailures uint32        `mapstructure:"consecutive_failures"` // 连续失败多少次熔断，默认 5
+	MinRequests         uint32        `mapstructure:"min_requests"`         // 统计窗口内至少有多少请求才按失败率判断，默认 20
+	FailureRatio        float64       `mapstructure:"failure_ratio"`        // 失败率达到多少熔断，默认 0.5
+	Interval            time.Duration `mapstructure:"interval"`             // 闭合状态下的统计窗口，默认 10s
+	OpenTimeout         time.Duration `mapstructure:"open_timeout"`         // 熔断多久后进入半开状态，默认 5s
+	HalfOpenRequests    uint32        `mapstructure:"half_open_requests"`   // 半开状态放行的探测请求数，默认 3
+}
+
+// LoadShedConfig 自适应并发限制配置，零值字段使用默认值
+type LoadShedConfig struct {
+	Enable       bool          `mapstructure:"enable"`        // 是否启用
+	InitialLimit int           `mapstructure:"initial_limit"` // 初始并发上限，默认 100
+	MinLimit     int           `mapstructure:"min_limit"`     // 并发上限的下限，默认 10
+	MaxLimit     int           `mapstructure:"max_limit"`     // 并发上限的上限，默认 1000
+	Tolerance    float64       `mapstructure:"tolerance"`     // 延迟超过基线的多少倍时开始降低上限，默认 1.5
+	Window       time.Duration `mapstructure:"window"`        // 调整上限的间隔，默认 1s
+}
+
+// TLSConfig gRPC 服务 TLS 配置，证书文件更新后自动重新加载，不需要重启服务
+type TLSConfig struct {
+	Enable         bool          `mapstructure:"enable"`          // 是否启用，关闭时使用明文连接
+	CertFile       string        `mapstructure:"cert_file"`       // 服务端证书
+	KeyFile        string        `mapstructure:"key_file"`        // 服务端私钥
+	ClientCAFile   string        `mapstructure:"client_ca_file"`  // 校验客户端证书的 CA，设置后要求客户端证书（mTLS）
+	ReloadInterval time.Duration `mapstructure:"reload_interval"` // 检查证书文件是否更新的间隔，默认 1m
+}
+
+// AuthConfig 调用方认证配置，修改配置文件后自动生效。
+// 请求依次尝试 API Key、HMAC 签名、JWT 和 mTLS 客户端证书，认证通过的调用方用于限流和权限校验。
+type AuthConfig struct {
+	Enable      bool               `mapstructure:"enable"`       // 是否要求认证，关闭时调用方取调用方 IP
+	SkipMethods []string           `mapstructure:"skip_methods"` // 不需要认证的 gRPC 完整方法名，健康检查始终不需要认证
+	APIKeys     []APIKeyConfig     `mapstructure:"api_keys"`     // 静态 API Key，通过 x-api-key 传递
+	HMAC        *HMACConfig        `mapstructure:"hmac"`         // HMAC 签名，通过 authorization: HMAC-SHA256 传递
+	JWT         *JWTConfig         `mapstructure:"jwt"`          // JWT，通过 authorization: Bearer 传递
+	ClientCerts []ClientCertConfig `mapstructure:"client_certs"` // mTLS 客户端证书与调用方的对应关系，需要配置 tls.client_ca_file
+}
+
+// ClientCertConfig 一个客户端证书对应的调用方
+type ClientCertConfig struct {
+	Name   string   `mapstructure:"name"`   // 证书的 CN 或 SAN（DNS、URI、邮箱）
+	Caller string   `mapstructure:"caller"` // 调用方，为空时取 name
+	Roles  []string `mapstructure:"roles"`
+}
+
+// APIKeyConfig 一个 API Key 及其对应的调用方
+type APIKeyConfig struct {
+	Key    string   `mapstructure:"key"`
+	Caller string   `mapstructure:"caller"`
+	Roles  []string `mapstructure:"roles"`
+}
+
+// HMACConfig HMAC 签名认证配置
+type HMACConfig struct {
+	MaxSkew       time.Duration   `mapstructure:"max_skew"`       // 签名时间与服务端时间允许的最大偏差，默认 5m
+	ReplayBackend string          `mapstructure:"replay_backend"` // 记录用过的随机数：local（每个实例单独记录，默认）或 redis（所有实例共享）
+	Keys          []HMACKeyConfig `mapstructure:"keys"`
+}
+
+// HMACKeyConfig 一个调用方的签名密钥
+type HMACKeyConfig struct {
+	Caller string   `mapstructure:"caller"`
+	Secret string   `mapstructure:"secret"`
+	Roles  []string `mapstructure:"roles"`
+}
+
+// JWTConfig JWT 认证配置，secret 和 public_key_file 二选一
+type JWTConfig struct {
+	Secret        string `mapstructure:"secret"`          // HS256 密钥
+	PublicKeyFile string `mapstructure:"public_key_file"` // RS256、ES256 或 EdDSA 公钥（PEM）
+	Issuer        string `mapstructure:"issuer"`          // 要求的 iss，为空时不校验
+	Audience      string `mapstructure:"audience"`        // 要求的 aud，为空时不校验
+	CallerClaim   string `mapstructure:"caller_claim"`    // 调用方所在的 claim，默认 sub
+	RolesClaim    string `mapstructure:"roles_claim"`     // 角色所在的 claim（列表或空格分隔的字符串），默认 roles
+}
+
+// RBACConfig 基于角色的权限校验配置，策略文件修改后自动生效，修改本配置需要重启服务
+type RBACConfig struct {
+	Enable     bool   `mapstructure:"enable"`      // 是否启用，关闭时不校验权限
+	PolicyFile string `mapstructure:"policy_file"` // 策略文件，定义每个角色可以调用的方法和商品范围
+}
+
+// OutboxConfig 库存变更事件投递配置
+type OutboxConfig struct {
+	Publisher      string        `mapstructure:"publisher"`       // mq、webhook 或 log，为空时不投递
+	Topic          string        `mapstructure:"topic"`           // publisher 为 mq 时的主题
+	WebhookURL     string        `mapstructure:"webhook_url"`     // publisher 为 webhook 时的地址
+	WebhookTimeout time.Duration `mapstructure:"webhook_timeout"` // webhook 请求超时时间
+	Interval       time.Duration `mapstructure:"interval"`        // 轮询间隔
+	BatchSize      int           `mapstructure:"batch_size"`      // 每轮最多投递的事件数
+	BaseDelay      time.Duration `mapstructure:"base_delay"`      // 首次重试间隔
+	MaxDelay       time.Duration `mapstructure:"max_delay"`       // 最长重试间隔
+	MaxRetries     int32         `mapstructure:"max_retries"`     // 最多重试次数，用完后事件被搁置，默认 20
+}
+
 // Init 整个服务配置文件初始化的方法
 func Init(filePath string) (err error) {
 	// 方式1：直接指定配置文件路径（相对路径或者绝对路径）
@@ -90,6 +284,36 @@ func Init(filePath string) (err error) {
 		if err := viper.Unmarshal(Conf); err != nil {
 			fmt.Printf("viper.Unmarshal failed, err:%v\n", err)
 		}
+		notify()
 	})
 	return
 }
+
+var (
+	watchersMu sync.Mutex
+	watchers   []func()
+)
+
+// OnChange 注册配置文件修改后的回调，回调在 Conf 更新之后按注册顺序执行
+func OnChange(fn func()) {
+	watchersMu.Lock()
+	defer watchersMu.Unlock()
+	watchers = append(watchers, fn)
+}
+
+// notify 执行配置修改的回调
+func notify() {
+	watchersMu.Lock()
+	fns := append([]func(){}, watchers...)
+	watchersMu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// UnmarshalKey 把配置文件中 key 对应的部分解析到 out 中。
+// 配置文件修改后 Conf 是原地更新的，列表中删掉的元素不会被清除，
+// 需要完整读取新配置的模块（限流规则等）在 OnChange 的回调中用它解析到新的变量里。
+func UnmarshalKey(key string, out any) error {
+	return viper.UnmarshalKey(key, out)
+}
@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"stock_service/dao/redis"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// AdjustStock 按增量调整库存数量（入库、盘点、报损等），调整后的库存数量不能小于 0。
+// 每次调整写入一条审计记录和库存变更事件。
+func AdjustStock(ctx context.Context, goodsId, delta int64, reason, operator string) (*model.Stock, error) {
+	mutex := redis.Rs.NewMutex(fmt.Sprintf("xx-stock-%d", goodsId))
+	if err := redis.LockStock(ctx, mutex); err != nil {
+		return nil, errno.Or(err, errno.ErrAdjustStockFailed.WithMeta("goods_id", goodsId))
+	}
+	defer mutex.Unlock()
+
+	var stock model.Stock
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 消息去重或幂等控制，重复的消息或请求直接返回。
+		if err := markConsumed(ctx, tx); err != nil {
+			return err
+		}
+
+		err := tx.Where("goods_id = ?", goodsId).
+			Scopes(notDeleted).
+			First(&stock).Error
+		if err == gorm.ErrRecordNotFound {
+			return errno.ErrQueryEmpty.WithMeta("goods_id", goodsId)
+		}
+		if err != nil {
+			return err
+		}
+
+		if stock.StockNum+delta < 0 {
+			logger.Ctx(ctx).Warn("调整后库存小于 0",
+				zap.Int64("goods_id", goodsId),
+				zap.Int64("stock_num", stock.StockNum),
+				zap.Int64("delta", delta))
+			return errno.ErrUnderstock.
+				WithMeta("goods_id", goodsId).
+				WithMeta("stock", stock.StockNum).
+				WithMeta("delta", delta)
+		}
+
+		audit := model.StockAudit{
+			GoodsId:     goodsId,
+			Action:      "adjust",
+			BeforeStock: stock.StockNum,
+			AfterStock:  stock.StockNum + delta,
+			BeforeLock:  stock.Lock,
+			AfterLock:   stock.Lock,
+			Remark:      reason,
+		}
+		audit.CreateBy = operator
+
+		stock.StockNum += delta
+		if err := tx.Save(&stock).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&audit).Error; err != nil {
+			return err
+		}
+		return addOutboxEvent(tx, model.EventStockAdjusted, &stock, 0, delta)
+	})
+	if err != nil {
+		logger.Ctx(ctx).Error("调整库存失败", zap.Int64("goods_id", goodsId), zap.Int64("delta", delta), zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return &stock, nil
+}
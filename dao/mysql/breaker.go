@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"errors"
+
+	"stock_service/breaker"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// MySQL 熔断：每条 SQL 执行前检查熔断器，熔断中直接返回 ErrUnavailable，不再等待连接池。
+// 事务的 BEGIN 不经过 GORM 的回调，熔断中开启的事务在第一条 SQL 处返回。
+
+var dbBreaker *breaker.Breaker
+
+// overloadErrors 说明 MySQL 过载的错误码：连接数过多、锁等待超时、查询超时
+var overloadErrors = map[uint16]bool{
+	1040: true, // ER_CON_COUNT_ERROR
+	1205: true, // ER_LOCK_WAIT_TIMEOUT
+	3024: true, // ER_QUERY_TIMEOUT
+}
+
+// isFailure MySQL 返回了错误码说明连接是正常的（唯一键冲突等），过载类的错误码除外；
+// 其余错误（连接失败、超时等）说明 MySQL 出了问题
+func isFailure(err error) bool {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false
+	}
+	var me *mysqldriver.MySQLError
+	if errors.As(err, &me) {
+		return overloadErrors[me.Number]
+	}
+	return true
+}
+
+// queryBreaker GORM 插件，SQL 执行前检查熔断器，执行后记录结果
+type queryBreaker struct{}
+
+// breakerDoneKey 保存熔断器回调的 key
+const breakerDoneKey = "breaker:done"
+
+func (queryBreaker) Name() string {
+	return "breaker"
+}
+
+func (queryBreaker) Initialize(db *gorm.DB) error {
+	return registerAround(db, "breaker",
+		func(string) func(*gorm.DB) { return allowQuery },
+		func(string) func(*gorm.DB) { return doneQuery },
+	)
+}
+
+func allowQuery(tx *gorm.DB) {
+	done, err := dbBreaker.Allow()
+	if err != nil {
+		// 已有错误时 GORM 不再执行 SQL
+		tx.AddError(err)
+		return
+	}
+	tx.InstanceSet(breakerDoneKey, done)
+}
+
+func doneQuery(tx *gorm.DB) {
+	v, ok := tx.InstanceGet(breakerDoneKey)
+	if !ok {
+		return
+	}
+	if done, ok := v.(func(error)); ok {
+		done(tx.Error)
+	}
+}
@@ -0,0 +1,177 @@
+package mysql
+
+import (
+	"context"
+
+	"stock_service/config"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 扣减规则：ReduceStock 和预检（CheckStock）共用这一套校验，新增扣减规则时加在这里，
+// 预检的结果才会与实际扣减一致。校验顺序：数量、库存存在、商品上架、可用库存、限购、直播间配额。
+
+// Buyer 扣减的购买方：限购按用户统计，配额按直播间统计，为 0 时不校验对应的规则
+type Buyer struct {
+	UserId int64 // 用户ID
+	RoomId int64 // 直播间ID
+}
+
+// ReduceRules 一组商品的扣减规则，以及用户和直播间已经占用（预扣和已扣减）的数量
+type ReduceRules struct {
+	buyer    Buyer
+	onShelf  map[int64]bool  // 商品是否上架，未开启上架校验时为 nil
+	userUsed map[int64]int64 // 用户已占用的数量
+	quota    map[int64]int64 // 直播间的配额，商品不在直播间时没有这一项
+	roomUsed map[int64]int64 // 直播间已占用的数量
+}
+
+// usedRow 按商品汇总的占用数量
+type usedRow struct {
+	GoodsId int64
+	Used    int64
+}
+
+// LoadReduceRules 查询一组商品的扣减规则，不加锁，用于预检
+func LoadReduceRules(ctx context.Context, goodsIds []int64, buyer Buyer) (*ReduceRules, error) {
+	return loadReduceRules(db.WithContext(ctx), goodsIds, buyer)
+}
+
+// loadReduceRules 在 tx 中查询扣减规则，每条规则对整组商品只查询一次
+func loadReduceRules(tx *gorm.DB, goodsIds []int64, buyer Buyer) (*ReduceRules, error) {
+	ctx := tx.Statement.Context
+	r := &ReduceRules{buyer: buyer}
+
+	if cfg := config.Conf.StockRuleConfig; cfg != nil && cfg.CheckOnShelf {
+		var goods []*model.Goods
+		err := tx.Model(&model.Goods{}).
+			Select("goods_id", "status").
+			Where("goods_id IN ?", goodsIds).
+			Scopes(notDeleted).
+			Find(&goods).Error
+		if err != nil {
+			logger.Ctx(ctx).Error("查询商品状态失败", zap.Int64s("goods_ids", goodsIds), zap.Error(err))
+			return nil, dbError(err, errno.ErrQueryFailed)
+		}
+		r.onShelf = make(map[int64]bool, len(goods))
+		for _, g := range goods {
+			r.onShelf[g.GoodsId] = g.Status == model.GoodsOnShelf
+		}
+	}
+
+	if buyer.UserId > 0 {
+		used, err := sumReserved(tx, goodsIds, "user_id = ?", buyer.UserId)
+		if err != nil {
+			logger.Ctx(ctx).Error("查询用户已购数量失败", zap.Int64("user_id", buyer.UserId), zap.Error(err))
+			return nil, err
+		}
+		r.userUsed = used
+	}
+
+	if buyer.RoomId > 0 {
+		var rooms []*model.RoomGoods
+		err := tx.Model(&model.RoomGoods{}).
+			Select("goods_id", "quota").
+			Where("room_id = ? AND goods_id IN ?", buyer.RoomId, goodsIds).
+			Scopes(notDeleted).
+			Find(&rooms).Error
+		if err != nil {
+			logger.Ctx(ctx).Error("查询直播间配额失败", zap.Int64("room_id", buyer.RoomId), zap.Error(err))
+			return nil, dbError(err, errno.ErrQueryFailed)
+		}
+		r.quota = make(map[int64]int64, len(rooms))
+		for _, g := range rooms {
+			r.quota[g.GoodsId] = g.Quota
+		}
+		used, err := sumReserved(tx, goodsIds, "room_id = ?", buyer.RoomId)
+		if err != nil {
+			logger.Ctx(ctx).Error("查询直播间已售数量失败", zap.Int64("room_id", buyer.RoomId), zap.Error(err))
+			return nil, err
+		}
+		r.roomUsed = used
+	}
+	return r, nil
+}
+
+// sumReserved 按商品汇总满足条件的预扣和已扣减的数量
+func sumReserved(tx *gorm.DB, goodsIds []int64, query string, args ...any) (map[int64]int64, error) {
+	var rows []usedRow
+	err := tx.Model(&model.StockRecord{}).
+		Select("goods_id, SUM(num) AS used").
+		Where(query, args...).
+		Where("goods_id IN ? AND status IN ?", goodsIds, []int32{model.StockRecordReserved, model.StockRecordConfirmed}).
+		Scopes(notDeleted).
+		Group("goods_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	used := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		used[row.GoodsId] = row.Used
+	}
+	return used, nil
+}
+
+// Check 校验库存能否扣减 num，s 为 nil 表示库存不存在或已删除
+func (r *ReduceRules) Check(s *model.Stock, goodsId, num int64) error {
+	if num <= 0 {
+		return errno.ErrInvalidParam.WithMessage("扣减数量必须大于 0").WithMeta("goods_id", goodsId)
+	}
+	if s == nil {
+		return errno.ErrQueryEmpty.WithMeta("goods_id", goodsId)
+	}
+	// 商品表中没有的商品按未上架处理
+	if r.onShelf != nil && !r.onShelf[goodsId] {
+		return errno.ErrGoodsOffShelf.WithMeta("goods_id", goodsId)
+	}
+	// 可用库存：预扣时已经从 stocknum 中减去，不能再减锁定库存
+	if available := s.StockNum; available < num {
+		return errno.ErrUnderstock.
+			WithMeta("goods_id", goodsId).
+			WithMeta("available", available).
+			WithMeta("requested", num)
+	}
+	if r.buyer.UserId > 0 && s.UserLimit > 0 {
+		if used := r.userUsed[goodsId]; used+num > s.UserLimit {
+			return errno.ErrPurchaseLimit.
+				WithMeta("goods_id", goodsId).
+				WithMeta("user_id", r.buyer.UserId).
+				WithMeta("limit", s.UserLimit).
+				WithMeta("used", used).
+				WithMeta("requested", num)
+		}
+	}
+	// 商品不在该直播间时不校验配额
+	if quota := r.quota[goodsId]; quota > 0 {
+		if used := r.roomUsed[goodsId]; used+num > quota {
+			return errno.ErrRoomQuota.
+				WithMeta("goods_id", goodsId).
+				WithMeta("room_id", r.buyer.RoomId).
+				WithMeta("quota", quota).
+				WithMeta("used", used).
+				WithMeta("requested", num)
+		}
+	}
+	return nil
+}
+
+// Allowance 最多还能扣减的数量：可用库存、限购和直播间配额剩余数量中最小的一个，
+// 库存不存在或商品未上架时为 0
+func (r *ReduceRules) Allowance(s *model.Stock, goodsId int64) int64 {
+	if s == nil || (r.onShelf != nil && !r.onShelf[goodsId]) {
+		return 0
+	}
+	n := s.StockNum
+	if r.buyer.UserId > 0 && s.UserLimit > 0 {
+		n = min(n, s.UserLimit-r.userUsed[goodsId])
+	}
+	if quota := r.quota[goodsId]; quota > 0 {
+		n = min(n, quota-r.roomUsed[goodsId])
+	}
+	return max(n, 0)
+}
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"stock_service/dao/redis"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConfirmStock 订单支付后确认扣减：预扣库存转为实际扣减，库存记录状态置为 2（已扣减）。
+// 已确认的记录重复确认直接返回（库存为 nil）；已回滚或已超时释放的记录返回 ErrReservationReleased。
+// 与回滚、超时释放持有同一把商品维度的分布式锁，事务内对库存记录和库存行加行锁，
+// 状态只允许从预扣减（1）转换为已扣减（2）。
+func ConfirmStock(ctx context.Context, goodsId, orderId int64) (*model.Stock, *model.StockRecord, error) {
+	mutex := redis.Rs.NewMutex(fmt.Sprintf("xx-stock-%d", goodsId))
+	if err := redis.LockStock(ctx, mutex); err != nil {
+		return nil, nil, errno.Or(err, errno.ErrConfirmStockFailed.WithMeta("goods_id", goodsId))
+	}
+	defer mutex.Unlock()
+
+	var record model.StockRecord
+	var confirmed *model.Stock
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 消息去重或幂等控制，重复的消息或请求直接返回。
+		if err := markConsumed(ctx, tx); err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ? and goods_id = ?", orderId, goodsId).
+			Scopes(notDeleted).
+			First(&record).Error
+		if err == gorm.ErrRecordNotFound {
+			return errno.ErrQueryEmpty.WithMeta("goods_id", goodsId).WithMeta("order_id", orderId)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch record.Status {
+		case model.StockRecordConfirmed:
+			return nil
+		case model.StockRecordRolledBack, model.StockRecordExpired:
+			return errno.ErrReservationReleased.
+				WithMeta("goods_id", goodsId).
+				WithMeta("order_id", orderId).
+				WithMeta("status", record.Status)
+		}
+
+		// 状态转换放在修改库存之前，记录已不是预扣减状态时整个事务回滚
+		result := tx.Model(&record).
+			Where("status = ?", model.StockRecordReserved).
+			Update("status", model.StockRecordConfirmed)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errno.ErrConfirmStockFailed.
+				WithMeta("goods_id", goodsId).
+				WithMeta("order_id", orderId).
+				WithMeta("status", record.Status)
+		}
+		record.Status = model.StockRecordConfirmed
+
+		var stock model.Stock
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("goods_id = ?", goodsId).
+			Scopes(notDeleted).
+			First(&stock).Error
+		if err != nil {
+			return err
+		}
+
+		// 预扣时已经扣过库存数量，这里只减少锁定库存。
+		stock.Lock -= record.Num
+		if stock.Lock < 0 {
+			logger.Ctx(ctx).Error("确认扣减失败，锁定库存不足",
+				zap.Int64("goods_id", goodsId),
+				zap.Int64("lock", stock.Lock))
+			return errno.ErrConfirmStockFailed.WithMeta("goods_id", goodsId).WithMeta("lock", stock.Lock+record.Num)
+		}
+		if err := tx.Save(&stock).Error; err != nil {
+			return err
+		}
+		if err := addOutboxEvent(tx, model.EventStockConfirmed, &stock, orderId, record.Num); err != nil {
+			return err
+		}
+		confirmed = &stock
+		return nil
+	})
+	if err != nil {
+		logger.Ctx(ctx).Error("确认扣减库存失败", zap.Int64("goods_id", goodsId), zap.Int64("order_id", orderId), zap.Error(err))
+		return nil, nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return confirmed, &record, nil
+}
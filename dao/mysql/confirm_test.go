@@ -0,0 +1,73 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"stock_service/errno"
+	"stock_service/model"
+)
+
+func TestConfirmStock(t *testing.T) {
+	setupDB(t)
+	ctx := context.Background()
+	if _, err := SetStock(ctx, 1001, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ReduceStock(ctx, 1001, 3, 1, Buyer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	stock, record, err := ConfirmStock(ctx, 1001, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stock.StockNum != 7 || stock.Lock != 0 {
+		t.Errorf("stock = %d/%d, want 7 available and 0 locked", stock.StockNum, stock.Lock)
+	}
+	if record.Status != model.StockRecordConfirmed {
+		t.Errorf("record status = %d, want %d", record.Status, model.StockRecordConfirmed)
+	}
+
+	// 重复确认直接返回，不再修改库存
+	stock, record, err = ConfirmStock(ctx, 1001, 1)
+	if err != nil || stock != nil || record.Status != model.StockRecordConfirmed {
+		t.Fatalf("second confirm: stock=%v record=%v err=%v, want nil stock", stock, record, err)
+	}
+	if s, _ := GetStockByGoodsId(ctx, 1001); s.StockNum != 7 || s.Lock != 0 {
+		t.Errorf("stock after second confirm = %d/%d, want 7/0", s.StockNum, s.Lock)
+	}
+}
+
+func TestConfirmStockAfterRelease(t *testing.T) {
+	setupDB(t)
+	ctx := context.Background()
+	if _, err := SetStock(ctx, 1001, 10); err != nil {
+		t.Fatal(err)
+	}
+	for orderId := int64(1); orderId <= 2; orderId++ {
+		if _, _, err := ReduceStock(ctx, 1001, 2, orderId, Buyer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := RollbackStockByMsg(ctx, model.StockRecord{GoodsId: 1001, OrderId: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ReleaseStock(ctx, model.StockRecord{GoodsId: 1001, OrderId: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 已回滚或已超时释放的预扣不能再确认
+	for orderId := int64(1); orderId <= 2; orderId++ {
+		if _, _, err := ConfirmStock(ctx, 1001, orderId); !errors.Is(err, errno.ErrReservationReleased) {
+			t.Errorf("confirm order %d: %v, want ErrReservationReleased", orderId, err)
+		}
+	}
+	if _, _, err := ConfirmStock(ctx, 1001, 3); !errors.Is(err, errno.ErrQueryEmpty) {
+		t.Errorf("confirm unknown order: %v, want ErrQueryEmpty", err)
+	}
+	if s, _ := GetStockByGoodsId(ctx, 1001); s.StockNum != 10 || s.Lock != 0 {
+		t.Errorf("stock = %d/%d, want 10/0", s.StockNum, s.Lock)
+	}
+}
@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 消息去重：消息驱动的修改通过 WithConsumedMessage 把消息ID和消费者组放进 ctx，
+// dao 在修改库存的同一个事务里先写入消费记录，唯一键冲突说明消息已经处理过，
+// 整个事务回滚并返回 ErrDuplicateMessage。
+// v2 接口的幂等键也复用这张表（WithIdempotencyKey）：消息ID为幂等键，消费者组为 "rpc:" + 方法名，
+// 同时记录请求内容的哈希，幂等键被内容不同的请求复用时返回 ErrIdempotencyKeyReused；
+// 首次请求成功后由 SaveResponse 保存响应，重复请求用 GetResponse 取出原样返回。
+
+type consumedMessageKey struct{}
+
+type consumedMessage struct {
+	msgId       string
+	group       string
+	requestHash string // 为空时不校验请求内容（消息去重）
+}
+
+// WithConsumedMessage 返回带有消息ID和消费者组的 ctx
+func WithConsumedMessage(ctx context.Context, msgId, group string) context.Context {
+	return context.WithValue(ctx, consumedMessageKey{}, consumedMessage{msgId: msgId, group: group})
+}
+
+// WithIdempotencyKey 返回带有幂等键的 ctx，requestHash 为请求内容的哈希
+func WithIdempotencyKey(ctx context.Context, key, group, requestHash string) context.Context {
+	return context.WithValue(ctx, consumedMessageKey{}, consumedMessage{msgId: key, group: group, requestHash: requestHash})
+}
+
+// markConsumed 在事务 tx 中写入消费记录，ctx 中没有消息信息时不做处理
+func markConsumed(ctx context.Context, tx *gorm.DB) error {
+	m, ok := ctx.Value(consumedMessageKey{}).(consumedMessage)
+	if !ok {
+		return nil
+	}
+
+	err := tx.Create(&model.ConsumedMessage{
+		MsgId:         m.msgId,
+		ConsumerGroup: m.group,
+		RequestHash:   m.requestHash,
+	}).Error
+	if !isDuplicateKey(err) {
+		return err
+	}
+	if m.requestHash != "" {
+		// 冲突的记录已经提交，在事务外查询才能读到
+		var prev model.ConsumedMessage
+		err := db.WithContext(ctx).
+			Select("request_hash").
+			Where("msg_id = ? AND consumer_group = ?", m.msgId, m.group).
+			Take(&prev).Error
+		if err != nil {
+			logger.Ctx(ctx).Error("查询幂等键失败", zap.String("key", m.msgId), zap.String("group", m.group), zap.Error(err))
+			return dbError(err, errno.ErrQueryFailed)
+		}
+		if prev.RequestHash != m.requestHash {
+			logger.Ctx(ctx).Warn("幂等键被内容不同的请求复用", zap.String("key", m.msgId), zap.String("group", m.group))
+			return errno.ErrIdempotencyKeyReused.WithMeta("idempotency_key", m.msgId)
+		}
+	}
+	logger.Ctx(ctx).Info("重复消息，跳过", zap.String("msg_id", m.msgId), zap.String("group", m.group))
+	return errno.ErrDuplicateMessage
+}
+
+// SaveResponse 保存首次请求的响应，ctx 中没有幂等键时不做处理
+func SaveResponse(ctx context.Context, resp []byte) error {
+	m, ok := ctx.Value(consumedMessageKey{}).(consumedMessage)
+	if !ok || m.requestHash == "" {
+		return nil
+	}
+	err := db.WithContext(ctx).
+		Model(&model.ConsumedMessage{}).
+		Where("msg_id = ? AND consumer_group = ?", m.msgId, m.group).
+		Update("response", resp).Error
+	if err != nil {
+		logger.Ctx(ctx).Error("保存幂等响应失败", zap.String("key", m.msgId), zap.String("group", m.group), zap.Error(err))
+		return dbError(err, errno.ErrQueryFailed)
+	}
+	return nil
+}
+
+// GetResponse 查询首次请求保存的响应，ctx 中没有幂等键或还没有保存时返回 nil
+func GetResponse(ctx context.Context) ([]byte, error) {
+	m, ok := ctx.Value(consumedMessageKey{}).(consumedMessage)
+	if !ok || m.requestHash == "" {
+		return nil, nil
+	}
+	var prev model.ConsumedMessage
+	err := db.WithContext(ctx).
+		Select("response").
+		Where("msg_id = ? AND consumer_group = ?", m.msgId, m.group).
+		Take(&prev).Error
+	if err != nil {
+		logger.Ctx(ctx).Error("查询幂等响应失败", zap.String("key", m.msgId), zap.String("group", m.group), zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	if len(prev.Response) == 0 {
+		return nil, nil
+	}
+	return prev.Response, nil
+}
+
+// PurgeConsumedMessages 物理删除消费时间早于 before 的消费记录，分批执行，返回删除的行数。
+func PurgeConsumedMessages(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for {
+		result := db.WithContext(ctx).
+			Where("create_at < ?", before).
+			Limit(purgeBatchSize).
+			Delete(&model.ConsumedMessage{})
+		if result.Error != nil {
+			logger.Ctx(ctx).Error("清理消费记录失败", zap.Error(result.Error))
+			return total, dbError(result.Error, errno.ErrQueryFailed)
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stock_service/dao/redis"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// purgeBatchSize 清理已删除库存时每批删除的行数
+const purgeBatchSize = 1000
+
+// isDeleted 在事务 tx 中判断商品的库存是否已被软删除
+func isDeleted(tx *gorm.DB, goodsId int64) (bool, error) {
+	var n int64
+	err := tx.Model(&model.Stock{}).
+		Where("goods_id = ? and is_del = 1", goodsId).
+		Count(&n).Error
+	if err != nil {
+		logger.Ctx(tx.Statement.Context).Error("查询库存删除状态失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return false, dbError(err, errno.ErrQueryFailed)
+	}
+	return n > 0, nil
+}
+
+// DeleteStock 软删除商品库存，还有预扣库存的商品不允许删除。
+func DeleteStock(ctx context.Context, goodsId int64) (*model.Stock, error) {
+	return setDeleted(ctx, goodsId, 1)
+}
+
+// UndeleteStock 恢复已软删除的商品库存。
+func UndeleteStock(ctx context.Context, goodsId int64) (*model.Stock, error) {
+	return setDeleted(ctx, goodsId, 0)
+}
+
+// setDeleted 修改库存的删除标记，持有与扣减库存相同的分布式锁。
+// 删除标记变化时 update_at 随之更新，清理任务以它作为删除时间。
+func setDeleted(ctx context.Context, goodsId int64, isDel int8) (*model.Stock, error) {
+	mutex := redis.Rs.NewMutex(fmt.Sprintf("xx-stock-%d", goodsId))
+	if err := redis.LockStock(ctx, mutex); err != nil {
+		return nil, errno.Or(err, errno.ErrQueryFailed.WithMeta("goods_id", goodsId))
+	}
+	defer mutex.Unlock()
+
+	var stock model.Stock
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 幂等控制，重复请求直接返回。
+		if err := markConsumed(ctx, tx); err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("goods_id = ? and is_del = ?", goodsId, 1-isDel).
+			First(&stock).Error
+		if err == gorm.ErrRecordNotFound {
+			return errno.ErrQueryEmpty.WithMeta("goods_id", goodsId)
+		}
+		if err != nil {
+			logger.Ctx(ctx).Error("查询库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+			return dbError(err, errno.ErrQueryFailed)
+		}
+
+		if isDel == 1 && stock.Lock > 0 {
+			logger.Ctx(ctx).Warn("存在预扣库存，不允许删除", zap.Int64("goods_id", goodsId), zap.Int64("lock", stock.Lock))
+			return errno.ErrStockLocked.WithMeta("goods_id", goodsId).WithMeta("lock", stock.Lock)
+		}
+
+		err = tx.Model(&stock).Update("is_del", isDel).Error
+		if err != nil {
+			logger.Ctx(ctx).Error("修改库存删除标记失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+			return dbError(err, errno.ErrQueryFailed)
+		}
+
+		eventType := model.EventStockUndeleted
+		if isDel == 1 {
+			eventType = model.EventStockDeleted
+		}
+		return addOutboxEvent(tx, eventType, &stock, 0, 0)
+	})
+	if err != nil {
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return &stock, nil
+}
+
+// PurgeDeletedStock 物理删除软删除时间早于 before 的库存，分批执行，返回删除的行数。
+func PurgeDeletedStock(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for {
+		result := db.WithContext(ctx).
+			Where("is_del = 1 and update_at < ?", before).
+			Limit(purgeBatchSize).
+			Delete(&model.Stock{})
+		if result.Error != nil {
+			logger.Ctx(ctx).Error("清理已删除库存失败", zap.Error(result.Error))
+			return total, dbError(result.Error, errno.ErrQueryFailed)
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
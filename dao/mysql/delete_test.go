@@ -0,0 +1,43 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"stock_service/errno"
+)
+
+func TestSetStockOnDeletedStock(t *testing.T) {
+	setupDB(t)
+	ctx := context.Background()
+	if _, err := SetStock(ctx, 1001, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DeleteStock(ctx, 1001); err != nil {
+		t.Fatal(err)
+	}
+
+	// 已删除的库存不能直接重新设置，需要先恢复
+	if _, err := SetStock(ctx, 1001, 5); !errors.Is(err, errno.ErrStockDeleted) {
+		t.Fatalf("SetStock on deleted stock: %v, want ErrStockDeleted", err)
+	}
+	if _, err := UndeleteStock(ctx, 1001); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := SetStock(ctx, 1001, 5); err != nil || s.StockNum != 5 {
+		t.Fatalf("SetStock after undelete: stock=%v err=%v", s, err)
+	}
+}
+
+func TestIsDeletedReturnsQueryError(t *testing.T) {
+	d := setupDB(t)
+	if err := d.Migrator().DropTable("xx_stock"); err != nil {
+		t.Fatal(err)
+	}
+	// 查询出错时不能当作未删除处理
+	deleted, err := isDeleted(d.WithContext(context.Background()), 1001)
+	if err == nil || deleted {
+		t.Fatalf("isDeleted = %v, %v, want an error", deleted, err)
+	}
+}
@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"stock_service/errno"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// erDupEntry MySQL 唯一键冲突的错误码
+const erDupEntry = 1062
+
+// isDuplicateKey 是否为唯一键冲突，开启 TranslateError 的方言返回 gorm.ErrDuplicatedKey
+func isDuplicateKey(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var me *mysqldriver.MySQLError
+	return errors.As(err, &me) && me.Number == erDupEntry
+}
+
+// isUnavailable 是否为连接失败或超时类的错误，这类错误稍后重试可能成功
+func isUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysqldriver.ErrInvalidConn) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var me *mysqldriver.MySQLError
+	if errors.As(err, &me) {
+		return overloadErrors[me.Number]
+	}
+	var ne net.Error
+	return errors.As(err, &ne)
+}
+
+// dbError 把数据库错误转换为业务错误：已经是业务错误或 ctx 取消时原样返回，
+// 唯一键冲突返回 ErrAlreadyExists，连接失败和超时返回 ErrUnavailable，其余错误用 fallback 包装。
+func dbError(err error, fallback *errno.Error) error {
+	if err == nil {
+		return nil
+	}
+	var e *errno.Error
+	if errors.As(err, &e) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	switch {
+	case isDuplicateKey(err):
+		return errno.ErrAlreadyExists.Wrap(err)
+	case isUnavailable(err):
+		return errno.ErrUnavailable.Wrap(err)
+	}
+	return fallback.Wrap(err)
+}
@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// exportBatchSize 导出时每批从数据库读取的行数
+const exportBatchSize = 500
+
+// ExportFilter 库存导出的过滤条件
+type ExportFilter struct {
+	MinGoodsId   int64     // 商品ID下界（含），0 表示不限
+	MaxGoodsId   int64     // 商品ID上界（含），0 表示不限
+	UpdatedSince time.Time // 只导出该时间之后更新过的库存，零值表示不限
+	OnlyNonZero  bool      // 只导出库存或预扣库存不为 0 的商品
+	WithRecords  bool      // 是否同时导出状态为 1（预扣减）的库存记录
+}
+
+// goodsRange 按商品ID范围过滤
+func (f ExportFilter) goodsRange(tx *gorm.DB) *gorm.DB {
+	if f.MinGoodsId > 0 {
+		tx = tx.Where("goods_id >= ?", f.MinGoodsId)
+	}
+	if f.MaxGoodsId > 0 {
+		tx = tx.Where("goods_id <= ?", f.MaxGoodsId)
+	}
+	return tx
+}
+
+// stockScope 库存表的过滤条件
+func (f ExportFilter) stockScope(tx *gorm.DB) *gorm.DB {
+	tx = notDeleted(f.goodsRange(tx))
+	if !f.UpdatedSince.IsZero() {
+		tx = tx.Where("update_at >= ?", f.UpdatedSince)
+	}
+	if f.OnlyNonZero {
+		tx = tx.Where("(stocknum > 0 OR `lock` > 0)")
+	}
+	return tx
+}
+
+// ExportStock 在同一个只读的一致性快照中分批遍历库存（以及可选的预扣记录），逐条回调。
+// 快照读不加任何行锁，导出期间 ReduceStock 等写操作不会被阻塞。
+func ExportStock(ctx context.Context, f ExportFilter, onStock func(*model.Stock) error, onRecord func(*model.StockRecord) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 遍历库存表
+		var stocks []*model.Stock
+		err := tx.Model(&model.Stock{}).
+			Scopes(f.stockScope).
+			FindInBatches(&stocks, exportBatchSize, func(_ *gorm.DB, _ int) error {
+				for _, s := range stocks {
+					if err := onStock(s); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).Error
+		if err != nil {
+			logger.Ctx(ctx).Error("导出库存失败", zap.Error(err))
+			return err
+		}
+
+		if !f.WithRecords {
+			return nil
+		}
+
+		// 遍历状态为 1 的库存记录
+		var records []*model.StockRecord
+		err = tx.Model(&model.StockRecord{}).
+			Scopes(f.goodsRange, notDeleted).
+			Where("status = 1").
+			FindInBatches(&records, exportBatchSize, func(_ *gorm.DB, _ int) error {
+				for _, r := range records {
+					if err := onRecord(r); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).Error
+		if err != nil {
+			logger.Ctx(ctx).Error("导出库存记录失败", zap.Error(err))
+			return err
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
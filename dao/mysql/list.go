@@ -0,0 +1,112 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StockSort 库存列表排序字段
+type StockSort int
+
+const (
+	SortByGoodsId   StockSort = iota // 按商品ID
+	SortByAvailable                  // 按可用库存
+	SortByUpdateAt                   // 按更新时间
+)
+
+// availableExpr 可用库存表达式，预扣时已经从 stocknum 中减去，可用库存就是 stocknum
+const availableExpr = "stocknum"
+
+// column 排序字段对应的 SQL 表达式
+func (s StockSort) column() string {
+	switch s {
+	case SortByAvailable:
+		return availableExpr
+	case SortByUpdateAt:
+		return "update_at"
+	default:
+		return "goods_id"
+	}
+}
+
+// Key 取出库存在该排序字段上的值，用于生成游标
+func (s StockSort) Key(stock *model.Stock) int64 {
+	switch s {
+	case SortByAvailable:
+		return stock.StockNum
+	case SortByUpdateAt:
+		return stock.UpdateAt.Unix()
+	default:
+		return stock.GoodsId
+	}
+}
+
+// keyValue 游标中的值转换为 SQL 参数
+func (s StockSort) keyValue(key int64) interface{} {
+	if s == SortByUpdateAt {
+		return time.Unix(key, 0)
+	}
+	return key
+}
+
+// ListStockFilter 库存列表过滤条件
+type ListStockFilter struct {
+	AvailableBelow *int64    // 可用库存小于该值，nil 表示不限
+	LockedOnly     bool      // 只返回预扣库存大于 0 的商品
+	UpdatedSince   time.Time // 只返回该时间之后更新过的商品，零值表示不限
+	GoodsIds       []int64   // 商品ID列表，为空表示不限
+}
+
+func (f ListStockFilter) scope(tx *gorm.DB) *gorm.DB {
+	if f.AvailableBelow != nil {
+		tx = tx.Where(availableExpr+" < ?", *f.AvailableBelow)
+	}
+	if f.LockedOnly {
+		tx = tx.Where("`lock` > 0")
+	}
+	if !f.UpdatedSince.IsZero() {
+		tx = tx.Where("update_at >= ?", f.UpdatedSince)
+	}
+	if len(f.GoodsIds) > 0 {
+		tx = tx.Where("goods_id IN ?", f.GoodsIds)
+	}
+	return notDeleted(tx)
+}
+
+// StockCursor 游标，记录上一页最后一条数据的排序值和主键
+type StockCursor struct {
+	Key int64
+	ID  uint
+}
+
+// ListStock 按条件和排序字段做游标分页，after 为 nil 表示第一页。
+// 以 (排序值, id) 作为排序键，保证排序值相同时翻页也不重不漏。
+func ListStock(ctx context.Context, f ListStockFilter, sort StockSort, desc bool, after *StockCursor, limit int) ([]*model.Stock, error) {
+	col, op, dir := sort.column(), ">", "ASC"
+	if desc {
+		op, dir = "<", "DESC"
+	}
+
+	tx := db.WithContext(ctx).Model(&model.Stock{}).Scopes(f.scope)
+	if after != nil {
+		tx = tx.Where(fmt.Sprintf("(%s, id) %s (?, ?)", col, op), sort.keyValue(after.Key), after.ID)
+	}
+
+	var list []*model.Stock
+	err := tx.Order(fmt.Sprintf("%s %s, id %s", col, dir, dir)).
+		Limit(limit).
+		Find(&list).Error
+	if err != nil {
+		logger.Ctx(ctx).Error("分页查询库存失败", zap.Any("filter", f), zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return list, nil
+}
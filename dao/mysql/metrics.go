@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"time"
+
+	"stock_service/metrics"
+
+	"gorm.io/gorm"
+)
+
+// queryMetrics GORM 插件，记录每条 SQL 的耗时
+type queryMetrics struct{}
+
+// queryStartKey 保存 SQL 开始时间的 key
+const queryStartKey = "metrics:query_start"
+
+func (queryMetrics) Name() string {
+	return "metrics"
+}
+
+// Initialize 在各类操作的回调前后记录开始时间和耗时
+func (queryMetrics) Initialize(db *gorm.DB) error {
+	return registerAround(db, "metrics", func(string) func(*gorm.DB) { return startQuery }, observeQuery)
+}
+
+// registerAround 在 create、query 等各类操作的 GORM 回调前后注册插件的回调，
+// before 和 after 按操作类型返回回调函数
+func registerAround(db *gorm.DB, plugin string, before, after func(op string) func(*gorm.DB)) error {
+	cb := db.Callback()
+	hooks := []struct {
+		op     string
+		before func(name string, fn func(*gorm.DB)) error
+		after  func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, h := range hooks {
+		if err := h.before(plugin+":before_"+h.op, before(h.op)); err != nil {
+			return err
+		}
+		if err := h.after(plugin+":after_"+h.op, after(h.op)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQuery(tx *gorm.DB) {
+	tx.InstanceSet(queryStartKey, time.Now())
+}
+
+func observeQuery(op string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+		metrics.DBQueryDuration.WithLabelValues(op, tx.Statement.Table).Observe(time.Since(start).Seconds())
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"time"
 
+	"stock_service/breaker"
 	"stock_service/config"
+	"stock_service/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -38,5 +42,35 @@ func Init(cfg *config.MySQLConfig) (err error) {
 
 	// SetConnMaxLifetime 设置了连接可复用的最大时间。
 	sqlDB.SetConnMaxLifetime(time.Hour)
+
+	// 熔断，放在指标和链路追踪之前注册，熔断时这两者也能记录下来
+	dbBreaker = breaker.New("mysql", isFailure)
+	if err = db.Use(queryBreaker{}); err != nil {
+		return
+	}
+
+	// SQL 耗时和连接池状态指标
+	if err = db.Use(queryMetrics{}); err != nil {
+		return
+	}
+	// SQL 链路追踪
+	if err = db.Use(queryTracing{}); err != nil {
+		return
+	}
+	metrics.Registry.MustRegister(collectors.NewDBStatsCollector(sqlDB, cfg.DB))
 	return
 }
+
+// Use 使用已经打开的数据库连接，不注册熔断、指标和链路追踪插件，用于测试
+func Use(d *gorm.DB) {
+	db = d
+}
+
+// Close 关闭数据库连接池，服务退出时在所有使用数据库的协程结束后调用
+func Close() error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
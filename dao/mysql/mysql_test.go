@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"stock_service/config"
+	"stock_service/dao/redis"
+	"stock_service/model"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// setupDB 使用 SQLite 和 miniredis 初始化 dao 层，表结构与 sql 目录中的唯一键保持一致
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rc := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rc.Close() })
+	redis.Use(rc, &config.RedisConfig{NegativeTTL: 5 * time.Second})
+
+	dsn := filepath.Join(t.TempDir(), "stock.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	d, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true, Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.AutoMigrate(&model.Stock{}, &model.StockRecord{}, &model.StockOutbox{}, &model.StockAudit{}, &model.ConsumedMessage{}, &model.Goods{}, &model.RoomGoods{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, ddl := range []string{
+		"CREATE UNIQUE INDEX uk_goods ON xx_stock (goods_id)",
+		"CREATE UNIQUE INDEX uk_order_goods ON xx_stock_record (order_id, goods_id)",
+		"CREATE UNIQUE INDEX uk_msg_group ON xx_consumed_message (msg_id, consumer_group)",
+	} {
+		if err := d.Exec(ddl).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	Use(d)
+	t.Cleanup(func() {
+		if sqlDB, err := d.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return d
+}
@@ -0,0 +1,124 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// addOutboxEvent 在事务 tx 中写入一条库存变更事件。
+// 事件与库存修改一起提交或回滚，事务回滚时不会有事件被投递。
+func addOutboxEvent(tx *gorm.DB, eventType string, stock *model.Stock, orderId, num int64) error {
+	now := time.Now()
+	payload, err := json.Marshal(model.StockChangedEvent{
+		EventType:  eventType,
+		GoodsId:    stock.GoodsId,
+		OrderId:    orderId,
+		Num:        num,
+		Stock:      stock.StockNum,
+		Lock:       stock.Lock,
+		OccurredAt: now.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Create(&model.StockOutbox{
+		GoodsId:     stock.GoodsId,
+		EventType:   eventType,
+		Payload:     string(payload),
+		Status:      model.OutboxPending,
+		NextRetryAt: now,
+	}).Error
+	if err != nil {
+		logger.Ctx(tx.Statement.Context).Error("写入库存变更事件失败", zap.Int64("goods_id", stock.GoodsId), zap.String("event_type", eventType), zap.Error(err))
+	}
+	return err
+}
+
+// GetPendingEvents 按写入顺序查询 now 时可以投递的事件：已到重试时间，
+// 并且同一商品没有更早的、尚未到重试时间的待投递事件（保证同一商品的事件按顺序投递）。
+// 阻塞的商品不占用 limit，其他商品的事件不会因为它而一直查不到。
+func GetPendingEvents(ctx context.Context, now time.Time, limit int) ([]*model.StockOutbox, error) {
+	var list []*model.StockOutbox
+	blocked := db.Table("xx_stock_outbox AS p").
+		Select("1").
+		Where("p.goods_id = xx_stock_outbox.goods_id AND p.id < xx_stock_outbox.id").
+		Where("p.status = ? AND p.is_del = 0 AND p.next_retry_at > ?", model.OutboxPending, now)
+	err := db.WithContext(ctx).
+		Where("status = ? AND next_retry_at <= ?", model.OutboxPending, now).
+		Where("NOT EXISTS (?)", blocked).
+		Scopes(notDeleted).
+		Order("id").
+		Limit(limit).
+		Find(&list).Error
+	if err != nil {
+		logger.Ctx(ctx).Error("查询待投递事件失败", zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return list, nil
+}
+
+// MarkEventSent 将事件标记为已投递
+func MarkEventSent(ctx context.Context, id uint) error {
+	return db.WithContext(ctx).
+		Model(&model.StockOutbox{}).
+		Where("id = ?", id).
+		Update("status", model.OutboxSent).Error
+}
+
+// MarkEventFailed 记录事件投递失败，等待 nextRetryAt 之后重试
+func MarkEventFailed(ctx context.Context, id uint, retries int32, nextRetryAt time.Time, reason string) error {
+	if r := []rune(reason); len(r) > 255 {
+		reason = string(r[:255])
+	}
+	return db.WithContext(ctx).
+		Model(&model.StockOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"retries":       retries,
+			"next_retry_at": nextRetryAt,
+			"last_error":    reason,
+		}).Error
+}
+
+// MarkEventParked 重试次数用完，将事件标记为已搁置，不再投递
+func MarkEventParked(ctx context.Context, id uint, retries int32, reason string) error {
+	if r := []rune(reason); len(r) > 255 {
+		reason = string(r[:255])
+	}
+	return db.WithContext(ctx).
+		Model(&model.StockOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     model.OutboxParked,
+			"retries":    retries,
+			"last_error": reason,
+		}).Error
+}
+
+// PurgeSentEvents 物理删除投递时间早于 before 的事件，分批执行，返回删除的行数。
+func PurgeSentEvents(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for {
+		result := db.WithContext(ctx).
+			Where("status = ? and update_at < ?", model.OutboxSent, before).
+			Limit(purgeBatchSize).
+			Delete(&model.StockOutbox{})
+		if result.Error != nil {
+			logger.Ctx(ctx).Error("清理已投递事件失败", zap.Error(result.Error))
+			return total, dbError(result.Error, errno.ErrQueryFailed)
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
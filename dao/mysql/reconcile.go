@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"stock_service/dao/redis"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LockMismatch 预扣库存与未结库存记录不一致的商品
+type LockMismatch struct {
+	GoodsId      int64
+	Lock         int64 // xx_stock 中的预扣库存
+	ExpectedLock int64 // 状态为 1 的库存记录数量之和
+	Repaired     bool  // 是否已修复
+}
+
+// openRecordSum 统计状态为 1 的库存记录数量之和的子查询
+const openRecordSum = "(SELECT goods_id, SUM(num) AS total FROM xx_stock_record WHERE status = 1 AND is_del = 0 GROUP BY goods_id) AS r"
+
+// FindLockMismatches 找出 Lock 与状态为 1 的库存记录数量之和不一致的商品，goodsIds 为空时检查全部商品。
+func FindLockMismatches(ctx context.Context, goodsIds []int64) ([]*LockMismatch, error) {
+	var list []*LockMismatch
+	tx := db.WithContext(ctx).
+		Table("xx_stock AS s").
+		Select("s.goods_id, s.`lock` AS `lock`, COALESCE(r.total, 0) AS expected_lock").
+		Joins("LEFT JOIN " + openRecordSum + " ON r.goods_id = s.goods_id").
+		Where("s.is_del = 0 AND s.`lock` <> COALESCE(r.total, 0)")
+	if len(goodsIds) > 0 {
+		tx = tx.Where("s.goods_id IN ?", goodsIds)
+	}
+	if err := tx.Order("s.goods_id").Scan(&list).Error; err != nil {
+		logger.Ctx(ctx).Error("查询预扣库存差异失败", zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return list, nil
+}
+
+// RepairLock 将商品的预扣库存修正为状态为 1 的库存记录数量之和，并写入一条审计记录。
+// 修正时持有与扣减库存相同的分布式锁，并在事务内重新计算，避免覆盖并发的扣减。
+// 返回的 LockMismatch 为 nil 表示重新计算后已不存在差异。
+func RepairLock(ctx context.Context, goodsId int64, operator string) (*LockMismatch, error) {
+	mutex := redis.Rs.NewMutex(fmt.Sprintf("xx-stock-%d", goodsId))
+	if err := redis.LockStock(ctx, mutex); err != nil {
+		return nil, errno.Or(err, errno.ErrReconcileFailed.WithMeta("goods_id", goodsId))
+	}
+	defer mutex.Unlock()
+
+	var m *LockMismatch
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stock model.Stock
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("goods_id = ?", goodsId).
+			Scopes(notDeleted).
+			First(&stock).Error
+		if err != nil {
+			return err
+		}
+
+		var expected int64
+		err = tx.Model(&model.StockRecord{}).
+			Select("COALESCE(SUM(num), 0)").
+			Where("goods_id = ? and status = 1", goodsId).
+			Scopes(notDeleted).
+			Scan(&expected).Error
+		if err != nil {
+			return err
+		}
+		if stock.Lock == expected {
+			return nil
+		}
+
+		m = &LockMismatch{GoodsId: goodsId, Lock: stock.Lock, ExpectedLock: expected}
+		audit := model.StockAudit{
+			GoodsId:     goodsId,
+			Action:      "reconcile",
+			BeforeStock: stock.StockNum,
+			AfterStock:  stock.StockNum,
+			BeforeLock:  stock.Lock,
+			AfterLock:   expected,
+			Remark:      fmt.Sprintf("lock %d -> %d", stock.Lock, expected),
+		}
+		audit.CreateBy = operator
+
+		stock.Lock = expected
+		if err := tx.Save(&stock).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&audit).Error; err != nil {
+			return err
+		}
+		if err := addOutboxEvent(tx, model.EventLockRepaired, &stock, 0, 0); err != nil {
+			return err
+		}
+		m.Repaired = true
+		return nil
+	})
+	if err != nil {
+		logger.Ctx(ctx).Error("修复预扣库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, dbError(err, errno.ErrReconcileFailed.WithMeta("goods_id", goodsId))
+	}
+	if m != nil {
+		logger.Ctx(ctx).Warn("已修复预扣库存",
+			zap.Int64("goods_id", goodsId),
+			zap.Int64("lock", m.Lock),
+			zap.Int64("expected_lock", m.ExpectedLock),
+			zap.String("operator", operator))
+	}
+	return m, nil
+}
@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"stock_service/model"
+)
+
+func TestRepairLock(t *testing.T) {
+	d := setupDB(t)
+	ctx := context.Background()
+	for _, goodsId := range []int64{1001, 1002} {
+		if _, err := SetStock(ctx, goodsId, 10); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := ReduceStock(ctx, goodsId, 2, goodsId, Buyer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 模拟预扣库存与库存记录不一致
+	if err := d.Model(&model.Stock{}).Where("goods_id = ?", 1001).Update("lock", 5).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := FindLockMismatches(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || *list[0] != (LockMismatch{GoodsId: 1001, Lock: 5, ExpectedLock: 2}) {
+		t.Fatalf("mismatches = %+v, want goods 1001 with lock 5 and expected 2", list)
+	}
+
+	m, err := RepairLock(ctx, 1001, "tester")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || !m.Repaired || m.ExpectedLock != 2 {
+		t.Fatalf("RepairLock = %+v, want repaired to 2", m)
+	}
+	if s, _ := GetStockByGoodsId(ctx, 1001); s.StockNum != 8 || s.Lock != 2 {
+		t.Errorf("stock = %d/%d, want 8/2", s.StockNum, s.Lock)
+	}
+
+	var audit model.StockAudit
+	if err := d.Where("goods_id = ? and action = ?", 1001, "reconcile").First(&audit).Error; err != nil {
+		t.Fatal(err)
+	}
+	if audit.BeforeLock != 5 || audit.AfterLock != 2 || audit.CreateBy != "tester" {
+		t.Errorf("audit = %+v", audit)
+	}
+	var events int64
+	d.Model(&model.StockOutbox{}).Where("goods_id = ? and event_type = ?", 1001, model.EventLockRepaired).Count(&events)
+	if events != 1 {
+		t.Errorf("lock_repaired events = %d, want 1", events)
+	}
+
+	// 已经一致的商品不再修复
+	if list, err := FindLockMismatches(ctx, nil); err != nil || len(list) != 0 {
+		t.Fatalf("mismatches after repair = %+v, %v", list, err)
+	}
+	if m, err := RepairLock(ctx, 1002, "tester"); err != nil || m != nil {
+		t.Fatalf("RepairLock on consistent stock = %+v, %v, want nil", m, err)
+	}
+}
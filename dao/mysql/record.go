@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// RecordFilter 库存记录查询条件
+type RecordFilter struct {
+	GoodsId   int64     // 商品ID，0 表示不限
+	Status    int32     // 状态，0 表示不限
+	StartTime time.Time // 创建时间下界（含），零值表示不限
+	EndTime   time.Time // 创建时间上界（不含），零值表示不限
+}
+
+// scope 将查询条件转换为 GORM 查询
+func (f RecordFilter) scope(tx *gorm.DB) *gorm.DB {
+	if f.GoodsId > 0 {
+		tx = tx.Where("goods_id = ?", f.GoodsId)
+	}
+	if f.Status > 0 {
+		tx = tx.Where("status = ?", f.Status)
+	}
+	if !f.StartTime.IsZero() {
+		tx = tx.Where("create_at >= ?", f.StartTime)
+	}
+	if !f.EndTime.IsZero() {
+		tx = tx.Where("create_at < ?", f.EndTime)
+	}
+	return notDeleted(tx)
+}
+
+// GetRecordsByOrderId 查询订单的全部库存记录
+func GetRecordsByOrderId(ctx context.Context, orderId int64) ([]*model.StockRecord, error) {
+	var list []*model.StockRecord
+	err := db.WithContext(ctx).
+		Where("order_id = ?", orderId).
+		Scopes(notDeleted).
+		Order("id").
+		Find(&list).Error
+	if err != nil {
+		logger.Ctx(ctx).Error("根据订单 ID 查询库存记录失败", zap.Int64("order_id", orderId), zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return list, nil
+}
+
+// ListRecords 按条件分页查询库存记录，按创建顺序倒序，返回当前页数据和总条数
+func ListRecords(ctx context.Context, f RecordFilter, page, pageSize int) ([]*model.StockRecord, int64, error) {
+	var (
+		list  []*model.StockRecord
+		total int64
+	)
+	tx := db.WithContext(ctx).Model(&model.StockRecord{}).Scopes(f.scope)
+	if err := tx.Count(&total).Error; err != nil {
+		logger.Ctx(ctx).Error("统计库存记录失败", zap.Any("filter", f), zap.Error(err))
+		return nil, 0, dbError(err, errno.ErrQueryFailed)
+	}
+	if total == 0 {
+		return list, 0, nil
+	}
+
+	err := tx.Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&list).Error
+	if err != nil {
+		logger.Ctx(ctx).Error("分页查询库存记录失败", zap.Any("filter", f), zap.Error(err))
+		return nil, 0, dbError(err, errno.ErrQueryFailed)
+	}
+	return list, total, nil
+}
+
+// GetRecord 查询订单中某个商品的库存记录
+func GetRecord(ctx context.Context, orderId, goodsId int64) (*model.StockRecord, error) {
+	var record model.StockRecord
+	err := db.WithContext(ctx).
+		Where("order_id = ? and goods_id = ?", orderId, goodsId).
+		Scopes(notDeleted).
+		Order("id DESC").
+		First(&record).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errno.ErrQueryEmpty.WithMeta("goods_id", goodsId).WithMeta("order_id", orderId)
+	}
+	if err != nil {
+		logger.Ctx(ctx).Error("查询库存记录失败", zap.Int64("order_id", orderId), zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return &record, nil
+}
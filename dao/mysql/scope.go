@@ -0,0 +1,10 @@
+package mysql
+
+import "gorm.io/gorm"
+
+// 通用查询条件
+
+// notDeleted 过滤已软删除（is_del = 1）的记录
+func notDeleted(tx *gorm.DB) *gorm.DB {
+	return tx.Where("is_del = 0")
+}
@@ -5,38 +5,71 @@ import (
 	"fmt"
 	"stock_service/dao/redis"
 	"stock_service/errno"
+	"stock_service/logger"
 	"stock_service/model"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
-	//"gorm.io/gorm/clause"
+	"gorm.io/gorm/clause"
 )
 
 // SetStock 设置商品库存，如果库存记录不存在则创建，否则更新库存数量。
-func SetStock(ctx context.Context, goodsId, num int64) error {
-	// 使用 GORM 的 WithContext 方法确保操作在指定的上下文中执行。
-	result := db.WithContext(ctx).
-		Model(&model.Stock{}).          // 指定操作的模型为 Stock 表。
-		Where("goods_id = ?", goodsId). // 根据商品 ID 查询库存记录。
-		FirstOrCreate(&model.Stock{     // 如果记录不存在则创建，否则获取第一条记录。
-			GoodsId:  goodsId,
-			StockNum: num,
-		})
-
-	// 如果查询或创建失败，返回错误。
-	if result.Error != nil {
-		return errno.ErrQueryFailed
+// 与扣减库存持有同一把分布式锁，并在同一个事务中写入库存变更事件，返回设置后的库存。
+func SetStock(ctx context.Context, goodsId, num int64) (*model.Stock, error) {
+	// 创建 Redis 分布式锁。
+	mutex := redis.Rs.NewMutex(fmt.Sprintf("xx-stock-%d", goodsId))
+	if err := redis.LockStock(ctx, mutex); err != nil {
+		return nil, errno.Or(err, errno.ErrSetstockFailed.WithMeta("goods_id", goodsId))
 	}
+	defer mutex.Unlock() // 确保在函数结束时释放锁。
 
-	// 如果没有影响任何行（即记录已存在且未更新），则手动更新库存数量。
-	if result.RowsAffected == 0 {
-		return db.WithContext(ctx).
-			Model(&model.Stock{}).
-			Where("goods_id = ?", goodsId).
-			Update("StockNum", num).Error // 更新库存数量。
-	}
+	var data model.Stock
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 幂等控制，重复请求直接返回。
+		if err := markConsumed(ctx, tx); err != nil {
+			return err
+		}
 
-	return nil // 操作成功，返回 nil。
+		err := tx.Model(&model.Stock{}).
+			Where("goods_id = ?", goodsId). // 根据商品 ID 查询库存记录。
+			Scopes(notDeleted).             // 排除已删除的记录。
+			First(&data).Error
+
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			// goods_id 唯一，已删除的库存需要先恢复才能重新设置。
+			deleted, err := isDeleted(tx, goodsId)
+			if err != nil {
+				return err
+			}
+			if deleted {
+				return errno.ErrStockDeleted.WithMeta("goods_id", goodsId)
+			}
+			// 记录不存在则创建。
+			data = model.Stock{GoodsId: goodsId, StockNum: num}
+			if err := tx.Create(&data).Error; err != nil {
+				logger.Ctx(ctx).Error("创建库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+				return dbError(err, errno.ErrQueryFailed)
+			}
+		case err != nil:
+			logger.Ctx(ctx).Error("查询库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+			return dbError(err, errno.ErrQueryFailed)
+		default:
+			// 记录已存在则更新库存数量。
+			data.StockNum = num
+			if err := tx.Model(&data).Update("stocknum", num).Error; err != nil {
+				logger.Ctx(ctx).Error("更新库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+				return dbError(err, errno.ErrQueryFailed)
+			}
+		}
+
+		// 写入库存变更事件。
+		return addOutboxEvent(tx, model.EventStockSet, &data, 0, 0)
+	})
+	if err != nil {
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return &data, nil
 }
 
 // GetStockByGoodsId 根据商品 ID 查询库存信息。
@@ -48,23 +81,45 @@ func GetStockByGoodsId(ctx context.Context, goodsId int64) (*model.Stock, error)
 	err := db.WithContext(ctx).
 		Model(&model.Stock{}).          // 指定操作的模型为 Stock 表。
 		Where("goods_id = ?", goodsId). // 根据商品 ID 查询。
+		Scopes(notDeleted).             // 排除已删除的记录。
 		First(&data).                   // 获取第一条记录。
 		Error                           // 获取查询结果的错误信息。
 
-	// 如果查询失败且错误不是记录未找到，则返回 ErrQueryFailed。
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return nil, errno.ErrQueryFailed
+	// 记录不存在（或已删除）返回 ErrQueryEmpty，与库存为 0 区分开。
+	if err == gorm.ErrRecordNotFound {
+		return nil, errno.ErrQueryEmpty.WithMeta("goods_id", goodsId)
+	}
+	// 其他查询错误返回 ErrQueryFailed。
+	if err != nil {
+		return nil, dbError(err, errno.ErrQueryFailed)
 	}
 
 	// 记录查询结果的日志。
-	zap.L().Info("查询到的库存信息", zap.Any("data", data))
+	logger.Ctx(ctx).Info("查询到的库存信息", zap.Any("data", data))
 
 	return &data, nil // 返回查询结果。
 }
 
-// ReduceStock 减少库存，支持事务和分布式锁，确保操作的原子性。
-func ReduceStock(ctx context.Context, goodsId, num, orderId int64) (*model.Stock, error) {
+// GetStocksByGoodsIds 批量查询库存信息，不存在（或已删除）的商品不在结果中。
+func GetStocksByGoodsIds(ctx context.Context, goodsIds []int64) ([]*model.Stock, error) {
+	var list []*model.Stock
+	err := db.WithContext(ctx).
+		Model(&model.Stock{}).
+		Where("goods_id IN ?", goodsIds).
+		Scopes(notDeleted).
+		Find(&list).Error
+	if err != nil {
+		logger.Ctx(ctx).Error("批量查询库存失败", zap.Int64s("goods_ids", goodsIds), zap.Error(err))
+		return nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return list, nil
+}
+
+// ReduceStock 减少库存，支持事务和分布式锁，确保操作的原子性，返回扣减后的库存和创建的库存记录。
+// buyer 用于限购和直播间配额的校验，并记录在库存记录中。
+func ReduceStock(ctx context.Context, goodsId, num, orderId int64, buyer Buyer) (*model.Stock, *model.StockRecord, error) {
 	var data model.Stock
+	var stockRecord model.StockRecord
 
 	// 构造分布式锁的 key。
 	mutexname := fmt.Sprintf("xx-stock-%d", goodsId)
@@ -73,31 +128,41 @@ func ReduceStock(ctx context.Context, goodsId, num, orderId int64) (*model.Stock
 	mutex := redis.Rs.NewMutex(mutexname)
 
 	// 尝试获取锁。
-	if err := mutex.Lock(); err != nil {
-		return nil, errno.ErrReducestockFailed
+	if err := redis.LockStock(ctx, mutex); err != nil {
+		return nil, nil, errno.Or(err, errno.ErrReducestockFailed.WithMeta("goods_id", goodsId))
 	}
 	defer mutex.Unlock() // 确保在函数结束时释放锁。
 
 	// 使用 GORM 事务执行库存减少操作。
 	err := db.Transaction(func(tx *gorm.DB) error {
+		// 幂等控制，重复请求直接返回。
+		if err := markConsumed(ctx, tx); err != nil {
+			return err
+		}
+
 		// 查询当前库存。
 		err := tx.WithContext(ctx).
 			Model(&model.Stock{}).
 			Where("goods_id = ?", goodsId).
+			Scopes(notDeleted).
 			First(&data).Error
+		if err == gorm.ErrRecordNotFound {
+			return errno.ErrQueryEmpty.WithMeta("goods_id", goodsId)
+		}
 		if err != nil {
-			zap.L().Error("查询库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+			logger.Ctx(ctx).Error("查询库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
 			return err
 		}
-		zap.L().Info("查询到的库存信息", zap.Any("data", data))
+		logger.Ctx(ctx).Info("查询到的库存信息", zap.Any("data", data))
 
-		// 计算可用库存（当前库存 - 锁定库存）。
-		availableStock := data.StockNum - data.Lock
-
-		// 检查是否有足够的库存。
-		if availableStock < num {
-			zap.L().Error("库存不足", zap.Int64("goods_id", goodsId), zap.Int64("available_stock", availableStock), zap.Int64("requested_num", num))
-			return errno.ErrUnderstock
+		// 检查扣减规则：商品上架、可用库存（当前库存）、限购和直播间配额。
+		rules, err := loadReduceRules(tx.WithContext(ctx), []int64{goodsId}, buyer)
+		if err != nil {
+			return err
+		}
+		if err := rules.Check(&data, goodsId, num); err != nil {
+			logger.Ctx(ctx).Error("不能扣减库存", zap.Int64("goods_id", goodsId), zap.Int64("available_stock", data.StockNum), zap.Int64("requested_num", num), zap.Error(err))
+			return err
 		}
 
 		// 减少库存并增加锁定库存。
@@ -108,71 +173,100 @@ func ReduceStock(ctx context.Context, goodsId, num, orderId int64) (*model.Stock
 		err = tx.WithContext(ctx).
 			Save(&data).Error
 		if err != nil {
-			zap.L().Error("更新库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+			logger.Ctx(ctx).Error("更新库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
 			return err
 		}
 
 		// 创建库存记录。
-		stockRecord := model.StockRecord{
+		stockRecord = model.StockRecord{
 			OrderId: orderId,
 			GoodsId: goodsId,
 			Num:     num,
 			Status:  1, // 状态为 1 表示已减少。
+			UserId:  buyer.UserId,
+			RoomId:  buyer.RoomId,
 		}
 		err = tx.WithContext(ctx).
 			Model(&model.StockRecord{}).
 			Create(&stockRecord).Error
 		if err != nil {
-			zap.L().Error("创建库存记录失败", zap.Error(err))
+			logger.Ctx(ctx).Error("创建库存记录失败", zap.Error(err))
 			return err
 		}
-		return nil
+
+		// 写入库存变更事件。
+		return addOutboxEvent(tx, model.EventStockReduced, &data, orderId, num)
 	})
 
 	// 如果事务失败，返回错误。
 	if err != nil {
-		zap.L().Error("减少库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
-		return nil, err
+		logger.Ctx(ctx).Error("减少库存失败", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, nil, dbError(err, errno.ErrQueryFailed)
 	}
 
 	// 记录减少库存成功的日志。
-	zap.L().Info("减少库存成功",
+	logger.Ctx(ctx).Info("减少库存成功",
 		zap.Int64("goods_id", goodsId),
 		zap.Int64("num", num),
 		zap.Int64("new_stock_num", data.StockNum),
 	)
-	return &data, nil
-} // RollbackStockByMsg 根据 RocketMQ 消息回滚库存，支持事务操作。
-func RollbackStockByMsg(ctx context.Context, data model.StockRecord) error {
+	return &data, &stockRecord, nil
+}
+
+// RollbackStockByMsg 根据 RocketMQ 消息回滚库存，支持事务操作。
+// 返回回滚后的库存和库存记录，没有待回滚的记录时都返回 nil。
+func RollbackStockByMsg(ctx context.Context, data model.StockRecord) (*model.Stock, *model.StockRecord, error) {
+	return rollbackStock(ctx, data, model.StockRecordRolledBack)
+}
+
+// ReleaseStock 订单超时未确认，释放预扣的库存，库存记录状态置为 4（超时释放）。
+func ReleaseStock(ctx context.Context, data model.StockRecord) (*model.Stock, *model.StockRecord, error) {
+	return rollbackStock(ctx, data, model.StockRecordExpired)
+}
+
+// rollbackStock 回滚预扣的库存，并将库存记录状态置为 status。
+// 回滚的数量以库存记录为准：data.Num 为 0 时回滚整条记录，与记录的数量不一致时返回 ErrInvalidParam。
+// 与扣减、确认持有同一把商品维度的分布式锁；事务内对库存记录和库存行加行锁，
+// 状态只允许从预扣减（1）转换，回滚与确认、超时释放并发时只有一个能成功。
+func rollbackStock(ctx context.Context, data model.StockRecord, status int32) (*model.Stock, *model.StockRecord, error) {
 	// 构造分布式锁的 key。
-	mutexName := fmt.Sprintf("xx-stock-%d", data.OrderId)
+	mutexName := fmt.Sprintf("xx-stock-%d", data.GoodsId)
 
 	// 创建 Redis 分布式锁。
 	mutex := redis.Rs.NewMutex(mutexName)
 
 	// 尝试获取锁。
-	if err := mutex.Lock(); err != nil {
-		zap.L().Error("获取分布式锁失败",
+	if err := redis.LockStock(ctx, mutex); err != nil {
+		logger.Ctx(ctx).Error("获取分布式锁失败",
 			zap.String("mutexName", mutexName),
 			zap.Error(err))
-		return errno.ErrRollbackstockFailed
+		return nil, nil, errno.Or(err, errno.ErrRollbackstockFailed.WithMeta("goods_id", data.GoodsId))
 	}
 	// 确保在函数结束时释放锁。
 	defer mutex.Unlock() // 确保在函数结束时释放锁。
 
 	// 使用 GORM 事务执行库存回滚操作。
-	return db.Transaction(func(tx *gorm.DB) error {
+	var rolledStock *model.Stock
+	var rolledRecord *model.StockRecord
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 消息去重或幂等控制，重复的消息或请求直接返回。
+		if err := markConsumed(ctx, tx); err != nil {
+			return err
+		}
+
 		var stockRecord model.StockRecord
 
 		// 查询库存记录。
 		err := tx.WithContext(ctx).
 			Model(&model.StockRecord{}).
-			Where("order_id = ? and goods_id = ? and status = 1", data.OrderId, data.GoodsId).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ? and goods_id = ? and status = ?", data.OrderId, data.GoodsId, model.StockRecordReserved).
+			Scopes(notDeleted).
 			First(&stockRecord).Error
 
 		// 如果记录不存在，则直接返回，不做处理。
 		if err == gorm.ErrRecordNotFound {
-			zap.L().Warn("库存记录不存在，无需回滚",
+			logger.Ctx(ctx).Warn("库存记录不存在，无需回滚",
 				zap.Int64("orderId", data.OrderId),
 				zap.Int64("goodsId", data.GoodsId))
 			return nil
@@ -180,56 +274,96 @@ func RollbackStockByMsg(ctx context.Context, data model.StockRecord) error {
 
 		// 如果查询失败，记录错误并返回。
 		if err != nil {
-			zap.L().Error("根据订单 ID 查询库存记录失败",
+			logger.Ctx(ctx).Error("根据订单 ID 查询库存记录失败",
 				zap.Error(err),
 				zap.Int64("orderId", data.OrderId),
 				zap.Int64("goodsId", data.GoodsId))
 			return err
 		}
 
+		// 调用方（RPC、消息、超时释放任务）给出的数量只用于校验。
+		if data.Num != 0 && data.Num != stockRecord.Num {
+			logger.Ctx(ctx).Warn("回滚数量与库存记录不一致",
+				zap.Int64("orderId", data.OrderId),
+				zap.Int64("goodsId", data.GoodsId),
+				zap.Int64("num", data.Num),
+				zap.Int64("reservedNum", stockRecord.Num))
+			return errno.ErrInvalidParam.
+				WithMessage("rollback num does not match the reservation").
+				WithMeta("goods_id", data.GoodsId).
+				WithMeta("order_id", data.OrderId).
+				WithMeta("reserved_num", stockRecord.Num)
+		}
+
+		// 更新库存记录状态为 3（已回滚）或 4（超时释放），只有仍是预扣减状态的记录会被更新。
+		result := tx.WithContext(ctx).
+			Model(&stockRecord).
+			Where("status = ?", model.StockRecordReserved).
+			Update("status", status)
+		if result.Error != nil {
+			logger.Ctx(ctx).Warn("更新库存记录状态失败",
+				zap.Int64("goodsId", data.GoodsId),
+				zap.Error(result.Error))
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			logger.Ctx(ctx).Warn("库存记录已被处理，无需回滚",
+				zap.Int64("orderId", data.OrderId),
+				zap.Int64("goodsId", data.GoodsId))
+			return nil
+		}
+		stockRecord.Status = status
+
 		// 查询库存信息。
 		var stock model.Stock
 		err = tx.WithContext(ctx).
 			Model(&model.Stock{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
 			Where("goods_id = ?", data.GoodsId).
+			Scopes(notDeleted).
 			First(&stock).Error
 		if err != nil {
-			zap.L().Error("查询库存失败",
+			logger.Ctx(ctx).Error("查询库存失败",
 				zap.Error(err),
 				zap.Int64("goodsId", data.GoodsId))
 			return err
 		}
 
 		// 回滚库存。
-		stock.StockNum += data.Num // 增加库存数量。
-		stock.Lock -= data.Num     // 减少锁定库存。
-		if stock.Lock < 0 {        // 如果锁定库存小于 0，表示回滚失败。
-			zap.L().Error("回滚库存失败，锁定库存不足",
+		stock.StockNum += stockRecord.Num // 增加库存数量。
+		stock.Lock -= stockRecord.Num     // 减少锁定库存。
+		if stock.Lock < 0 {               // 如果锁定库存小于 0，表示回滚失败。
+			logger.Ctx(ctx).Error("回滚库存失败，锁定库存不足",
 				zap.Int64("goodsId", data.GoodsId),
 				zap.Int64("stockNum", stock.StockNum),
 				zap.Int64("lock", stock.Lock))
-			return errno.ErrRollbackstockFailed
+			return errno.ErrRollbackstockFailed.
+				WithMeta("goods_id", data.GoodsId).
+				WithMeta("lock", stock.Lock+stockRecord.Num)
 		}
 
 		// 更新库存记录。
 		err = tx.WithContext(ctx).Save(&stock).Error
 		if err != nil {
-			zap.L().Warn("回滚库存失败",
+			logger.Ctx(ctx).Warn("回滚库存失败",
 				zap.Int64("goodsId", stock.GoodsId),
 				zap.Error(err))
 			return err
 		}
 
-		// 更新库存记录状态为 3（表示已回滚）。
-		stockRecord.Status = 3
-		err = tx.WithContext(ctx).Save(&stockRecord).Error
-		if err != nil {
-			zap.L().Warn("更新库存记录状态失败",
-				zap.Int64("goodsId", stock.GoodsId),
-				zap.Error(err))
+		// 写入库存变更事件。
+		eventType := model.EventStockRolledBack
+		if status == model.StockRecordExpired {
+			eventType = model.EventStockReleased
+		}
+		if err := addOutboxEvent(tx, eventType, &stock, data.OrderId, stockRecord.Num); err != nil {
 			return err
 		}
-
+		rolledStock, rolledRecord = &stock, &stockRecord
 		return nil
 	})
+	if err != nil {
+		return nil, nil, dbError(err, errno.ErrQueryFailed)
+	}
+	return rolledStock, rolledRecord, nil
 }
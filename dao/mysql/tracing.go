@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"errors"
+
+	"stock_service/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// queryTracing GORM 插件，为每条 SQL 创建一个 span。
+// 只在 ctx 中已有 span 时创建（即通过 WithContext 传入了请求的 ctx），定时任务等没有链路的查询不产生孤立的 span。
+type queryTracing struct{}
+
+// querySpanKey 保存 SQL span 的 key
+const querySpanKey = "tracing:query_span"
+
+var tracer = otel.Tracer("stock_service/dao/mysql")
+
+func (queryTracing) Name() string {
+	return "tracing"
+}
+
+// Initialize 在各类操作的回调前后开始和结束 span
+func (queryTracing) Initialize(db *gorm.DB) error {
+	return registerAround(db, "tracing", startQuerySpan, func(string) func(*gorm.DB) { return endQuerySpan })
+}
+
+func startQuerySpan(op string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		ctx := tx.Statement.Context
+		if ctx == nil || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			return
+		}
+		_, span := tracer.Start(ctx, "gorm."+op,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemMySQL,
+				semconv.DBOperationName(op),
+			),
+		)
+		tx.InstanceSet(querySpanKey, span)
+	}
+}
+
+func endQuerySpan(tx *gorm.DB) {
+	v, ok := tx.InstanceGet(querySpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(
+		semconv.DBCollectionName(tx.Statement.Table),
+		semconv.DBQueryText(tx.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+	)
+	// 查不到记录是正常的查询结果
+	err := tx.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
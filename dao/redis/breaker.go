@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"stock_service/breaker"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis 熔断：命令执行前检查熔断器，熔断中直接返回 ErrUnavailable
+
+var redisBreaker *breaker.Breaker
+
+// isFailure Redis 返回了错误（WRONGTYPE 等）或 key 不存在说明连接是正常的，
+// 其余错误（连接失败、超时、连接池耗尽等）说明 Redis 出了问题
+func isFailure(err error) bool {
+	if errors.Is(err, redis.Nil) {
+		return false
+	}
+	var re redis.Error
+	return !errors.As(err, &re)
+}
+
+// doneKey 保存熔断器回调
+type doneKey struct{}
+
+// breakerHook go-redis 的 hook，管道整体算一次调用
+type breakerHook struct{}
+
+var _ redis.Hook = breakerHook{}
+
+func (breakerHook) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	return allow(ctx)
+}
+
+func (breakerHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	done(ctx, cmd.Err())
+	return nil
+}
+
+func (breakerHook) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	return allow(ctx)
+}
+
+func (breakerHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmd.Err() != nil && isFailure(cmd.Err()) {
+			err = cmd.Err()
+			break
+		}
+	}
+	done(ctx, err)
+	return nil
+}
+
+// allow 检查熔断器，放行时把回调放入 ctx；熔断中返回错误，go-redis 不再执行命令
+func allow(ctx context.Context) (context.Context, error) {
+	fn, err := redisBreaker.Allow()
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, doneKey{}, fn), nil
+}
+
+// done 记录调用结果，BeforeProcess 返回错误时 go-redis 也会调用 AfterProcess，此时 ctx 中没有回调
+func done(ctx context.Context, err error) {
+	if fn, ok := ctx.Value(doneKey{}).(func(error)); ok {
+		fn(err)
+	}
+}
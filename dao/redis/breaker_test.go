@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"stock_service/breaker"
+	"stock_service/config"
+	"stock_service/errno"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestBreakerHook(t *testing.T) {
+	mr := setupRedis(t)
+	breaker.Init(&config.BreakerConfig{Enable: true, ConsecutiveFailures: 2})
+	t.Cleanup(func() {
+		breaker.Init(&config.BreakerConfig{Enable: false})
+		redisBreaker = nil
+	})
+	redisBreaker = breaker.New("redis-test", isFailure)
+	rc.AddHook(breakerHook{})
+	ctx := context.Background()
+
+	// key 不存在和命令错误说明 Redis 是正常的
+	for i := 0; i < 3; i++ {
+		if err := rc.Get(ctx, "missing").Err(); err != redis.Nil {
+			t.Fatalf("get missing key: %v", err)
+		}
+		mr.Set("str", "v")
+		if err := rc.HGet(ctx, "str", "f").Err(); err == nil {
+			t.Fatal("HGET on a string succeeded")
+		}
+	}
+	if err := redisBreaker.Err(); err != nil {
+		t.Fatalf("breaker open with Redis up: %v", err)
+	}
+
+	// 连接失败时熔断，之后的命令不再访问 Redis
+	mr.Close()
+	for i := 0; i < 2; i++ {
+		if err := rc.Ping(ctx).Err(); err == nil {
+			t.Fatal("ping succeeded with Redis down")
+		}
+	}
+	if err := rc.Ping(ctx).Err(); !errors.Is(err, errno.ErrUnavailable) {
+		t.Fatalf("ping after 2 failures: %v, want ErrUnavailable", err)
+	}
+	if _, err := rc.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.Get(ctx, "k")
+		return nil
+	}); !errors.Is(err, errno.ErrUnavailable) {
+		t.Fatalf("pipeline after 2 failures: %v, want ErrUnavailable", err)
+	}
+}
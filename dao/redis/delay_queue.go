@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 基于有序集合的延迟队列：member 为任务内容，score 为到期时间（Unix 毫秒）
+
+// releaseQueueKey 超时释放库存的延迟队列
+const releaseQueueKey = "xx-stock-release-delay"
+
+// ReleaseJob 超时释放库存任务
+type ReleaseJob struct {
+	OrderId int64
+	GoodsId int64
+	Num     int64
+}
+
+func (j ReleaseJob) member() string {
+	return fmt.Sprintf("%d:%d:%d", j.OrderId, j.GoodsId, j.Num)
+}
+
+func parseReleaseJob(s string) (ReleaseJob, error) {
+	var j ReleaseJob
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return j, fmt.Errorf("invalid release job: %s", s)
+	}
+	var err error
+	if j.OrderId, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return j, err
+	}
+	if j.GoodsId, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return j, err
+	}
+	if j.Num, err = strconv.ParseInt(parts[2], 10, 64); err != nil {
+		return j, err
+	}
+	return j, nil
+}
+
+// AddReleaseJob 添加超时释放任务，到期时间为 due
+func AddReleaseJob(ctx context.Context, job ReleaseJob, due time.Time) error {
+	return rc.ZAdd(ctx, releaseQueueKey, &redis.Z{
+		Score:  float64(due.UnixMilli()),
+		Member: job.member(),
+	}).Err()
+}
+
+// RemoveReleaseJob 删除超时释放任务（订单已确认或已回滚）
+func RemoveReleaseJob(ctx context.Context, job ReleaseJob) error {
+	return rc.ZRem(ctx, releaseQueueKey, job.member()).Err()
+}
+
+// claimScript 原子地领取最多 ARGV[2] 个到期任务：
+// 不直接删除，而是把到期时间推迟到租约结束（ARGV[3]），处理成功后再删除；
+// 处理过程中实例宕机的任务在租约结束后会被重新领取。
+var claimScript = redis.NewScript(`
+local items = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[2])
+for _, item in ipairs(items) do
+    redis.call("ZADD", KEYS[1], ARGV[3], item)
+end
+return items
+`)
+
+// ClaimReleaseJobs 领取最多 limit 个已到期的任务，任务在 lease 时间内不会被再次领取
+func ClaimReleaseJobs(ctx context.Context, limit int, lease time.Duration) ([]ReleaseJob, error) {
+	now := time.Now()
+	items, err := claimScript.Run(ctx, rc, []string{releaseQueueKey},
+		now.UnixMilli(), limit, now.Add(lease).UnixMilli()).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]ReleaseJob, 0, len(items))
+	for _, item := range items {
+		j, err := parseReleaseJob(item)
+		if err != nil {
+			// 格式错误的任务直接丢弃
+			rc.ZRem(ctx, releaseQueueKey, item)
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stock_service/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// setupRedis 使用 miniredis 初始化 Redis 客户端
+func setupRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	c := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { c.Close() })
+	Use(c, &config.RedisConfig{})
+	return mr
+}
+
+func TestClaimReleaseJobsLease(t *testing.T) {
+	mr := setupRedis(t)
+	ctx := context.Background()
+	due := ReleaseJob{OrderId: 1, GoodsId: 1001, Num: 2}
+	later := ReleaseJob{OrderId: 2, GoodsId: 1001, Num: 1}
+	if err := AddReleaseJob(ctx, due, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddReleaseJob(ctx, later, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	// 格式错误的任务被丢弃
+	mr.ZAdd(releaseQueueKey, 0, "bad")
+
+	// 只领取到期的任务
+	jobs, err := ClaimReleaseJobs(ctx, 10, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0] != due {
+		t.Fatalf("claimed %v, want [%v]", jobs, due)
+	}
+	if members, _ := mr.ZMembers(releaseQueueKey); len(members) != 2 {
+		t.Errorf("queue = %v, want the malformed job removed", members)
+	}
+
+	// 租约内不会被再次领取
+	if jobs, err := ClaimReleaseJobs(ctx, 10, 100*time.Millisecond); err != nil || len(jobs) != 0 {
+		t.Fatalf("claimed %v (err %v) within the lease, want none", jobs, err)
+	}
+
+	// 处理中宕机（没有删除任务），租约结束后重新领取
+	time.Sleep(150 * time.Millisecond)
+	jobs, err = ClaimReleaseJobs(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0] != due {
+		t.Fatalf("claimed %v after the lease expired, want [%v]", jobs, due)
+	}
+
+	// 处理成功后删除，不再领取
+	if err := RemoveReleaseJob(ctx, due); err != nil {
+		t.Fatal(err)
+	}
+	if members, _ := mr.ZMembers(releaseQueueKey); len(members) != 1 {
+		t.Errorf("queue = %v, want only the job that is not due", members)
+	}
+}
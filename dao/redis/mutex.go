@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"stock_service/metrics"
+	"stock_service/tracing"
+
+	"github.com/go-redsync/redsync/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LockStock 获取库存的 redsync 分布式锁，记录等待时间和失败次数，并为等待过程创建一个 span。
+// ctx 取消或超时后停止重试并返回 ctx 的错误，按获取失败记录；重试次数和间隔仍由 redsync 的配置决定。
+// Redis 熔断中直接返回 ErrUnavailable，不进入 redsync 的重试。
+func LockStock(ctx context.Context, mutex *redsync.Mutex) error {
+	ctx, span := tracer.Start(ctx, "redsync.Lock", trace.WithAttributes(attribute.String("lock.name", mutex.Name())))
+	start := time.Now()
+	err := redisBreaker.Err()
+	if err == nil {
+		err = mutex.LockContext(ctx)
+	}
+	if err != nil {
+		metrics.LockWait.WithLabelValues("failed").Observe(time.Since(start).Seconds())
+		metrics.LockFailures.Inc()
+	} else {
+		metrics.LockWait.WithLabelValues("acquired").Observe(time.Since(start).Seconds())
+	}
+	tracing.End(span, err)
+	return err
+}
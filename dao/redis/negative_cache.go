@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 不存在的商品的缓存（负缓存）：查询不到库存的商品ID缓存 negativeTTL，
+// 期间的查询直接返回不存在，不再访问数据库。创建或恢复库存后删除对应的缓存。
+//
+// 查询数据库和写入缓存之间库存可能刚好被创建，晚到的写入会把新库存缓存为不存在。
+// 每个商品有一个版本号，创建或恢复库存时先递增版本号再删除缓存；
+// 查询前读取版本号，写入缓存时版本号没有变化才写入，否则说明期间库存有变化，放弃写入。
+
+// missVersionTTL 版本号的过期时间，远大于一次查询的耗时和 negativeTTL
+const missVersionTTL = 24 * time.Hour
+
+func stockMissKey(goodsId int64) string {
+	return fmt.Sprintf("xx-stock-miss-%d", goodsId)
+}
+
+func stockMissVersionKey(goodsId int64) string {
+	return fmt.Sprintf("xx-stock-miss-ver-%d", goodsId)
+}
+
+// MissVersions 查询前读取的版本号，写入缓存时传给 SetStockMiss
+type MissVersions map[int64]string
+
+// setMissScript 版本号（KEYS[1]）与 ARGV[1] 相同时写入缓存（KEYS[2]），不存在的版本号视为空字符串
+var setMissScript = redis.NewScript(`
+local ver = redis.call("GET", KEYS[1]) or ""
+if ver ~= ARGV[1] then
+    return 0
+end
+redis.call("SET", KEYS[2], 1, "PX", ARGV[2])
+return 1
+`)
+
+// IsStockMiss 判断商品是否已缓存为不存在，同时返回当前的版本号。
+// 未启用或 Redis 出错时返回 false，版本号为 nil，之后不会写入缓存。
+func IsStockMiss(ctx context.Context, goodsId int64) (bool, MissVersions) {
+	misses, vers := StockMisses(ctx, []int64{goodsId})
+	return misses[goodsId], vers
+}
+
+// StockMisses 批量判断商品是否已缓存为不存在，返回已缓存的商品ID集合和各商品当前的版本号
+func StockMisses(ctx context.Context, goodsIds []int64) (map[int64]bool, MissVersions) {
+	misses := make(map[int64]bool)
+	if negativeTTL <= 0 || len(goodsIds) == 0 {
+		return misses, nil
+	}
+	keys := make([]string, 0, 2*len(goodsIds))
+	for _, id := range goodsIds {
+		keys = append(keys, stockMissKey(id), stockMissVersionKey(id))
+	}
+	vals, err := rc.MGet(ctx, keys...).Result()
+	if err != nil {
+		return misses, nil
+	}
+	vers := make(MissVersions, len(goodsIds))
+	for i, id := range goodsIds {
+		if vals[2*i] != nil {
+			misses[id] = true
+		}
+		ver, _ := vals[2*i+1].(string)
+		vers[id] = ver
+	}
+	return misses, vers
+}
+
+// SetStockMiss 将商品缓存为不存在，vers 为查询前读取的版本号；
+// 版本号已经变化（期间创建或恢复了库存）或没有读取到版本号的商品不写入
+func SetStockMiss(ctx context.Context, vers MissVersions, goodsIds ...int64) error {
+	if negativeTTL <= 0 || len(goodsIds) == 0 {
+		return nil
+	}
+	pipe := rc.Pipeline()
+	for _, id := range goodsIds {
+		ver, ok := vers[id]
+		if !ok {
+			continue
+		}
+		setMissScript.Eval(ctx, pipe, []string{stockMissVersionKey(id), stockMissKey(id)}, ver, negativeTTL.Milliseconds())
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DelStockMiss 递增商品的版本号并删除不存在的缓存，在库存创建或恢复的事务提交之后调用
+func DelStockMiss(ctx context.Context, goodsId int64) error {
+	pipe := rc.TxPipeline()
+	pipe.Incr(ctx, stockMissVersionKey(goodsId))
+	pipe.Expire(ctx, stockMissVersionKey(goodsId), missVersionTTL)
+	pipe.Del(ctx, stockMissKey(goodsId))
+	_, err := pipe.Exec(ctx)
+	return err
+}
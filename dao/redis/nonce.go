@@ -0,0 +1,14 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// nonceKeyPrefix 签名随机数 key 的前缀
+const nonceKeyPrefix = "xx-stock-nonce:"
+
+// ClaimNonce 记录一个签名随机数，ttl 内第一次记录时返回 true，已经记录过（重放的请求）时返回 false
+func ClaimNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return rc.SetNX(ctx, nonceKeyPrefix+nonce, 1, ttl).Result()
+}
@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 所有实例共享的令牌桶：桶是一个哈希，tokens 为剩余令牌数，ts 为上次更新的时间（毫秒），
+// 用 Redis 的 TIME 计时，不受各实例时钟偏差的影响。
+
+// rateLimitKeyPrefix 令牌桶 key 的前缀
+const rateLimitKeyPrefix = "xx-stock-ratelimit:"
+
+// takeTokenScript 按时间补充令牌后取一个令牌，返回需要等待的毫秒数，0 表示拿到了令牌。
+// 桶在补满所需的时间之后过期，长期不用的桶不会一直占用内存。
+var takeTokenScript = redis.NewScript(`
+redis.replicate_commands()
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local b = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(b[1])
+local ts = tonumber(b[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+
+// TakeToken 从 key 对应的令牌桶中取一个令牌，rate 为每秒补充的令牌数，burst 为桶的容量。
+// 返回 0 表示拿到了令牌，否则返回还需要等待的时间（这次没有消耗令牌）。
+func TakeToken(ctx context.Context, key string, rate float64, burst int) (time.Duration, error) {
+	wait, err := takeTokenScript.Run(ctx, rc, []string{rateLimitKeyPrefix + key}, rate, burst).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTakeTokenRefill(t *testing.T) {
+	mr := setupRedis(t)
+	ctx := context.Background()
+	now := time.Now()
+	mr.SetTime(now)
+
+	// 每秒 10 个令牌，容量 2：先用完桶里的令牌
+	for i := 0; i < 2; i++ {
+		if wait, err := TakeToken(ctx, "m", 10, 2); err != nil || wait != 0 {
+			t.Fatalf("take %d: wait=%v err=%v, want a token", i, wait, err)
+		}
+	}
+	wait, err := TakeToken(ctx, "m", 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait != 100*time.Millisecond {
+		t.Fatalf("wait = %v, want 100ms", wait)
+	}
+	// 被拒绝的请求不消耗令牌，等待的时间不会越来越长
+	if wait, _ := TakeToken(ctx, "m", 10, 2); wait != 100*time.Millisecond {
+		t.Fatalf("wait after rejection = %v, want 100ms", wait)
+	}
+
+	// 50ms 只补充了半个令牌
+	mr.SetTime(now.Add(50 * time.Millisecond))
+	if wait, _ := TakeToken(ctx, "m", 10, 2); wait != 50*time.Millisecond {
+		t.Fatalf("wait after 50ms = %v, want 50ms", wait)
+	}
+	mr.SetTime(now.Add(100 * time.Millisecond))
+	if wait, _ := TakeToken(ctx, "m", 10, 2); wait != 0 {
+		t.Fatalf("wait after 100ms = %v, want a token", wait)
+	}
+
+	// 补充的令牌不超过容量
+	mr.SetTime(now.Add(time.Minute))
+	for i := 0; i < 2; i++ {
+		if wait, _ := TakeToken(ctx, "m", 10, 2); wait != 0 {
+			t.Fatalf("take %d after refill: wait=%v, want a token", i, wait)
+		}
+	}
+	if wait, _ := TakeToken(ctx, "m", 10, 2); wait == 0 {
+		t.Fatal("took more tokens than the burst")
+	}
+
+	// 不同的 key 使用不同的桶
+	if wait, _ := TakeToken(ctx, "other", 10, 2); wait != 0 {
+		t.Fatalf("other bucket: wait=%v, want a token", wait)
+	}
+}
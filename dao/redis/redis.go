@@ -3,7 +3,10 @@ package redis
 import (
 	"context"
 	"fmt"
+	"stock_service/breaker"
 	"stock_service/config"
+	"stock_service/metrics"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/go-redsync/redsync/v4"
@@ -15,8 +18,13 @@ import (
 var (
 	rc *redis.Client
 	Rs *redsync.Redsync
+
+	negativeTTL time.Duration // 不存在的商品的缓存时长
 )
 
+// maxNegativeTTL 不存在的商品的最长缓存时长：删除缓存失败时，新创建的库存在缓存过期前仍被查询为不存在
+const maxNegativeTTL = time.Minute
+
 func Init(cfg *config.RedisConfig) error {
 	rc = redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
@@ -24,6 +32,10 @@ func Init(cfg *config.RedisConfig) error {
 		DB:       cfg.DB,       // 数据库
 		PoolSize: cfg.PoolSize, // 连接池大小
 	})
+	// 命令的链路追踪和熔断，熔断的 hook 在后面，熔断时链路中也有记录
+	rc.AddHook(tracingHook{})
+	redisBreaker = breaker.New("redis", isFailure)
+	rc.AddHook(breakerHook{})
 	err := rc.Ping(context.Background()).Err()
 	if err != nil {
 		return err
@@ -33,5 +45,20 @@ func Init(cfg *config.RedisConfig) error {
 	// Create an instance of redisync to be used to obtain a mutual exclusion
 	// lock.
 	Rs = redsync.New(pool)
+	// 连接池状态指标
+	metrics.Registry.MustRegister(metrics.NewRedisPoolCollector(rc))
+	negativeTTL = min(cfg.NegativeTTL, maxNegativeTTL)
 	return nil
 }
+
+// Use 使用已经创建的 Redis 客户端，不注册熔断和指标，用于测试
+func Use(c *redis.Client, cfg *config.RedisConfig) {
+	rc = c
+	Rs = redsync.New(goredis.NewPool(rc))
+	negativeTTL = min(cfg.NegativeTTL, maxNegativeTTL)
+}
+
+// Close 关闭 Redis 连接池，服务退出时在所有使用 Redis 的协程结束后调用
+func Close() error {
+	return rc.Close()
+}
@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"stock_service/tracing"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("stock_service/dao/redis")
+
+// spanKey 保存 hook 创建的 span，After 中只结束自己创建的 span
+type spanKey struct{}
+
+// tracingHook go-redis 的 hook，为每个命令（管道为一次）创建一个 span。
+// 只在 ctx 中已有 span 时创建，定时任务和 redsync 内部的命令不产生孤立的 span。
+type tracingHook struct{}
+
+var _ redis.Hook = tracingHook{}
+
+func (tracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return startSpan(ctx, cmd.FullName(), attribute.String("db.operation.name", cmd.Name())), nil
+}
+
+func (tracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endSpan(ctx, cmd.Err())
+	return nil
+}
+
+func (tracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return startSpan(ctx, "pipeline", attribute.Int("db.operation.batch.size", len(cmds))), nil
+}
+
+func (tracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmd.Err() != nil && !errors.Is(cmd.Err(), redis.Nil) {
+			err = cmd.Err()
+			break
+		}
+	}
+	endSpan(ctx, err)
+	return nil
+}
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) context.Context {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		return ctx
+	}
+	ctx, span := tracer.Start(ctx, "redis."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, semconv.DBSystemRedis)...),
+	)
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+func endSpan(ctx context.Context, err error) {
+	span, ok := ctx.Value(spanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	// key 不存在是正常的查询结果
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
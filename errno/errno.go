@@ -1,13 +1,34 @@
 package errno
 
-import "errors"
+import "google.golang.org/grpc/codes"
 
+// 业务错误码：1xxxx 通用错误，2xxxx 库存相关错误
 var (
-	ErrQueryFailed = errors.New("query db failed")
-	ErrQueryEmpty  = errors.New("query empty") // 查询结果为空
+	ErrQueryFailed      = New(10001, codes.Internal, "QUERY_FAILED", "query db failed")                // 数据库错误，连接失败和超时为 ErrUnavailable
+	ErrQueryEmpty       = New(10002, codes.NotFound, "NOT_FOUND", "query empty")                       // 查询结果为空
+	ErrInvalidCursor    = New(10003, codes.InvalidArgument, "INVALID_CURSOR", "invalid cursor")        // 分页游标无效
+	ErrInvalidParam     = New(10004, codes.InvalidArgument, "INVALID_ARGUMENT", "invalid argument")    // 参数错误
+	ErrInternal         = New(10005, codes.Internal, "INTERNAL", "internal error")                     // 未归类的内部错误
+	ErrRateLimited      = New(10006, codes.ResourceExhausted, "RATE_LIMITED", "rate limited")          // 请求过于频繁
+	ErrUnavailable      = New(10007, codes.Unavailable, "UNAVAILABLE", "dependency unavailable")       // 依赖（MySQL、Redis）熔断中
+	ErrOverloaded       = New(10008, codes.Unavailable, "OVERLOADED", "server overloaded")             // 并发请求过多，请求被丢弃
+	ErrUnauthenticated  = New(10009, codes.Unauthenticated, "UNAUTHENTICATED", "unauthenticated")      // 缺少凭证或凭证无效
+	ErrPermissionDenied = New(10010, codes.PermissionDenied, "PERMISSION_DENIED", "permission denied") // 调用方的角色没有权限
+	ErrAlreadyExists    = New(10011, codes.AlreadyExists, "ALREADY_EXISTS", "already exists")          // 唯一键冲突
 
-	ErrUnderstock          = errors.New("understock")
-	ErrReducestockFailed   = errors.New("reduce stock failed")   // 库存扣减失败
-	ErrRollbackstockFailed = errors.New("rollback stock failed") // 回滚库存失败
-	ErrSetstockFailed	   = errors.New("set stock failed")//设置库存失败
+	ErrUnderstock           = New(20001, codes.FailedPrecondition, "UNDERSTOCK", "understock")                         // 可用库存不足
+	ErrReducestockFailed    = New(20002, codes.Aborted, "REDUCE_STOCK_FAILED", "reduce stock failed")                  // 库存扣减失败
+	ErrRollbackstockFailed  = New(20003, codes.Aborted, "ROLLBACK_STOCK_FAILED", "rollback stock failed")              // 回滚库存失败
+	ErrSetstockFailed       = New(20004, codes.Aborted, "SET_STOCK_FAILED", "set stock failed")                        // 设置库存失败
+	ErrReconcileFailed      = New(20005, codes.Internal, "RECONCILE_FAILED", "reconcile stock failed")                 // 库存对账失败
+	ErrStockDeleted         = New(20006, codes.FailedPrecondition, "STOCK_DELETED", "stock deleted")                   // 库存已删除
+	ErrStockLocked          = New(20007, codes.FailedPrecondition, "STOCK_LOCKED", "stock locked")                     // 存在预扣库存
+	ErrConfirmStockFailed   = New(20008, codes.Aborted, "CONFIRM_STOCK_FAILED", "confirm stock failed")                // 确认扣减失败
+	ErrReservationReleased  = New(20009, codes.FailedPrecondition, "RESERVATION_RELEASED", "reservation released")     // 预扣已回滚或超时释放
+	ErrDuplicateMessage     = New(20010, codes.AlreadyExists, "DUPLICATE_MESSAGE", "duplicate message")                // 消息已处理过
+	ErrAdjustStockFailed    = New(20011, codes.Aborted, "ADJUST_STOCK_FAILED", "adjust stock failed")                  // 调整库存失败
+	ErrGoodsOffShelf        = New(20012, codes.FailedPrecondition, "GOODS_OFF_SHELF", "goods off shelf")               // 商品未上架
+	ErrPurchaseLimit        = New(20013, codes.FailedPrecondition, "PURCHASE_LIMIT", "purchase limit exceeded")        // 超过每个用户的限购数量
+	ErrRoomQuota            = New(20014, codes.FailedPrecondition, "ROOM_QUOTA", "room quota exceeded")                // 超过直播间的配额
+	ErrIdempotencyKeyReused = New(20015, codes.FailedPrecondition, "IDEMPOTENCY_KEY_REUSED", "idempotency key reused") // 幂等键已被内容不同的请求使用
 )
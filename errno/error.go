@@ -0,0 +1,85 @@
+package errno
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Error 业务错误，包含数字业务码、对应的 gRPC 状态码和上下文信息。
+// 上面定义的是哨兵错误，WithMeta 等方法返回副本，副本与哨兵错误用 errors.Is 比较时按业务码判断。
+type Error struct {
+	Code    int32             // 业务码
+	GRPC    codes.Code        // gRPC 状态码
+	Reason  string            // 错误原因，对应 ErrorInfo.Reason
+	Message string            // 错误信息
+	Meta    map[string]string // 上下文信息，例如商品ID、可用库存
+	cause   error             // 原始错误，只用于日志，不返回给调用方
+}
+
+// New 创建业务错误
+func New(code int32, grpcCode codes.Code, reason, message string) *Error {
+	return &Error{Code: code, GRPC: grpcCode, Reason: reason, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.cause)
+	}
+	return e.Message
+}
+
+// Unwrap 返回原始错误
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is 业务码相同即认为是同一个错误
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// clone 复制错误，Meta 重新分配，避免修改哨兵错误
+func (e *Error) clone() *Error {
+	c := *e
+	c.Meta = make(map[string]string, len(e.Meta)+1)
+	for k, v := range e.Meta {
+		c.Meta[k] = v
+	}
+	return &c
+}
+
+// WithMeta 返回附带上下文信息的副本
+func (e *Error) WithMeta(key string, value any) *Error {
+	c := e.clone()
+	c.Meta[key] = fmt.Sprint(value)
+	return c
+}
+
+// WithMessage 返回替换了错误信息的副本
+func (e *Error) WithMessage(msg string) *Error {
+	c := e.clone()
+	c.Message = msg
+	return c
+}
+
+// Wrap 返回记录了原始错误的副本
+func (e *Error) Wrap(cause error) *Error {
+	c := e.clone()
+	c.cause = cause
+	return c
+}
+
+// Or 如果 err 已经是业务错误则原样返回，否则返回记录了 err 的 fallback
+func Or(err error, fallback *Error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	return fallback.Wrap(err)
+}
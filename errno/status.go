@@ -0,0 +1,108 @@
+package errno
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// domain ErrorInfo 中的错误域
+const domain = "stock_service"
+
+// GRPCStatus 转换为 gRPC 状态，附带 ErrorInfo（业务码和上下文信息），
+// FailedPrecondition 类错误另外附带 PreconditionFailure，
+// 指明了字段（Meta 中的 field）的 InvalidArgument 类错误另外附带 BadRequest，
+// 指明了重试间隔（Meta 中的 retry_after_ms）的 ResourceExhausted 类错误另外附带 RetryInfo。
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.GRPC, e.Message)
+
+	meta := make(map[string]string, len(e.Meta)+1)
+	for k, v := range e.Meta {
+		meta[k] = v
+	}
+	meta["code"] = strconv.Itoa(int(e.Code))
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: e.Reason, Domain: domain, Metadata: meta}}
+
+	if e.GRPC == codes.FailedPrecondition {
+		subject := domain
+		if id, ok := e.Meta["goods_id"]; ok {
+			subject = fmt.Sprintf("goods/%s", id)
+		}
+		details = append(details, &errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: e.Reason, Subject: subject, Description: e.Message},
+			},
+		})
+	}
+
+	if field, ok := e.Meta["field"]; ok && e.GRPC == codes.InvalidArgument {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: field, Description: e.Message},
+			},
+		})
+	}
+
+	if ms, ok := e.Meta["retry_after_ms"]; ok && e.GRPC == codes.ResourceExhausted {
+		if n, err := strconv.ParseInt(ms, 10, 64); err == nil {
+			details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(n) * time.Millisecond)})
+		}
+	}
+
+	// WithDetails 只有在状态码为 OK 时才会失败
+	if ds, err := st.WithDetails(details...); err == nil {
+		st = ds
+	}
+	return st
+}
+
+// ToStatus 把错误转换为 gRPC 状态错误：业务错误按错误码目录转换，
+// 已经是 gRPC 状态的错误原样返回，其余错误作为 Internal 返回，不暴露内部信息。
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.GRPCStatus().Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	return ErrInternal.GRPCStatus().Err()
+}
+
+// FromStatus 从 gRPC 状态还原业务错误，用于客户端：
+// 状态中带有本服务的 ErrorInfo 时返回对应的 *Error（可以用 errors.Is 与哨兵错误比较），否则返回 nil。
+func FromStatus(st *status.Status) *Error {
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != domain {
+			continue
+		}
+		code, err := strconv.Atoi(info.GetMetadata()["code"])
+		if err != nil {
+			return nil
+		}
+		e := New(int32(code), st.Code(), info.GetReason(), st.Message())
+		e.Meta = make(map[string]string, len(info.GetMetadata()))
+		for k, v := range info.GetMetadata() {
+			if k != "code" {
+				e.Meta[k] = v
+			}
+		}
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package gateway
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// OpenAPI 文档由 protoc-gen-openapiv2 从 proto 文件生成到 openapi 目录（见 rpc.text），
+// 每个 proto 包一份文档，按文档中的 info.version 提供：/openapi/{version}.json。
+
+//go:embed openapi
+var openapiFS embed.FS
+
+// apiDoc 一份 OpenAPI 文档
+type apiDoc struct {
+	Version string
+	Title   string
+	Content []byte
+}
+
+// loadDocs 读取内嵌的 OpenAPI 文档，按版本排序
+func loadDocs() ([]apiDoc, error) {
+	var files []string
+	err := fs.WalkDir(openapiFS, "openapi", func(name string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(name, ".swagger.json") {
+			files = append(files, name)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]apiDoc, 0, len(files))
+	for _, name := range files {
+		b, err := openapiFS.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		var spec struct {
+			Info struct {
+				Title   string `json:"title"`
+				Version string `json:"version"`
+			} `json:"info"`
+		}
+		if err := json.Unmarshal(b, &spec); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		if spec.Info.Version == "" {
+			return nil, fmt.Errorf("%s: missing info.version", name)
+		}
+		docs = append(docs, apiDoc{Version: spec.Info.Version, Title: spec.Info.Title, Content: b})
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Version < docs[j].Version })
+	return docs, nil
+}
+
+// defaultSwaggerUIURL Swagger UI 静态资源（swagger-ui-dist）的默认地址
+const defaultSwaggerUIURL = "https://unpkg.com/swagger-ui-dist@5"
+
+// swaggerPage Swagger UI 页面的参数
+type swaggerPage struct {
+	AssetsURL string // swagger-ui-dist 的地址，不以 / 结尾
+	Docs      []apiDoc
+}
+
+// swaggerUI Swagger UI 页面，静态资源从 AssetsURL 加载
+var swaggerUI = template.Must(template.New("swagger").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Stock Service API</title>
+  <link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{.AssetsURL}}/swagger-ui-bundle.js"></script>
+<script src="{{.AssetsURL}}/swagger-ui-standalone-preset.js"></script>
+<script>
+window.onload = function () {
+  window.ui = SwaggerUIBundle({
+    urls: [{{range .Docs}}{url: "/openapi/{{.Version}}.json", name: "{{.Title}} {{.Version}}"},{{end}}],
+    dom_id: "#swagger-ui",
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    layout: "StandaloneLayout"
+  });
+};
+</script>
+</body>
+</html>
+`))
+
+// registerDocs 在 mux 上注册 OpenAPI 文档和 Swagger UI 页面，assetsURL 为 Swagger UI 静态资源的地址，为空时从 CDN 加载
+func registerDocs(mux *http.ServeMux, assetsURL string) error {
+	docs, err := loadDocs()
+	if err != nil {
+		return err
+	}
+	if assetsURL == "" {
+		assetsURL = defaultSwaggerUIURL
+	}
+
+	// 页面内容固定，启动时生成一次
+	var page bytes.Buffer
+	if err := swaggerUI.Execute(&page, swaggerPage{AssetsURL: strings.TrimSuffix(assetsURL, "/"), Docs: docs}); err != nil {
+		return fmt.Errorf("render swagger ui: %w", err)
+	}
+
+	for _, d := range docs {
+		content := d.Content
+		mux.HandleFunc("GET /openapi/"+d.Version+".json", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			writeDoc(w, r, content)
+		})
+	}
+	mux.HandleFunc("GET /swagger/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		writeDoc(w, r, page.Bytes())
+	})
+	return nil
+}
+
+// writeDoc 写入文档内容，失败时记录日志（通常是客户端断开了连接）
+func writeDoc(w http.ResponseWriter, r *http.Request, b []byte) {
+	if _, err := w.Write(b); err != nil {
+		zap.L().Warn("write api doc failed", zap.String("path", r.URL.Path), zap.Error(err))
+	}
+}
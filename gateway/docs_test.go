@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func get(t *testing.T, h http.Handler, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	b, _ := io.ReadAll(rec.Body)
+	return rec.Code, string(b)
+}
+
+func TestSwaggerUIAssetsURL(t *testing.T) {
+	for _, tc := range []struct {
+		assetsURL string
+		want      string
+	}{
+		{"", defaultSwaggerUIURL + "/swagger-ui-bundle.js"},
+		{"https://static.internal/swagger-ui/", "https://static.internal/swagger-ui/swagger-ui-bundle.js"},
+	} {
+		mux := http.NewServeMux()
+		if err := registerDocs(mux, tc.assetsURL); err != nil {
+			t.Fatal(err)
+		}
+		code, body := get(t, mux, "/swagger/")
+		if code != http.StatusOK || !strings.Contains(body, `src="`+tc.want+`"`) {
+			t.Errorf("assets url %q: status %d, page does not load %s", tc.assetsURL, code, tc.want)
+		}
+		if strings.Contains(body, "unpkg.com") != (tc.assetsURL == "") {
+			t.Errorf("assets url %q: page still loads from the CDN", tc.assetsURL)
+		}
+		for _, v := range []string{"v1", "v2"} {
+			if !strings.Contains(body, `/openapi/`+v+`.json`) {
+				t.Errorf("page does not list the %s document", v)
+			}
+			if code, _ := get(t, mux, "/openapi/"+v+".json"); code != http.StatusOK {
+				t.Errorf("/openapi/%s.json: status %d", v, code)
+			}
+		}
+	}
+}
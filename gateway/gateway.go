@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"stock_service/auth"
+	"stock_service/config"
+	"stock_service/interceptor"
+	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// gRPC-Gateway：把 HTTP/JSON 请求转发给本机的 gRPC 服务，路由见 stock.proto 中的 google.api.http 注解
+
+// errorBody HTTP 接口的错误响应
+type errorBody struct {
+	Code    int32             `json:"code"`              // gRPC 状态码
+	Status  string            `json:"status"`            // gRPC 状态码名称，例如 NotFound
+	Message string            `json:"message"`           // 错误信息
+	Details []json.RawMessage `json:"details,omitempty"` // 错误详情
+}
+
+// NewHandler 创建 HTTP 处理器：/swagger/ 和 /openapi/ 提供接口文档，
+// 其余请求由 gRPC-Gateway 通过 grpcAddr 转发给 gRPC 服务，creds 为 nil 时使用明文连接。
+// cfg 中配置的可信代理转发的请求，客户端地址取 X-Forwarded-For 中的地址，否则取连接的对端地址；
+// Swagger UI 的静态资源从 cfg 中配置的地址加载，没有配置时从 CDN 加载。
+func NewHandler(ctx context.Context, grpcAddr string, creds credentials.TransportCredentials, cfg *config.GatewayConfig) (http.Handler, error) {
+	var proxies trustedProxies
+	var swaggerUIURL string
+	if cfg != nil {
+		var err error
+		if proxies, err = parseTrustedProxies(cfg.TrustedProxies); err != nil {
+			return nil, err
+		}
+		swaggerUIURL = cfg.SwaggerUIURL
+	}
+
+	gw := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+		runtime.WithMetadata(forwardClient(proxies)),
+	)
+
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(auth.GatewayCredentials()),
+	}
+	if err := proto.RegisterStockHandlerFromEndpoint(ctx, gw, grpcAddr, opts); err != nil {
+		return nil, err
+	}
+	if err := stockv2.RegisterStockHandlerFromEndpoint(ctx, gw, grpcAddr, opts); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	if err := registerDocs(mux, swaggerUIURL); err != nil {
+		return nil, err
+	}
+	mux.Handle("/", proxies.stripForwarded(gw))
+	return mux, nil
+}
+
+// forwardClient 把 HTTP 客户端的地址和校验过的客户端证书转发给 gRPC 服务，
+// 网关令牌保证只有网关能设置这些 metadata
+func forwardClient(proxies trustedProxies) func(context.Context, *http.Request) metadata.MD {
+	return func(_ context.Context, r *http.Request) metadata.MD {
+		md := metadata.Pairs(auth.GatewayClientHeader, proxies.clientIP(r))
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			md.Set(auth.GatewayClientCertHeader, string(r.TLS.VerifiedChains[0][0].Raw))
+		}
+		return md
+	}
+}
+
+// requestIDHeader HTTP 请求和响应中的请求ID头
+const requestIDHeader = "X-Request-Id"
+
+// incomingHeader 把 HTTP 请求中的 X-Request-Id、X-Api-Key 转为 gRPC metadata（Authorization 由 gRPC-Gateway 转发），
+// W3C trace context 的请求头原样转发，gRPC 服务端的链路接在上游的链路后面；其余按默认规则转发，
+// 但不能通过 Grpc-Metadata- 前缀伪造网关自己添加的 metadata
+func incomingHeader(key string) (string, bool) {
+	if strings.EqualFold(key, requestIDHeader) {
+		return interceptor.RequestIDHeader, true
+	}
+	switch strings.ToLower(key) {
+	case auth.APIKeyHeader:
+		return auth.APIKeyHeader, true
+	case "traceparent", "tracestate", "baggage":
+		return strings.ToLower(key), true
+	}
+	h, ok := runtime.DefaultHeaderMatcher(key)
+	switch strings.ToLower(h) {
+	case auth.GatewayTokenHeader, auth.GatewayClientHeader, auth.GatewayClientCertHeader, "x-forwarded-for", "x-forwarded-host":
+		return "", false
+	}
+	return h, ok
+}
+
+// outgoingHeader gRPC 响应头中的请求ID以 X-Request-Id 返回，重试间隔以 Retry-After 返回，
+// 其余按默认规则加上 Grpc-Metadata- 前缀
+func outgoingHeader(key string) (string, bool) {
+	switch key {
+	case interceptor.RequestIDHeader:
+		return requestIDHeader, true
+	case interceptor.RetryAfterHeader:
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// errorHandler 把 gRPC 状态码映射为 HTTP 状态码，并以统一的 JSON 格式返回错误
+func errorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	s := status.Convert(err)
+	body := errorBody{
+		Code:    int32(s.Code()),
+		Status:  s.Code().String(),
+		Message: s.Message(),
+	}
+	for _, d := range s.Proto().GetDetails() {
+		b, err := protojson.Marshal(d)
+		if err != nil {
+			continue
+		}
+		body.Details = append(body.Details, b)
+	}
+
+	// 出错时也返回请求ID，便于按请求ID查日志；被限流时返回 Retry-After。
+	// 出错的响应可能只有 trailer，两处都要看
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for _, id := range md.HeaderMD.Get(interceptor.RequestIDHeader) {
+			w.Header().Add(requestIDHeader, id)
+		}
+		for _, v := range append(md.HeaderMD.Get(interceptor.RetryAfterHeader), md.TrailerMD.Get(interceptor.RetryAfterHeader)...) {
+			w.Header().Set("Retry-After", v)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(s.Code()))
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		zap.L().Error("write gateway error response failed", zap.String("path", r.URL.Path), zap.Error(err))
+	}
+}
@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// trustedProxies 可信的反向代理，为空时不信任任何 X-Forwarded-For
+type trustedProxies []netip.Prefix
+
+// parseTrustedProxies 解析可信代理的配置，支持 IP 和 CIDR
+func parseTrustedProxies(list []string) (trustedProxies, error) {
+	proxies := make(trustedProxies, 0, len(list))
+	for _, s := range list {
+		if p, err := netip.ParsePrefix(s); err == nil {
+			proxies = append(proxies, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("gateway: invalid trusted proxy %q", s)
+		}
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// trusted 地址是否为可信代理
+func (t trustedProxies) trusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range t {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP 连接的对端地址
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// clientIP 返回请求的客户端地址：对端是可信代理时，从 X-Forwarded-For 的最后一个地址往前找，
+// 第一个不是可信代理的地址就是客户端；否则为对端地址
+func (t trustedProxies) clientIP(r *http.Request) string {
+	ip := remoteIP(r)
+	if !t.trusted(ip) {
+		return ip
+	}
+	var hops []string
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		for _, h := range strings.Split(v, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				hops = append(hops, h)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip = hops[i]
+		if !t.trusted(ip) {
+			break
+		}
+	}
+	return ip
+}
+
+// stripForwarded 对端不是可信代理时删除 X-Forwarded-For 和 X-Forwarded-Host，
+// gRPC-Gateway 不再把客户端自己填写的地址转发给 gRPC 服务
+func (t trustedProxies) stripForwarded(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !t.trusted(remoteIP(r)) {
+			r.Header.Del("X-Forwarded-For")
+			r.Header.Del("X-Forwarded-Host")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
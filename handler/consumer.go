@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"stock_service/biz/stock"
+	"stock_service/config"
+	"stock_service/dao/mysql"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/mq"
+
+	"go.uber.org/zap"
+)
+
+// MQ 消息的入口：订单取消（回滚预扣）、订单支付（确认扣减）和库存调整。
+// MQ 至少投递一次，每个商品的修改都会在同一个事务里写入消费记录（消息ID:商品ID + 消费者组），
+// 重复投递的消息在写入消费记录时被识别并跳过。
+// 回滚可以靠库存记录的状态挡住重复消息，确认和调整没有这样的状态（重复的调整消息会再加一次库存），
+// 所以所有消息驱动的修改都经过消费记录去重，不区分操作。
+
+// OrderCancelMsg 订单取消消息
+type OrderCancelMsg struct {
+	OrderId int64        `json:"order_id"` // 订单ID
+	Goods   []OrderGoods `json:"goods"`    // 订单中的商品
+}
+
+// OrderPaidMsg 订单支付消息
+type OrderPaidMsg struct {
+	OrderId int64   `json:"order_id"` // 订单ID
+	GoodsId []int64 `json:"goods_id"` // 订单中的商品ID
+}
+
+// StockAdjustMsg 库存调整消息
+type StockAdjustMsg struct {
+	GoodsId  int64  `json:"goods_id"` // 商品ID
+	Delta    int64  `json:"delta"`    // 库存增量，负数表示减少
+	Reason   string `json:"reason"`   // 调整原因
+	Operator string `json:"operator"` // 操作人
+}
+
+// OrderGoods 订单中的商品及数量
+type OrderGoods struct {
+	GoodsId int64 `json:"goods_id"` // 商品ID
+	Num     int64 `json:"num"`      // 数量，只用于日志，回滚的数量以库存记录为准
+}
+
+// Consumer 消息处理器
+type Consumer struct {
+	Group string // 消费者组，消费记录按消费者组区分
+}
+
+// withDedup 返回带有消费记录信息的 ctx，同一条消息中的每个商品单独去重
+func (c *Consumer) withDedup(ctx context.Context, msg *mq.Message, goodsId int64) context.Context {
+	return mysql.WithConsumedMessage(ctx, fmt.Sprintf("%s:%d", msg.ID, goodsId), c.Group)
+}
+
+// HandleOrderCancel 消费订单取消消息，回滚订单预扣的库存。
+// 每个商品的回滚在各自的事务中提交，全部提交后才返回 nil 确认消息；
+// 部分失败时整条消息重试，已回滚的商品因消费记录已存在会被跳过。
+// 回滚的数量取预扣时的库存记录，消息中的数量有误或消息重复时也不会多回滚。
+func (c *Consumer) HandleOrderCancel(ctx context.Context, msg *mq.Message) error {
+	var data OrderCancelMsg
+	if err := json.Unmarshal(msg.Body, &data); err != nil {
+		return mq.Permanent(fmt.Errorf("invalid order cancel message: %w", err))
+	}
+	if data.OrderId <= 0 || len(data.Goods) == 0 {
+		return mq.Permanent(fmt.Errorf("invalid order cancel message: %s", msg.Body))
+	}
+
+	for _, g := range data.Goods {
+		_, err := stock.RollbackStock(c.withDedup(ctx, msg, g.GoodsId), g.GoodsId, 0, data.OrderId)
+		if err != nil {
+			logger.Ctx(ctx).Error("HandleOrderCancel failed",
+				zap.String("msg_id", msg.ID),
+				zap.Int64("order_id", data.OrderId),
+				zap.Int64("goods_id", g.GoodsId),
+				zap.Int64("num", g.Num),
+				zap.Error(err))
+			return err
+		}
+	}
+	logger.Ctx(ctx).Info("订单取消，库存回滚成功", zap.String("msg_id", msg.ID), zap.Int64("order_id", data.OrderId))
+	return nil
+}
+
+// HandleOrderPaid 消费订单支付消息，确认扣减订单预扣的库存。
+// 预扣已回滚或已超时释放的商品无法确认，记录日志后跳过，由订单服务处理。
+func (c *Consumer) HandleOrderPaid(ctx context.Context, msg *mq.Message) error {
+	var data OrderPaidMsg
+	if err := json.Unmarshal(msg.Body, &data); err != nil {
+		return mq.Permanent(fmt.Errorf("invalid order paid message: %w", err))
+	}
+	if data.OrderId <= 0 || len(data.GoodsId) == 0 {
+		return mq.Permanent(fmt.Errorf("invalid order paid message: %s", msg.Body))
+	}
+
+	for _, goodsId := range data.GoodsId {
+		_, err := stock.ConfirmStock(c.withDedup(ctx, msg, goodsId), goodsId, data.OrderId)
+		if errors.Is(err, errno.ErrReservationReleased) || errors.Is(err, errno.ErrQueryEmpty) {
+			logger.Ctx(ctx).Warn("HandleOrderPaid skipped",
+				zap.String("msg_id", msg.ID),
+				zap.Int64("order_id", data.OrderId),
+				zap.Int64("goods_id", goodsId),
+				zap.Error(err))
+			continue
+		}
+		if err != nil {
+			logger.Ctx(ctx).Error("HandleOrderPaid failed",
+				zap.String("msg_id", msg.ID),
+				zap.Int64("order_id", data.OrderId),
+				zap.Int64("goods_id", goodsId),
+				zap.Error(err))
+			return err
+		}
+	}
+	logger.Ctx(ctx).Info("订单支付，库存确认成功", zap.String("msg_id", msg.ID), zap.Int64("order_id", data.OrderId))
+	return nil
+}
+
+// HandleStockAdjust 消费库存调整消息，按增量调整库存数量。
+// 商品不存在或调整后库存小于 0 的消息不会重试。
+func (c *Consumer) HandleStockAdjust(ctx context.Context, msg *mq.Message) error {
+	var data StockAdjustMsg
+	if err := json.Unmarshal(msg.Body, &data); err != nil {
+		return mq.Permanent(fmt.Errorf("invalid stock adjust message: %w", err))
+	}
+	if data.GoodsId <= 0 || data.Delta == 0 {
+		return mq.Permanent(fmt.Errorf("invalid stock adjust message: %s", msg.Body))
+	}
+
+	_, err := stock.AdjustStock(c.withDedup(ctx, msg, data.GoodsId), data.GoodsId, data.Delta, data.Reason, data.Operator)
+	if errors.Is(err, errno.ErrQueryEmpty) || errors.Is(err, errno.ErrUnderstock) {
+		return mq.Permanent(err)
+	}
+	if err != nil {
+		logger.Ctx(ctx).Error("HandleStockAdjust failed",
+			zap.String("msg_id", msg.ID),
+			zap.Int64("goods_id", data.GoodsId),
+			zap.Int64("delta", data.Delta),
+			zap.Error(err))
+		return err
+	}
+	logger.Ctx(ctx).Info("库存调整成功", zap.String("msg_id", msg.ID), zap.Int64("goods_id", data.GoodsId), zap.Int64("delta", data.Delta))
+	return nil
+}
+
+// StartConsumer 按配置启动 MQ 消费者，返回停止消费的函数
+func StartConsumer(cfg *config.MQConfig) (stop func(), err error) {
+	producer, err := mq.NewProducer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	consumer, err := mq.NewConsumer(cfg)
+	if err != nil {
+		producer.Shutdown()
+		return nil, err
+	}
+
+	c := &Consumer{Group: cfg.Group}
+	subs := map[string]mq.Handler{
+		cfg.RollbackTopic: c.HandleOrderCancel,
+		cfg.ConfirmTopic:  c.HandleOrderPaid,
+		cfg.AdjustTopic:   c.HandleStockAdjust,
+	}
+	for topic, h := range subs {
+		if topic == "" {
+			continue
+		}
+		h = mq.WithDeadLetter(h, producer, cfg.DeadLetterTopic, cfg.MaxRetries)
+		if err := consumer.Subscribe(topic, h); err != nil {
+			producer.Shutdown()
+			return nil, err
+		}
+	}
+	if err := consumer.Start(); err != nil {
+		producer.Shutdown()
+		return nil, err
+	}
+
+	return func() {
+		consumer.Shutdown()
+		producer.Shutdown()
+	}, nil
+}
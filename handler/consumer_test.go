@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"stock_service/biz/stock"
+	"stock_service/config"
+	"stock_service/dao/mysql"
+	"stock_service/dao/redis"
+	"stock_service/model"
+	"stock_service/mq"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	testGroup       = "stock_srv_test"
+	testCancelTopic = "order_cancel"
+	testAdjustTopic = "stock_adjust"
+	testDLQTopic    = "stock_dlq"
+)
+
+// setupStore 使用 SQLite 和 miniredis 初始化 dao 层，表结构与 sql 目录中的唯一键保持一致
+func setupStore(t *testing.T) *gorm.DB {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rc := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rc.Close() })
+	redis.Use(rc, &config.RedisConfig{NegativeTTL: 5 * time.Second})
+
+	dsn := filepath.Join(t.TempDir(), "stock.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true, Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&model.Stock{}, &model.StockRecord{}, &model.StockOutbox{}, &model.StockAudit{}, &model.ConsumedMessage{}, &model.Goods{}, &model.RoomGoods{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, ddl := range []string{
+		"CREATE UNIQUE INDEX uk_goods ON xx_stock (goods_id)",
+		"CREATE UNIQUE INDEX uk_order_goods ON xx_stock_record (order_id, goods_id)",
+		"CREATE UNIQUE INDEX uk_msg_group ON xx_consumed_message (msg_id, consumer_group)",
+	} {
+		if err := db.Exec(ddl).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	mysql.Use(db)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// delivery 一次投递的结果
+type delivery struct {
+	reconsumeTimes int32
+	err            error
+}
+
+// startMemoryMQ 启动内存消息队列，订阅方式与 StartConsumer 相同（带死信处理），
+// 每次投递的结果写入返回的 channel
+func startMemoryMQ(t *testing.T, topic string, h mq.Handler) (*mq.Memory, <-chan delivery) {
+	t.Helper()
+	m := mq.NewMemory()
+	m.RetryDelay = 10 * time.Millisecond
+	results := make(chan delivery, 16)
+	h = mq.WithDeadLetter(h, m, testDLQTopic, 3)
+	err := m.Subscribe(topic, func(ctx context.Context, msg *mq.Message) error {
+		err := h(ctx, msg)
+		results <- delivery{reconsumeTimes: msg.ReconsumeTimes, err: err}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { m.Shutdown() })
+	return m, results
+}
+
+func send(t *testing.T, m *mq.Memory, topic string, v any) {
+	t.Helper()
+	body, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Send(context.Background(), topic, body); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// next 等待下一次投递的结果
+func next(t *testing.T, results <-chan delivery) delivery {
+	t.Helper()
+	select {
+	case d := <-results:
+		return d
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+		return delivery{}
+	}
+}
+
+// reserve 创建库存并为订单预扣
+func reserve(t *testing.T, goodsId, stockNum, orderId, num int64) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := stock.SetStock(ctx, goodsId, stockNum); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stock.ReduceStock(ctx, goodsId, num, orderId, 0, mysql.Buyer{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertStock(t *testing.T, goodsId, wantStock, wantLock int64) {
+	t.Helper()
+	s, err := mysql.GetStockByGoodsId(context.Background(), goodsId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.StockNum != wantStock || s.Lock != wantLock {
+		t.Errorf("goods %d: stock=%d lock=%d, want stock=%d lock=%d", goodsId, s.StockNum, s.Lock, wantStock, wantLock)
+	}
+}
+
+func assertRecordStatus(t *testing.T, orderId, goodsId int64, want int32) {
+	t.Helper()
+	r, err := mysql.GetRecord(context.Background(), orderId, goodsId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Status != want {
+		t.Errorf("record %d/%d: status=%d, want %d", orderId, goodsId, r.Status, want)
+	}
+}
+
+// countRows 统计满足条件的行数
+func countRows(t *testing.T, db *gorm.DB, m any, query string, args ...any) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Model(m).Where(query, args...).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestHandleOrderCancelRollsBackReservation(t *testing.T) {
+	db := setupStore(t)
+	c := &Consumer{Group: testGroup}
+	m, results := startMemoryMQ(t, testCancelTopic, c.HandleOrderCancel)
+
+	reserve(t, 1001, 10, 1, 3)
+	assertStock(t, 1001, 7, 3)
+
+	// 消息中的数量有误，回滚的数量以库存记录为准
+	send(t, m, testCancelTopic, OrderCancelMsg{OrderId: 1, Goods: []OrderGoods{{GoodsId: 1001, Num: 99}}})
+	if d := next(t, results); d.err != nil {
+		t.Fatalf("HandleOrderCancel: %v", d.err)
+	}
+	assertStock(t, 1001, 10, 0)
+	assertRecordStatus(t, 1, 1001, model.StockRecordRolledBack)
+
+	// 同一订单的取消消息再次发送（消息ID不同），记录已回滚，不会重复回滚
+	send(t, m, testCancelTopic, OrderCancelMsg{OrderId: 1, Goods: []OrderGoods{{GoodsId: 1001, Num: 3}}})
+	if d := next(t, results); d.err != nil {
+		t.Fatalf("HandleOrderCancel: %v", d.err)
+	}
+	assertStock(t, 1001, 10, 0)
+	if n := countRows(t, db, &model.StockOutbox{}, "event_type = ?", model.EventStockRolledBack); n != 1 {
+		t.Errorf("rolled back events = %d, want 1", n)
+	}
+}
+
+func TestHandleOrderCancelRedeliversPartialFailure(t *testing.T) {
+	db := setupStore(t)
+	c := &Consumer{Group: testGroup}
+
+	reserve(t, 1001, 10, 1, 3)
+	reserve(t, 1002, 20, 1, 5)
+
+	// 第一次投递时 1002 的库存锁被占用，等待超时后整条消息失败；1001 已经回滚
+	lock := redis.Rs.NewMutex("xx-stock-1002")
+	if err := lock.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	h := func(ctx context.Context, msg *mq.Message) error {
+		if msg.ReconsumeTimes == 0 {
+			ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer cancel()
+			return c.HandleOrderCancel(ctx, msg)
+		}
+		return c.HandleOrderCancel(ctx, msg)
+	}
+	m, results := startMemoryMQ(t, testCancelTopic, h)
+
+	send(t, m, testCancelTopic, OrderCancelMsg{OrderId: 1, Goods: []OrderGoods{{GoodsId: 1001, Num: 3}, {GoodsId: 1002, Num: 5}}})
+	if d := next(t, results); d.err == nil {
+		t.Fatal("first delivery succeeded while goods 1002 was locked")
+	}
+	assertStock(t, 1001, 10, 0)
+	assertStock(t, 1002, 15, 5)
+	if _, err := lock.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新投递：1001 的消费记录已存在而跳过，只回滚 1002
+	d := next(t, results)
+	if d.err != nil {
+		t.Fatalf("redelivery: %v", d.err)
+	}
+	if d.reconsumeTimes != 1 {
+		t.Errorf("reconsume times = %d, want 1", d.reconsumeTimes)
+	}
+	assertStock(t, 1001, 10, 0)
+	assertStock(t, 1002, 20, 0)
+	assertRecordStatus(t, 1, 1002, model.StockRecordRolledBack)
+	for _, goodsId := range []int64{1001, 1002} {
+		if n := countRows(t, db, &model.StockOutbox{}, "goods_id = ? AND event_type = ?", goodsId, model.EventStockRolledBack); n != 1 {
+			t.Errorf("goods %d: rolled back events = %d, want 1", goodsId, n)
+		}
+		if n := countRows(t, db, &model.ConsumedMessage{}, "consumer_group = ? AND msg_id LIKE ?", testGroup, "%:"+strconv.FormatInt(goodsId, 10)); n != 1 {
+			t.Errorf("goods %d: consumed records = %d, want 1", goodsId, n)
+		}
+	}
+}
+
+func TestHandleStockAdjustSkipsDuplicateMessage(t *testing.T) {
+	db := setupStore(t)
+	c := &Consumer{Group: testGroup}
+	if _, err := stock.SetStock(context.Background(), 1001, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// 处理成功但确认丢失，同一条消息被再次投递
+	var delivered int
+	h := func(ctx context.Context, msg *mq.Message) error {
+		err := c.HandleStockAdjust(ctx, msg)
+		if delivered++; delivered == 1 && err == nil {
+			return errors.New("ack lost")
+		}
+		return err
+	}
+	m, results := startMemoryMQ(t, testAdjustTopic, h)
+
+	send(t, m, testAdjustTopic, StockAdjustMsg{GoodsId: 1001, Delta: 5, Reason: "inbound", Operator: "test"})
+	if d := next(t, results); d.err == nil {
+		t.Fatal("first delivery should report the lost ack")
+	}
+	if d := next(t, results); d.err != nil {
+		t.Fatalf("redelivery: %v", d.err)
+	}
+	assertStock(t, 1001, 15, 0)
+	if n := countRows(t, db, &model.StockOutbox{}, "event_type = ?", model.EventStockAdjusted); n != 1 {
+		t.Errorf("adjusted events = %d, want 1", n)
+	}
+}
+
+func TestInvalidMessageGoesToDeadLetter(t *testing.T) {
+	setupStore(t)
+	c := &Consumer{Group: testGroup}
+	m, results := startMemoryMQ(t, testCancelTopic, c.HandleOrderCancel)
+
+	send(t, m, testCancelTopic, OrderCancelMsg{OrderId: 1})
+	// 格式错误的消息不重试，转入死信主题后确认
+	if d := next(t, results); d.err != nil || d.reconsumeTimes != 0 {
+		t.Fatalf("delivery = %+v, want acked on first attempt", d)
+	}
+	if got := len(m.Messages(testDLQTopic)); got != 1 {
+		t.Errorf("dead letters = %d, want 1", got)
+	}
+}
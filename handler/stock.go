@@ -3,106 +3,183 @@ package handler
 import (
 	"context"
 	"stock_service/biz/stock"
-	"stock_service/dao/mysql"
-	"stock_service/model"
+	"stock_service/errno"
+	"stock_service/logger"
 	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
 
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // RPC的入口
+// v1 接口中与 v2 同名的接口只做参数转换，直接调用 StockV2Srv 的方法，不再经过拦截器。
+// 拦截器只以 v1 的方法名（/proto.Stock/...）处理 v1 请求：参数校验使用 v1 proto 中的规则，
+// 按方法名配置的权限（conf/rbac.yaml）和限流（ratelimit.rules）需要同时列出 v1 和 v2 的方法名。
 
 type StockSrv struct {
 	proto.UnimplementedStockServer
+
+	v2 StockV2Srv
+}
+
+// invalidParam 参数校验失败，返回 InvalidArgument。
+// 单个字段的校验规则写在 proto 中，由 interceptor.UnaryValidate 校验，这里只用于跨字段的校验
+func invalidParam(msg string) error {
+	return errno.ToStatus(errno.ErrInvalidParam.WithMessage(msg))
 }
 
 // SetStock 设置库存
 func (s *StockSrv) SetStock(ctx context.Context, req *proto.GoodsStockInfo) (*proto.Response, error) {
-	// 参数校验
-	if req.GetGoodsId() <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "无效的商品 ID")
+	_, err := s.v2.SetStock(ctx, &stockv2.SetStockReq{GoodsId: req.GetGoodsId(), Total: req.GetStock()})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Response{Success: true}, nil
+}
+
+// GetStock 获取库存数
+func (s *StockSrv) GetStock(ctx context.Context, req *proto.GetStockReq) (*proto.GoodsStockInfo, error) {
+	data, err := s.v2.GetStock(ctx, &stockv2.GetStockReq{GoodsId: req.GetGoodsId()})
+	if err != nil {
+		return nil, err
 	}
-	if req.GetStock() < 0 {
-		return nil, status.Error(codes.InvalidArgument, "库存数量不能为负")
+	return &proto.GoodsStockInfo{GoodsId: data.GetGoodsId(), Stock: data.GetTotal(), Exists: true}, nil
+}
+
+// BatchGetStock 批量获取库存，不存在的商品 exists 为 false
+func (s *StockSrv) BatchGetStock(ctx context.Context, req *proto.StockInfoList) (*proto.StockInfoList, error) {
+	goodsIds := make([]int64, 0, len(req.GetData()))
+	for _, g := range req.GetData() {
+		goodsIds = append(goodsIds, g.GetGoodsId())
 	}
 
-	// 调用 stock 包中的 SetStock 函数设置库存
-	_, err := stock.SetStock(ctx, req.GetGoodsId(), req.GetStock())
+	resp, err := s.v2.BatchGetStock(ctx, &stockv2.BatchGetStockReq{GoodsIds: goodsIds})
 	if err != nil {
-		// 如果设置库存失败，记录错误日志并返回错误
-		zap.L().Error(
-			"SetStock failed",
-			zap.Int64("goods_id", req.GoodsId),
-			zap.Int64("num", req.GetStock()),
-			zap.Error(err),
-		)
-		return nil, status.Errorf(codes.Internal, "设置库存失败: %v", err)
-	}
-
-	// 返回成功响应
-	return &proto.Response{}, nil
+		return nil, err
+	}
+	data := make([]*proto.GoodsStockInfo, 0, len(resp.GetData()))
+	for _, item := range resp.GetData() {
+		data = append(data, &proto.GoodsStockInfo{
+			GoodsId: item.GetGoodsId(),
+			Stock:   item.GetStock().GetTotal(),
+			Exists:  item.GetExists(),
+		})
+	}
+	return &proto.StockInfoList{Data: data}, nil
 }
 
-// GetStock 获取库存数
-func (s *StockSrv) GetStock(ctx context.Context, req *proto.GetStockReq) (*proto.GoodsStockInfo, error) {
-	if req.GetGoodsId() <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "无效的商品 ID")
+// ReduceStock 扣减库存，v1 请求不带用户和直播间，不校验限购和直播间配额
+func (s *StockSrv) ReduceStock(ctx context.Context, req *proto.ReduceStockInfo) (*proto.Response, error) {
+	_, err := s.v2.ReduceStock(ctx, &stockv2.ReduceStockReq{
+		GoodsId:            req.GetGoodsId(),
+		Num:                req.GetNum(),
+		OrderId:            req.GetOrderId(),
+		AutoReleaseSeconds: req.GetAutoReleaseSeconds(),
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &proto.Response{Success: true}, nil
+}
 
-	data, err := stock.GetStockByGoodsId(ctx, req.GetGoodsId())
+// RollbackStock 回滚库存，与 MQ 消费者共用同一套回滚逻辑
+// 重复归还（幂等性）由库存扣减记录表的状态保证
+func (s *StockSrv) RollbackStock(ctx context.Context, req *proto.RollBackStockInfo) (*proto.Response, error) {
+	_, err := s.v2.RollbackStock(ctx, &stockv2.RollbackStockReq{
+		GoodsId: req.GetGoodsId(),
+		Num:     req.GetRollbackNum(),
+		OrderId: req.GetOrderId(),
+	})
 	if err != nil {
-		zap.L().Error(
-			"GetStock failed",
-			zap.Int64("goods_id", req.GoodsId),
-			zap.Error(err),
-		)
-		return nil, status.Errorf(codes.Internal, "获取库存失败: %v", err)
+		return nil, err
+	}
+	return &proto.Response{
+		Success: true,
+		Message: "库存回滚成功",
+	}, nil
+}
+
+// ExportStock 导出库存快照（服务端流式）
+func (s *StockSrv) ExportStock(req *proto.ExportStockReq, stream proto.Stock_ExportStockServer) error {
+	if req.GetMaxGoodsId() > 0 && req.GetMinGoodsId() > req.GetMaxGoodsId() {
+		return invalidParam("商品 ID 范围无效")
 	}
 
-	return data, nil
+	err := stock.ExportStock(stream.Context(), req, stream.Send)
+	if err != nil {
+		logger.Ctx(stream.Context()).Error("ExportStock failed", zap.Any("req", req), zap.Error(err))
+		return errno.ToStatus(err)
+	}
+	return nil
 }
 
-// ReduceStock 扣减库存
-func (s *StockSrv) ReduceStock(ctx context.Context, req *proto.ReduceStockInfo) (*proto.Response, error) {
-	if req.GetGoodsId() <= 0 || req.GetNum() <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "无效的参数")
+// ReconcileStock 核对预扣库存与未结库存记录
+func (s *StockSrv) ReconcileStock(ctx context.Context, req *proto.ReconcileStockReq) (*proto.ReconcileStockResp, error) {
+	list, err := stock.ReconcileStock(ctx, req.GetGoodsIds(), req.GetRepair(), "rpc")
+	if err != nil {
+		logger.Ctx(ctx).Error("ReconcileStock failed", zap.Error(err))
+		return nil, errno.ToStatus(err)
+	}
+	return &proto.ReconcileStockResp{Mismatches: list}, nil
+}
+
+// DeleteStock 删除库存（软删除）
+func (s *StockSrv) DeleteStock(ctx context.Context, req *proto.DeleteStockReq) (*proto.Response, error) {
+	_, err := s.v2.DeleteStock(ctx, &stockv2.DeleteStockReq{GoodsId: req.GetGoodsId()})
+	if err != nil {
+		return nil, err
 	}
+	return &proto.Response{Success: true}, nil
+}
 
-	_, err := stock.ReduceStock(ctx, req.GetGoodsId(), req.GetNum(), req.GetOrderId())
+// UndeleteStock 恢复已删除的库存
+func (s *StockSrv) UndeleteStock(ctx context.Context, req *proto.UndeleteStockReq) (*proto.Response, error) {
+	_, err := s.v2.UndeleteStock(ctx, &stockv2.UndeleteStockReq{GoodsId: req.GetGoodsId()})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "扣减库存失败: %v", err)
+		return nil, err
 	}
+	return &proto.Response{Success: true}, nil
+}
 
-	return &proto.Response{}, nil
+// GetOrderReservations 查询订单的库存预占记录
+func (s *StockSrv) GetOrderReservations(ctx context.Context, req *proto.GetOrderReservationsReq) (*proto.ReservationList, error) {
+	data, err := stock.GetOrderReservations(ctx, req.GetOrderId())
+	if err != nil {
+		logger.Ctx(ctx).Error("GetOrderReservations failed", zap.Int64("order_id", req.GetOrderId()), zap.Error(err))
+		return nil, errno.ToStatus(err)
+	}
+	return data, nil
 }
 
-// RollbackMsghandle 监听rocketmq消息进行库存回滚的处理函数
-// 需考虑重复归还的问题（幂等性）  用库存扣减记录表解决
-// 添加库存扣减记录表
-func (s *StockSrv) RollbackStock(ctx context.Context, req *proto.RollBackStockInfo) (*proto.Response, error) {
-	// 将 proto.ReduceStockInfo 转换为 model.StockRecord
-	data := model.StockRecord{
-		GoodsId: req.GoodsId,
-		Num: req.RollbackNum,
-		OrderId: req.OrderId,		
+// ListReservations 分页查询库存预占记录
+func (s *StockSrv) ListReservations(ctx context.Context, req *proto.ListReservationsReq) (*proto.ReservationList, error) {
+	if req.GetStartTime() > 0 && req.GetEndTime() > 0 && req.GetStartTime() >= req.GetEndTime() {
+		return nil, invalidParam("无效的时间范围")
 	}
 
-	// 调用数据库层的库存回滚方法
-	err := mysql.RollbackStockByMsg(ctx, data)
+	data, err := stock.ListReservations(ctx, req)
 	if err != nil {
-		// 如果库存回滚失败，记录错误并返回失败响应
-		zap.L().Error("RollbackStockByMsg failed", zap.Error(err), zap.Int64("goods_id", req.GoodsId), zap.Int64("order_id", req.OrderId))
-		return &proto.Response{
-			Success: false,
-			Message: "库存回滚失败", // 返回错误的具体原因
-		}, nil
+		logger.Ctx(ctx).Error("ListReservations failed", zap.Any("req", req), zap.Error(err))
+		return nil, errno.ToStatus(err)
 	}
+	return data, nil
+}
 
-	// 如果成功处理消息，返回成功结果
-	return &proto.Response{
-		Success: true,
-		Message: "库存回滚成功",
-	}, nil
+// ListStock 分页查询库存列表
+func (s *StockSrv) ListStock(ctx context.Context, req *proto.ListStockReq) (*proto.ListStockResp, error) {
+	data, err := stock.ListStock(ctx, req)
+	if err != nil {
+		logger.Ctx(ctx).Error("ListStock failed", zap.Any("req", req), zap.Error(err))
+		return nil, errno.ToStatus(err)
+	}
+	return data, nil
+}
+
+// ConfirmStock 确认扣减库存（订单支付后调用）
+func (s *StockSrv) ConfirmStock(ctx context.Context, req *proto.ConfirmStockInfo) (*proto.Response, error) {
+	_, err := s.v2.ConfirmStock(ctx, &stockv2.ConfirmStockReq{GoodsId: req.GetGoodsId(), OrderId: req.GetOrderId()})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Response{Success: true}, nil
 }
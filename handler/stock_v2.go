@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"stock_service/biz/stock"
+	"stock_service/dao/mysql"
+	"stock_service/errno"
+	"stock_service/interceptor"
+	"stock_service/logger"
+	"stock_service/model"
+	stockv2 "stock_service/proto/v2"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// v2 接口的入口，v1 的同名接口是这里的适配层
+// v1 适配层直接调用这里的方法，不经过拦截器，v1 请求的参数校验由 v1 proto 中的规则完成，两边的规则要保持一致；
+// 权限和限流也不会以 /stock.v2.Stock/... 的方法名看到 v1 请求（见 handler/stock.go）
+
+type StockV2Srv struct {
+	stockv2.UnimplementedStockServer
+}
+
+// mutationReq 修改类请求，都带有 RequestMeta
+type mutationReq interface {
+	proto.Message
+	GetMeta() *stockv2.RequestMeta
+}
+
+// requestHash 请求内容的 SHA-256，不包含 meta（重试时请求ID可能不同），用来判断幂等键是否被不同的请求复用
+func requestHash(req mutationReq) string {
+	m := proto.Clone(req)
+	if fd := m.ProtoReflect().Descriptor().Fields().ByName("meta"); fd != nil {
+		m.ProtoReflect().Clear(fd)
+	}
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m) // 刚反序列化的请求不会序列化失败
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// requestMeta 返回带有幂等控制的 ctx 和请求ID。
+// 请求ID 由 interceptor.UnaryRequestID 确定（meta.request_id 优先），没有经过拦截器时使用 meta 中的值或生成。
+// 幂等键的长度限制（与消费记录表的 msg_id 列一致）写在 proto 中。
+func requestMeta(ctx context.Context, op string, req mutationReq) (context.Context, string) {
+	meta := req.GetMeta()
+	requestId := interceptor.RequestID(ctx)
+	if requestId == "" {
+		requestId = meta.GetRequestId()
+	}
+	if requestId == "" {
+		requestId = uuid.NewString()
+	}
+	key := meta.GetIdempotencyKey()
+	if key == "" {
+		return ctx, requestId
+	}
+	return stock.WithIdempotencyKey(ctx, op, key, requestHash(req)), requestId
+}
+
+// toStockState 转换库存状态，s 为 nil 时返回 nil。
+// 预扣时 stocknum 已经减去了预扣的数量，可用库存就是 stocknum，总数为可用加预扣。
+func toStockState(s *model.Stock) *stockv2.StockState {
+	if s == nil {
+		return nil
+	}
+	return &stockv2.StockState{
+		GoodsId:   s.GoodsId,
+		Available: s.StockNum,
+		Locked:    s.Lock,
+		Total:     s.StockNum + s.Lock,
+		UpdateAt:  s.UpdateAt.Unix(),
+	}
+}
+
+// toMutationResp 转换修改结果
+func toMutationResp(requestId string, res *stock.Result) *stockv2.MutationResp {
+	resp := &stockv2.MutationResp{
+		RequestId: requestId,
+		Stock:     toStockState(res.Stock),
+		Replayed:  res.Replayed,
+	}
+	if res.Record != nil {
+		resp.RecordId = int64(res.Record.ID)
+		resp.RecordStatus = stockv2.RecordStatus(res.Record.Status)
+	}
+	return resp
+}
+
+// mutationResp 返回修改结果：重复请求返回首次请求保存的响应（请求ID为本次的），
+// 首次请求还没有保存响应时（并发的重复请求或保存失败）返回当前的库存；
+// 带幂等键的首次请求保存响应，保存失败只记录日志，重复请求退化为返回当前的库存。
+func mutationResp(ctx context.Context, requestId string, res *stock.Result) *stockv2.MutationResp {
+	if res.Response != nil {
+		resp := &stockv2.MutationResp{}
+		if err := proto.Unmarshal(res.Response, resp); err == nil {
+			resp.RequestId = requestId
+			resp.Replayed = true
+			return resp
+		}
+		logger.Ctx(ctx).Warn("解析保存的幂等响应失败，返回当前的库存")
+	}
+
+	resp := toMutationResp(requestId, res)
+	if !res.Replayed {
+		if b, err := proto.Marshal(resp); err == nil {
+			if err := stock.SaveResponse(ctx, b); err != nil {
+				logger.Ctx(ctx).Warn("保存幂等响应失败", zap.Error(err))
+			}
+		}
+	}
+	return resp
+}
+
+// mutationErr 记录修改失败的日志并转换为 gRPC 状态
+func mutationErr(ctx context.Context, op string, goodsId int64, err error) error {
+	logger.Ctx(ctx).Error(op+" failed", zap.Int64("goods_id", goodsId), zap.Error(err))
+	return errno.ToStatus(err)
+}
+
+// GetStock 获取库存
+func (s *StockV2Srv) GetStock(ctx context.Context, req *stockv2.GetStockReq) (*stockv2.StockState, error) {
+	data, err := stock.GetStockByGoodsId(ctx, req.GetGoodsId())
+	if err != nil {
+		// 商品不存在是正常的查询结果，不记录错误日志
+		if !errors.Is(err, errno.ErrQueryEmpty) {
+			logger.Ctx(ctx).Error("GetStock failed", zap.Int64("goods_id", req.GetGoodsId()), zap.Error(err))
+		}
+		return nil, errno.ToStatus(err)
+	}
+	return toStockState(data), nil
+}
+
+// BatchGetStock 批量获取库存，按请求顺序返回
+func (s *StockV2Srv) BatchGetStock(ctx context.Context, req *stockv2.BatchGetStockReq) (*stockv2.BatchGetStockResp, error) {
+	list, err := stock.BatchGetStock(ctx, req.GetGoodsIds())
+	if err != nil {
+		logger.Ctx(ctx).Error("BatchGetStock failed", zap.Int64s("goods_ids", req.GetGoodsIds()), zap.Error(err))
+		return nil, errno.ToStatus(err)
+	}
+	data := make([]*stockv2.BatchStockItem, len(list))
+	for i, s := range list {
+		data[i] = &stockv2.BatchStockItem{
+			GoodsId: req.GetGoodsIds()[i],
+			Exists:  s != nil,
+			Stock:   toStockState(s),
+		}
+	}
+	return &stockv2.BatchGetStockResp{Data: data}, nil
+}
+
+// CheckStock 预检一组商品能否扣减，不预扣库存
+func (s *StockV2Srv) CheckStock(ctx context.Context, req *stockv2.CheckStockReq) (*stockv2.CheckStockResp, error) {
+	items := make([]stock.CheckItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = stock.CheckItem{GoodsId: item.GetGoodsId(), Num: item.GetNum()}
+	}
+
+	verdicts, err := stock.CheckStock(ctx, items, mysql.Buyer{UserId: req.GetUserId(), RoomId: req.GetRoomId()})
+	if err != nil {
+		logger.Ctx(ctx).Error("CheckStock failed", zap.Error(err))
+		return nil, errno.ToStatus(err)
+	}
+
+	resp := &stockv2.CheckStockResp{Ok: true, Items: make([]*stockv2.CheckVerdict, len(verdicts))}
+	for i, v := range verdicts {
+		item := &stockv2.CheckVerdict{
+			GoodsId:   v.GoodsId,
+			Num:       v.Num,
+			Ok:        v.Err == nil,
+			Available: v.Available,
+			Shortfall: v.Shortfall,
+		}
+		if v.Err != nil {
+			resp.Ok = false
+			item.Reason, item.Message = errno.ErrInternal.Reason, errno.ErrInternal.Message
+			var e *errno.Error
+			if errors.As(v.Err, &e) {
+				item.Reason, item.Message = e.Reason, e.Message
+			}
+		}
+		resp.Items[i] = item
+	}
+	return resp, nil
+}
+
+// SetStock 设置库存
+func (s *StockV2Srv) SetStock(ctx context.Context, req *stockv2.SetStockReq) (*stockv2.MutationResp, error) {
+	ctx, requestId := requestMeta(ctx, "SetStock", req)
+
+	res, err := stock.SetStock(ctx, req.GetGoodsId(), req.GetTotal())
+	if err != nil {
+		return nil, mutationErr(ctx, "SetStock", req.GetGoodsId(), err)
+	}
+	return mutationResp(ctx, requestId, res), nil
+}
+
+// ReduceStock 预扣库存
+func (s *StockV2Srv) ReduceStock(ctx context.Context, req *stockv2.ReduceStockReq) (*stockv2.MutationResp, error) {
+	ctx, requestId := requestMeta(ctx, "ReduceStock", req)
+
+	buyer := mysql.Buyer{UserId: req.GetUserId(), RoomId: req.GetRoomId()}
+	res, err := stock.ReduceStock(ctx, req.GetGoodsId(), req.GetNum(), req.GetOrderId(), req.GetAutoReleaseSeconds(), buyer)
+	if err != nil {
+		return nil, mutationErr(ctx, "ReduceStock", req.GetGoodsId(), err)
+	}
+	return mutationResp(ctx, requestId, res), nil
+}
+
+// RollbackStock 回滚预扣的库存
+func (s *StockV2Srv) RollbackStock(ctx context.Context, req *stockv2.RollbackStockReq) (*stockv2.MutationResp, error) {
+	ctx, requestId := requestMeta(ctx, "RollbackStock", req)
+
+	res, err := stock.RollbackStock(ctx, req.GetGoodsId(), req.GetNum(), req.GetOrderId())
+	if err != nil {
+		return nil, mutationErr(ctx, "RollbackStock", req.GetGoodsId(), err)
+	}
+	return mutationResp(ctx, requestId, res), nil
+}
+
+// ConfirmStock 确认扣减库存（订单支付后调用）
+func (s *StockV2Srv) ConfirmStock(ctx context.Context, req *stockv2.ConfirmStockReq) (*stockv2.MutationResp, error) {
+	ctx, requestId := requestMeta(ctx, "ConfirmStock", req)
+
+	res, err := stock.ConfirmStock(ctx, req.GetGoodsId(), req.GetOrderId())
+	if err != nil {
+		return nil, mutationErr(ctx, "ConfirmStock", req.GetGoodsId(), err)
+	}
+	return mutationResp(ctx, requestId, res), nil
+}
+
+// AdjustStock 按增量调整库存数量
+func (s *StockV2Srv) AdjustStock(ctx context.Context, req *stockv2.AdjustStockReq) (*stockv2.MutationResp, error) {
+	ctx, requestId := requestMeta(ctx, "AdjustStock", req)
+
+	res, err := stock.AdjustStock(ctx, req.GetGoodsId(), req.GetDelta(), req.GetReason(), "rpc")
+	if err != nil {
+		return nil, mutationErr(ctx, "AdjustStock", req.GetGoodsId(), err)
+	}
+	return mutationResp(ctx, requestId, res), nil
+}
+
+// DeleteStock 删除库存（软删除）
+func (s *StockV2Srv) DeleteStock(ctx context.Context, req *stockv2.DeleteStockReq) (*stockv2.MutationResp, error) {
+	ctx, requestId := requestMeta(ctx, "DeleteStock", req)
+
+	res, err := stock.DeleteStock(ctx, req.GetGoodsId())
+	if err != nil {
+		return nil, mutationErr(ctx, "DeleteStock", req.GetGoodsId(), err)
+	}
+	return mutationResp(ctx, requestId, res), nil
+}
+
+// UndeleteStock 恢复已删除的库存
+func (s *StockV2Srv) UndeleteStock(ctx context.Context, req *stockv2.UndeleteStockReq) (*stockv2.MutationResp, error) {
+	ctx, requestId := requestMeta(ctx, "UndeleteStock", req)
+
+	res, err := stock.UndeleteStock(ctx, req.GetGoodsId())
+	if err != nil {
+		return nil, mutationErr(ctx, "UndeleteStock", req.GetGoodsId(), err)
+	}
+	return mutationResp(ctx, requestId, res), nil
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"stock_service/biz/stock"
+	"stock_service/config"
+	"stock_service/errno"
+	"stock_service/model"
+	stockv2 "stock_service/proto/v2"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reason 返回 gRPC 错误中的业务错误原因，nil 时为空字符串
+func reason(err error) string {
+	if err == nil {
+		return ""
+	}
+	st, _ := status.FromError(err)
+	return errno.FromStatus(st).Reason
+}
+
+func TestReduceRules(t *testing.T) {
+	db := setupStore(t)
+	// 1001 上架，每人限购 3 件，在直播间 7 的配额为 4 件；1002 未上架；1003 不在商品表中
+	reserve(t, 1001, 100, 1, 1)
+	reserve(t, 1002, 100, 2, 1)
+	reserve(t, 1003, 100, 3, 1)
+	for _, row := range []any{
+		&model.Goods{GoodsId: 1001, Status: model.GoodsOnShelf},
+		&model.Goods{GoodsId: 1002, Status: model.GoodsOffShelf},
+		&model.RoomGoods{RoomId: 7, GoodsId: 1001, Quota: 4},
+	} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Model(&model.Stock{}).Where("goods_id = ?", 1001).Update("user_limit", 3).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 库存准备好之后再开启上架校验
+	prev := config.Conf.StockRuleConfig
+	config.Conf.StockRuleConfig = &config.StockRuleConfig{CheckOnShelf: true}
+	t.Cleanup(func() { config.Conf.StockRuleConfig = prev })
+
+	s := &StockV2Srv{}
+	ctx := context.Background()
+	// 用户 42 在直播间 7 已经预扣了 2 件
+	_, err := s.ReduceStock(ctx, &stockv2.ReduceStockReq{GoodsId: 1001, Num: 2, OrderId: 10, UserId: 42, RoomId: 7})
+	if err != nil {
+		t.Fatalf("ReduceStock: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		goodsId       int64
+		num           int64
+		userId        int64
+		roomId        int64
+		wantReason    string
+		wantShortfall int64
+	}{
+		{"within all limits", 1001, 1, 42, 7, "", 0},
+		{"off shelf", 1002, 1, 0, 0, errno.ErrGoodsOffShelf.Reason, 1},
+		{"not in goods table", 1003, 1, 0, 0, errno.ErrGoodsOffShelf.Reason, 1},
+		{"not found", 1004, 1, 0, 0, errno.ErrQueryEmpty.Reason, 1},
+		{"understock", 1001, 200, 0, 0, errno.ErrUnderstock.Reason, 103},
+		{"user limit", 1001, 2, 42, 0, errno.ErrPurchaseLimit.Reason, 1},
+		{"user limit counts only this user", 1001, 3, 43, 0, "", 0},
+		{"room quota", 1001, 3, 0, 7, errno.ErrRoomQuota.Reason, 1},
+		{"room without the goods", 1001, 3, 0, 8, "", 0},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.CheckStock(ctx, &stockv2.CheckStockReq{
+				Items:  []*stockv2.CheckItem{{GoodsId: tt.goodsId, Num: tt.num}},
+				UserId: tt.userId,
+				RoomId: tt.roomId,
+			})
+			if err != nil {
+				t.Fatalf("CheckStock: %v", err)
+			}
+			v := resp.GetItems()[0]
+			if v.GetReason() != tt.wantReason || v.GetShortfall() != tt.wantShortfall {
+				t.Errorf("CheckStock: reason=%q shortfall=%d, want reason=%q shortfall=%d", v.GetReason(), v.GetShortfall(), tt.wantReason, tt.wantShortfall)
+			}
+
+			// ReduceStock 使用相同的规则
+			_, err = s.ReduceStock(ctx, &stockv2.ReduceStockReq{
+				GoodsId: tt.goodsId,
+				Num:     tt.num,
+				OrderId: int64(100 + i),
+				UserId:  tt.userId,
+				RoomId:  tt.roomId,
+			})
+			if got := reason(err); got != tt.wantReason {
+				t.Errorf("ReduceStock: reason=%q, want %q", got, tt.wantReason)
+			}
+			// 扣减成功的用例回滚，不影响后面的用例
+			if err == nil {
+				if _, err := s.RollbackStock(ctx, &stockv2.RollbackStockReq{GoodsId: tt.goodsId, Num: tt.num, OrderId: int64(100 + i)}); err != nil {
+					t.Fatalf("RollbackStock: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckStockAccumulatesRepeatedGoods(t *testing.T) {
+	db := setupStore(t)
+	reserve(t, 1001, 10, 1, 1)
+	if err := db.Model(&model.Stock{}).Where("goods_id = ?", 1001).Update("user_limit", 5).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// 同一商品出现两次，按累计数量校验限购，第二项只差超出的部分
+	resp, err := (&StockV2Srv{}).CheckStock(context.Background(), &stockv2.CheckStockReq{
+		Items:  []*stockv2.CheckItem{{GoodsId: 1001, Num: 3}, {GoodsId: 1001, Num: 4}},
+		UserId: 42,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetOk() {
+		t.Error("CheckStock ok, want the second item to exceed the purchase limit")
+	}
+	first, second := resp.GetItems()[0], resp.GetItems()[1]
+	if !first.GetOk() {
+		t.Errorf("first item: reason=%q, want ok", first.GetReason())
+	}
+	if second.GetReason() != errno.ErrPurchaseLimit.Reason || second.GetShortfall() != 2 {
+		t.Errorf("second item: reason=%q shortfall=%d, want %q shortfall=2", second.GetReason(), second.GetShortfall(), errno.ErrPurchaseLimit.Reason)
+	}
+}
+
+func TestIdempotencyKeyReplaysStoredResponse(t *testing.T) {
+	setupStore(t)
+	if _, err := stock.SetStock(context.Background(), 1001, 10); err != nil {
+		t.Fatal(err)
+	}
+	s := &StockV2Srv{}
+	ctx := context.Background()
+	meta := func(requestId string) *stockv2.RequestMeta {
+		return &stockv2.RequestMeta{RequestId: requestId, IdempotencyKey: "k1"}
+	}
+
+	first, err := s.ReduceStock(ctx, &stockv2.ReduceStockReq{Meta: meta("r1"), GoodsId: 1001, Num: 2, OrderId: 1})
+	if err != nil {
+		t.Fatalf("ReduceStock: %v", err)
+	}
+	// 库存在重试之前被其他请求修改，重试仍然返回首次请求的结果
+	if _, err := s.ReduceStock(ctx, &stockv2.ReduceStockReq{GoodsId: 1001, Num: 3, OrderId: 2}); err != nil {
+		t.Fatalf("ReduceStock: %v", err)
+	}
+
+	// 请求ID不参与请求内容的比较
+	retry, err := s.ReduceStock(ctx, &stockv2.ReduceStockReq{Meta: meta("r2"), GoodsId: 1001, Num: 2, OrderId: 1})
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !retry.GetReplayed() || retry.GetRequestId() != "r2" {
+		t.Errorf("retry: replayed=%v request_id=%q, want replayed with request_id r2", retry.GetReplayed(), retry.GetRequestId())
+	}
+	if retry.GetStock().GetAvailable() != first.GetStock().GetAvailable() || retry.GetRecordId() != first.GetRecordId() {
+		t.Errorf("retry: available=%d record_id=%d, want the first response available=%d record_id=%d",
+			retry.GetStock().GetAvailable(), retry.GetRecordId(), first.GetStock().GetAvailable(), first.GetRecordId())
+	}
+	assertStock(t, 1001, 5, 5)
+
+	// 相同幂等键、不同内容的请求被拒绝，不修改库存
+	_, err = s.ReduceStock(ctx, &stockv2.ReduceStockReq{Meta: meta("r3"), GoodsId: 1001, Num: 4, OrderId: 1})
+	if got := reason(err); got != errno.ErrIdempotencyKeyReused.Reason {
+		t.Errorf("reused key: reason=%q, want %q", got, errno.ErrIdempotencyKeyReused.Reason)
+	}
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("reused key: code=%s, want FailedPrecondition", st.Code())
+	}
+	assertStock(t, 1001, 5, 5)
+
+	// 幂等键按方法区分，其他方法可以使用相同的幂等键
+	if _, err := s.RollbackStock(ctx, &stockv2.RollbackStockReq{Meta: meta("r4"), GoodsId: 1001, Num: 2, OrderId: 1}); err != nil {
+		t.Fatalf("RollbackStock: %v", err)
+	}
+	assertStock(t, 1001, 7, 3)
+}
+
+func TestCheckStockIgnoresNegativeNum(t *testing.T) {
+	setupStore(t)
+	if _, err := stock.SetStock(context.Background(), 1001, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// 没有经过参数校验时，负数也不能抵消同一商品的其他项
+	resp, err := (&StockV2Srv{}).CheckStock(context.Background(), &stockv2.CheckStockReq{
+		Items: []*stockv2.CheckItem{{GoodsId: 1001, Num: -95}, {GoodsId: 1001, Num: 100}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetOk() {
+		t.Fatal("CheckStock ok, want the request to fail")
+	}
+	first, second := resp.GetItems()[0], resp.GetItems()[1]
+	if first.GetReason() != errno.ErrInvalidParam.Reason {
+		t.Errorf("first item: reason=%q, want %q", first.GetReason(), errno.ErrInvalidParam.Reason)
+	}
+	if second.GetReason() != errno.ErrUnderstock.Reason || second.GetShortfall() != 90 {
+		t.Errorf("second item: reason=%q shortfall=%d, want %q shortfall=90", second.GetReason(), second.GetShortfall(), errno.ErrUnderstock.Reason)
+	}
+}
+
+func TestStockStateCountsReservedOnce(t *testing.T) {
+	setupStore(t)
+	s := &StockV2Srv{}
+	ctx := context.Background()
+	if _, err := s.SetStock(ctx, &stockv2.SetStockReq{GoodsId: 1001, Total: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 预扣 3 件后可用 7 件、预扣 3 件，总数不变
+	resp, err := s.ReduceStock(ctx, &stockv2.ReduceStockReq{GoodsId: 1001, Num: 3, OrderId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := resp.GetStock(); st.GetAvailable() != 7 || st.GetLocked() != 3 || st.GetTotal() != 10 {
+		t.Errorf("stock = %+v, want available=7 locked=3 total=10", st)
+	}
+
+	// 预检和扣减都可以用完剩下的 7 件
+	check, err := s.CheckStock(ctx, &stockv2.CheckStockReq{Items: []*stockv2.CheckItem{{GoodsId: 1001, Num: 8}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := check.GetItems()[0]; v.GetAvailable() != 7 || v.GetShortfall() != 1 {
+		t.Errorf("CheckStock: available=%d shortfall=%d, want available=7 shortfall=1", v.GetAvailable(), v.GetShortfall())
+	}
+	if _, err := s.ReduceStock(ctx, &stockv2.ReduceStockReq{GoodsId: 1001, Num: 7, OrderId: 2}); err != nil {
+		t.Fatalf("ReduceStock the remaining 7: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"stock_service/logger"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// healthPrefix Consul 每隔几秒调用一次健康检查，不记录访问日志
+var healthPrefix = "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/"
+
+// accessLevel 按状态码决定日志级别：成功为 Info，服务端的问题为 Error，其余（参数错误、库存不足等）为 Warn
+func accessLevel(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK:
+		return zapcore.InfoLevel
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		return zapcore.ErrorLevel
+	}
+	return zapcore.WarnLevel
+}
+
+// accessLog 记录一条访问日志
+func accessLog(ctx context.Context, method string, start time.Time, err error) {
+	if strings.HasPrefix(method, healthPrefix) {
+		return
+	}
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.Duration("latency", time.Since(start)),
+		zap.String("code", code.String()),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		fields = append(fields, zap.String("error", status.Convert(err).Message()))
+	}
+	logger.Ctx(ctx).Log(accessLevel(code), "access", fields...)
+}
+
+// UnaryAccessLog 记录方法、调用方地址、耗时和状态码
+func UnaryAccessLog(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	accessLog(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamAccessLog 流式接口的访问日志，耗时为整个流的时长
+func StreamAccessLog(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	accessLog(ss.Context(), info.FullMethod, start, err)
+	return err
+}
@@ -0,0 +1,78 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"stock_service/auth"
+	"stock_service/errno"
+	"stock_service/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// authenticate 校验调用方的凭证，认证通过后把调用方身份放进 ctx；健康检查不需要认证
+func authenticate(ctx context.Context, method string) (context.Context, error) {
+	if strings.HasPrefix(method, healthPrefix) {
+		return ctx, nil
+	}
+	id, err := auth.Authenticate(ctx, method)
+	if err != nil {
+		logger.Ctx(ctx).Warn("authentication failed", zap.String("method", method), zap.Error(err))
+		return nil, errno.ToStatus(err)
+	}
+	if id == nil {
+		return ctx, nil
+	}
+	return auth.NewContext(ctx, id), nil
+}
+
+// verifyContent 校验请求消息与签名中的摘要一致（HMAC 签名），不一致时返回 Unauthenticated
+func verifyContent(ctx context.Context, method string, req any) error {
+	err := auth.VerifyContent(auth.FromContext(ctx), req)
+	if err == nil {
+		return nil
+	}
+	logger.Ctx(ctx).Warn("authentication failed", zap.String("method", method), zap.Error(err))
+	return errno.ToStatus(errno.ErrUnauthenticated.WithMessage("invalid credentials").Wrap(err))
+}
+
+// UnaryAuth 认证调用方，失败时返回 Unauthenticated
+func UnaryAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyContent(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// contentStream 校验客户端发送的第一条消息与签名中的摘要一致
+type contentStream struct {
+	serverStream
+	method   string
+	received bool
+}
+
+func (s *contentStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.received {
+		return nil
+	}
+	s.received = true
+	return verifyContent(s.ctx, s.method, m)
+}
+
+// StreamAuth 流式接口在建立流时认证，签名只覆盖客户端发送的第一条消息
+func StreamAuth(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contentStream{serverStream: serverStream{ServerStream: ss, ctx: ctx}, method: info.FullMethod})
+}
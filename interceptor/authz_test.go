@@ -0,0 +1,123 @@
+package interceptor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"stock_service/auth"
+	"stock_service/config"
+	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
+	"stock_service/rbac"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authzPolicy = `
+roles:
+  admin:
+    - methods: ["*"]
+  order:
+    - methods: ["/stock.v2.Stock/ReduceStock"]
+  reconciler:
+    - methods: ["/proto.Stock/ReconcileStock"]
+  merchant:
+    - methods: ["/stock.v2.Stock/SetStock", "/proto.Stock/ExportStock"]
+      goods: [1001]
+`
+
+func initPolicy(t *testing.T) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "rbac.yaml")
+	if err := os.WriteFile(file, []byte(authzPolicy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := rbac.Init(&config.RBACConfig{Enable: true, PolicyFile: file}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func withRoles(roles ...string) context.Context {
+	if roles == nil {
+		return context.Background()
+	}
+	return auth.NewContext(context.Background(), &auth.Identity{Caller: "test", Scheme: auth.SchemeAPIKey, Roles: roles})
+}
+
+func TestUnaryAuthz(t *testing.T) {
+	initPolicy(t)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{"admin", withRoles("admin"), "/stock.v2.Stock/SetStock", &stockv2.SetStockReq{GoodsId: 1}, codes.OK},
+		{"order reduce", withRoles("order"), "/stock.v2.Stock/ReduceStock", &stockv2.ReduceStockReq{GoodsId: 1}, codes.OK},
+		{"order set stock", withRoles("order"), "/stock.v2.Stock/SetStock", &stockv2.SetStockReq{GoodsId: 1}, codes.PermissionDenied},
+		{"merchant own goods", withRoles("merchant"), "/stock.v2.Stock/SetStock", &stockv2.SetStockReq{GoodsId: 1001}, codes.OK},
+		{"merchant other goods", withRoles("merchant"), "/stock.v2.Stock/SetStock", &stockv2.SetStockReq{GoodsId: 2001}, codes.PermissionDenied},
+		{"reconcile", withRoles("reconciler"), "/proto.Stock/ReconcileStock", &proto.ReconcileStockReq{}, codes.OK},
+		{"repair needs repair permission", withRoles("reconciler"), "/proto.Stock/ReconcileStock", &proto.ReconcileStockReq{Repair: true}, codes.PermissionDenied},
+		{"admin repair", withRoles("admin"), "/proto.Stock/ReconcileStock", &proto.ReconcileStockReq{Repair: true}, codes.OK},
+		{"anonymous", withRoles(), "/stock.v2.Stock/GetStock", &stockv2.GetStockReq{GoodsId: 1}, codes.PermissionDenied},
+		{"health check is not authorized", withRoles(), "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/Check", &grpc_health_v1.HealthCheckRequest{}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(context.Context, any) (any, error) {
+				called = true
+				return nil, nil
+			}
+			_, err := UnaryAuthz(tt.ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %s, want %s (err %v)", got, tt.want, err)
+			}
+			if called != (tt.want == codes.OK) {
+				t.Errorf("handler called = %v, want %v", called, tt.want == codes.OK)
+			}
+		})
+	}
+}
+
+// testServerStream 只提供 ctx 的 ServerStream
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context    { return s.ctx }
+func (s testServerStream) SetHeader(metadata.MD) error { return nil }
+
+func TestStreamAuthz(t *testing.T) {
+	initPolicy(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"admin", withRoles("admin"), codes.OK},
+		// 建立流时还没有读取请求，限定商品的规则不能放行
+		{"goods scoped role", withRoles("merchant"), codes.PermissionDenied},
+		{"role without the method", withRoles("order"), codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := StreamAuthz(nil, testServerStream{ctx: tt.ctx}, &grpc.StreamServerInfo{FullMethod: "/proto.Stock/ExportStock"},
+				func(any, grpc.ServerStream) error { return nil })
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %s, want %s (err %v)", got, tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// Package interceptor gRPC 服务端拦截器，在 main.go 中按以下顺序串联：
+// 请求ID -> 访问日志 -> 监控指标 -> panic 恢复 -> 认证 -> 权限校验 -> 限流 -> 参数校验 -> 并发限制。
+// 请求ID 放在最前面，后面的拦截器和 handler 都能通过 logger.Ctx(ctx) 拿到带有请求ID的 logger；
+// panic 恢复放在访问日志和监控指标之后，handler panic 时记录的状态码是 Internal；
+// 认证放在限流之前，按调用方限流时使用认证通过的调用方；权限校验紧跟在认证之后；
+// 限流放在参数校验之前，被拒绝的请求不再做其他处理，但仍然记录访问日志和指标；
+// 并发限制放在最后，只统计真正执行 handler 的请求的延迟。
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serverStream 替换 ctx 的 ServerStream，流式接口的拦截器用它把新的 ctx 传给 handler
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,42 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"stock_service/errno"
+	"stock_service/loadshed"
+	"stock_service/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryLoadShed 自适应并发限制，超过上限的请求返回 ErrOverloaded（Unavailable，客户端可以换一个实例重试）。
+// 超时和依赖不可用的请求不计入延迟，而是让上限下降。健康检查不受限制；
+// 流式接口（导出）耗时与负载无关，也不受限制。
+func UnaryLoadShed(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	if strings.HasPrefix(info.FullMethod, healthPrefix) {
+		return handler(ctx, req)
+	}
+	release, ok := loadshed.Acquire()
+	if !ok {
+		metrics.LoadShed.WithLabelValues(info.FullMethod).Inc()
+		return nil, errno.ToStatus(errno.ErrOverloaded)
+	}
+
+	// handler panic 时也要归还名额（按失败计入），panic 继续向外传给 UnaryRecovery
+	start := time.Now()
+	dropped := true
+	defer func() { release(time.Since(start), dropped) }()
+
+	resp, err = handler(ctx, req)
+	switch status.Code(errno.ToStatus(err)) {
+	case codes.DeadlineExceeded, codes.Unavailable:
+	default:
+		dropped = false
+	}
+	return resp, err
+}
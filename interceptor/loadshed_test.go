@@ -0,0 +1,41 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"stock_service/config"
+	"stock_service/loadshed"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryLoadShedReleasesOnPanic(t *testing.T) {
+	loadshed.Init(&config.LoadShedConfig{Enable: true, InitialLimit: 2, MinLimit: 2, MaxLimit: 2})
+	t.Cleanup(func() { loadshed.Init(nil) })
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/stock.v2.Stock/ReduceStock"}
+	panicking := func(context.Context, any) (any, error) { panic("boom") }
+	// 与 main.go 中的顺序一致：UnaryRecovery 在 UnaryLoadShed 外层
+	call := func(h grpc.UnaryHandler) error {
+		_, err := UnaryRecovery(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return UnaryLoadShed(ctx, req, info, h)
+		})
+		return err
+	}
+
+	// panic 的次数超过并发上限，名额仍然全部归还
+	for i := 0; i < 5; i++ {
+		if err := call(panicking); status.Code(err) != codes.Internal {
+			t.Fatalf("call %d: code = %s, want Internal", i, status.Code(err))
+		}
+		if n := loadshed.Inflight(); n != 0 {
+			t.Fatalf("call %d: inflight = %d, want 0", i, n)
+		}
+	}
+	if err := call(func(context.Context, any) (any, error) { return "ok", nil }); err != nil {
+		t.Fatalf("request after panics: %v", err)
+	}
+}
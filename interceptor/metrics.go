@@ -0,0 +1,38 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"stock_service/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// observe 记录请求数和耗时，健康检查不计入
+func observe(method string, start time.Time, err error) {
+	if strings.HasPrefix(method, healthPrefix) {
+		return
+	}
+	code := status.Code(err).String()
+	metrics.GRPCRequests.WithLabelValues(method, code).Inc()
+	metrics.GRPCDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// UnaryMetrics 按方法和状态码统计请求数和耗时
+func UnaryMetrics(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamMetrics 流式接口的请求数和耗时，耗时为整个流的时长
+func StreamMetrics(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observe(info.FullMethod, start, err)
+	return err
+}
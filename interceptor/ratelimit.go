@@ -0,0 +1,91 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"stock_service/auth"
+	"stock_service/errno"
+	"stock_service/logger"
+	"stock_service/metrics"
+	"stock_service/ratelimit"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RetryAfterHeader 被限流时响应头中建议的重试间隔（秒），同时在错误详情中附带 RetryInfo
+const RetryAfterHeader = "retry-after"
+
+// goodsRequest 针对单个商品的请求
+type goodsRequest interface {
+	GetGoodsId() int64
+}
+
+// Caller 返回调用方：认证通过的调用方优先，否则为调用方的传输层地址（IP），
+// 调用方在 metadata 中声明的名字和 X-Forwarded-For 都可以伪造，不作为调用方。
+// 经过 HTTP 网关的请求，传输层地址是网关，取网关转发的客户端地址。
+func Caller(ctx context.Context) string {
+	if id := auth.FromContext(ctx); id != nil {
+		return id.Caller
+	}
+	if auth.FromGateway(ctx) {
+		if vals := metadata.ValueFromIncomingContext(ctx, auth.GatewayClientHeader); len(vals) > 0 && vals[0] != "" {
+			return vals[0]
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// rateLimit 按方法、调用方和商品限流，被拒绝时设置 retry-after 响应头并返回 ResourceExhausted
+func rateLimit(ctx context.Context, method string, req any, setHeader func(metadata.MD) error) error {
+	r := ratelimit.Request{Method: method, Caller: Caller(ctx)}
+	if g, ok := req.(goodsRequest); ok {
+		r.GoodsId = g.GetGoodsId()
+	}
+	rej := ratelimit.Allow(ctx, r)
+	if rej == nil {
+		return nil
+	}
+
+	metrics.RateLimited.WithLabelValues(method, rej.Key).Inc()
+	seconds := int64((rej.RetryAfter + time.Second - 1) / time.Second)
+	if err := setHeader(metadata.Pairs(RetryAfterHeader, strconv.FormatInt(seconds, 10))); err != nil {
+		logger.Ctx(ctx).Warn("set retry-after header failed", zap.Error(err))
+	}
+	return errno.ToStatus(errno.ErrRateLimited.
+		WithMeta("key", rej.Key).
+		WithMeta("caller", r.Caller).
+		WithMeta("retry_after_ms", rej.RetryAfter.Milliseconds()))
+}
+
+// UnaryRateLimit 按配置的令牌桶规则限流
+func UnaryRateLimit(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	err := rateLimit(ctx, info.FullMethod, req, func(md metadata.MD) error {
+		return grpc.SetHeader(ctx, md)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamRateLimit 流式接口在建立流时限流，只按方法和调用方分桶
+func StreamRateLimit(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := rateLimit(ss.Context(), info.FullMethod, nil, ss.SetHeader); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,40 @@
+package interceptor
+
+import (
+	"context"
+
+	"stock_service/errno"
+	"stock_service/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// recovered 记录 panic 和调用栈，返回 Internal，不暴露 panic 的内容
+func recovered(ctx context.Context, method string, r any) error {
+	logger.Ctx(ctx).Error("panic recovered",
+		zap.String("method", method),
+		zap.Any("panic", r),
+		zap.Stack("stack"))
+	return errno.ToStatus(errno.ErrInternal)
+}
+
+// UnaryRecovery 捕获 handler 中的 panic，避免整个进程退出
+func UnaryRecovery(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp, err = nil, recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// StreamRecovery 捕获流式 handler 中的 panic
+func StreamRecovery(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+
+	"stock_service/logger"
+	stockv2 "stock_service/proto/v2"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader 传递请求ID的 metadata，响应头中也会带上
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLen 请求ID的最大长度，超过时重新生成，避免日志被过长的值污染
+const maxRequestIDLen = 128
+
+type requestIDKey struct{}
+
+// RequestID 返回 ctx 中的请求ID，没有经过拦截器时返回空字符串
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// metaRequest v2 修改类请求都带有 RequestMeta
+type metaRequest interface {
+	GetMeta() *stockv2.RequestMeta
+}
+
+// requestID 取请求ID：v2 请求体中的 meta.request_id 优先，其次是 metadata 中的 x-request-id，都没有时生成
+func requestID(ctx context.Context, req any) string {
+	if m, ok := req.(metaRequest); ok {
+		if id := m.GetMeta().GetRequestId(); id != "" && len(id) <= maxRequestIDLen {
+			return id
+		}
+	}
+	if vals := metadata.ValueFromIncomingContext(ctx, RequestIDHeader); len(vals) > 0 {
+		if id := vals[0]; id != "" && len(id) <= maxRequestIDLen {
+			return id
+		}
+	}
+	return uuid.NewString()
+}
+
+// withRequestID 把请求ID和带有请求ID的 logger 放入 ctx，并写入响应头
+func withRequestID(ctx context.Context, id string) context.Context {
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return logger.WithContext(ctx, zap.L().With(zap.String("request_id", id)))
+}
+
+// UnaryRequestID 提取或生成请求ID
+func UnaryRequestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withRequestID(ctx, requestID(ctx, req)), req)
+}
+
+// StreamRequestID 提取或生成请求ID，流式接口在 handler 读取请求之前执行，只看 metadata
+func StreamRequestID(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withRequestID(ss.Context(), requestID(ss.Context(), nil))
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
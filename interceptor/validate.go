@@ -0,0 +1,99 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"stock_service/errno"
+
+	"google.golang.org/grpc"
+)
+
+// 参数校验：校验规则用 protoc-gen-validate 的 (validate.rules) 写在 proto 的字段上，
+// 由 protoc-gen-validate 生成 *.pb.validate.go（命令见 rpc.text），这里调用生成的 Validate 方法。
+// 修改规则后需要重新生成代码。跨字段的校验（例如下界不能大于上界）无法用规则表达，仍然写在 handler 中。
+
+// validator 生成的消息校验方法，返回第一个不满足规则的字段
+type validator interface {
+	Validate() error
+}
+
+// fieldError 生成的校验错误，每个消息有各自的错误类型，都实现了这些方法；
+// 嵌套消息或列表元素不满足规则时，Cause 为内层的错误
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// snakeCase 生成代码中的字段名是 Go 的驼峰形式，转换为 proto 的字段名，例如 GoodsId[0] -> goods_id[0]
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// validationError 参数错误，Meta 中的 field 为字段路径（例如 items[0].goods_id），返回给调用方时附带 BadRequest
+func validationError(err error) error {
+	var path []string
+	reason := err.Error()
+	for err != nil {
+		fe, ok := err.(fieldError)
+		if !ok {
+			break
+		}
+		path = append(path, snakeCase(fe.Field()))
+		reason = fe.Reason()
+		err = fe.Cause()
+	}
+	field := strings.Join(path, ".")
+	return errno.ErrInvalidParam.
+		WithMessage(field+": "+reason).
+		WithMeta("field", field)
+}
+
+// validateRequest 校验请求，没有生成校验方法的消息不校验
+func validateRequest(req any) error {
+	v, ok := req.(validator)
+	if !ok {
+		return nil
+	}
+	if err := v.Validate(); err != nil {
+		return errno.ToStatus(validationError(err))
+	}
+	return nil
+}
+
+// UnaryValidate 按 proto 中的规则校验请求，不满足时返回 InvalidArgument
+func UnaryValidate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// validatingStream 每次读取客户端发送的消息后校验
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateRequest(m)
+}
+
+// StreamValidate 校验流式接口中客户端发送的每一条消息
+func StreamValidate(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: ss})
+}
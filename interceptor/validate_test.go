@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"stock_service/errno"
+	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       any
+		wantField string // 为空表示校验通过
+	}{
+		{"valid v2 request", &stockv2.ReduceStockReq{GoodsId: 1001, Num: 1}, ""},
+		{"top-level field", &stockv2.ReduceStockReq{GoodsId: 1001, Num: 0}, "num"},
+		{"nested message", &stockv2.SetStockReq{Meta: &stockv2.RequestMeta{IdempotencyKey: string(make([]byte, 129))}, GoodsId: 1001}, "meta.idempotency_key"},
+		{"repeated item", &stockv2.CheckStockReq{Items: []*stockv2.CheckItem{{GoodsId: 1001, Num: 1}, {GoodsId: 0, Num: 1}}}, "items[1].goods_id"},
+		{"repeated size", &stockv2.CheckStockReq{}, "items"},
+		{"non-positive check num", &stockv2.CheckStockReq{Items: []*stockv2.CheckItem{{GoodsId: 1001, Num: -95}, {GoodsId: 1001, Num: 100}}}, "items[0].num"},
+		{"v1 request", &proto.GoodsStockInfo{GoodsId: 0}, "goods_id"},
+		{"message without rules", &grpc_health_v1.HealthCheckRequest{}, ""},
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/test"}
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+	for _, tt := range tests {
+		_, err := UnaryValidate(context.Background(), tt.req, info, handler)
+		if tt.wantField == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		st, _ := status.FromError(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("%s: code = %s, want InvalidArgument", tt.name, st.Code())
+			continue
+		}
+		if got := errno.FromStatus(st).Meta["field"]; got != tt.wantField {
+			t.Errorf("%s: field = %v, want %q", tt.name, got, tt.wantField)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"stock_service/biz/stock"
+	"stock_service/config"
+	"stock_service/dao/mysql"
+	"stock_service/dao/redis"
+	"stock_service/logger"
+
+	"github.com/go-redsync/redsync/v4"
+	"go.uber.org/zap"
+)
+
+// RunPurge 按配置的间隔定时清理过期数据，ctx 取消后退出
+func RunPurge(ctx context.Context, cfg *config.PurgeConfig) {
+	if cfg == nil || cfg.Interval <= 0 {
+		return
+	}
+	logger.Ctx(ctx).Info("purge job start", zap.Duration("interval", cfg.Interval))
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purgeOnce(ctx, cfg)
+		}
+	}
+}
+
+// purgeOnce 执行一轮清理
+func purgeOnce(ctx context.Context, cfg *config.PurgeConfig) {
+	mutex := redis.Rs.NewMutex("xx-stock-purge-job",
+		redsync.WithTries(1),
+		redsync.WithExpiry(cfg.Interval/2),
+	)
+	if err := mutex.TryLockContext(ctx); err != nil {
+		return
+	}
+
+	if cfg.StockRetention > 0 {
+		n, err := stock.PurgeDeletedStock(ctx, cfg.StockRetention)
+		if err != nil {
+			logger.Ctx(ctx).Error("purge deleted stock failed", zap.Error(err))
+		} else {
+			logger.Ctx(ctx).Info("purge deleted stock done", zap.Int64("rows", n))
+		}
+	}
+
+	if cfg.OutboxRetention > 0 {
+		n, err := mysql.PurgeSentEvents(ctx, time.Now().Add(-cfg.OutboxRetention))
+		if err != nil {
+			logger.Ctx(ctx).Error("purge sent events failed", zap.Error(err))
+		} else {
+			logger.Ctx(ctx).Info("purge sent events done", zap.Int64("rows", n))
+		}
+	}
+
+	if cfg.MessageRetention > 0 {
+		n, err := mysql.PurgeConsumedMessages(ctx, time.Now().Add(-cfg.MessageRetention))
+		if err != nil {
+			logger.Ctx(ctx).Error("purge consumed messages failed", zap.Error(err))
+		} else {
+			logger.Ctx(ctx).Info("purge consumed messages done", zap.Int64("rows", n))
+		}
+	}
+}
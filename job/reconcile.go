@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"stock_service/biz/stock"
+	"stock_service/config"
+	"stock_service/dao/redis"
+	"stock_service/logger"
+
+	"github.com/go-redsync/redsync/v4"
+	"go.uber.org/zap"
+)
+
+// 定时任务
+// 多实例部署时，每一轮通过分布式锁保证只有一个实例真正执行
+
+// RunReconcile 按配置的间隔定时执行库存对账，ctx 取消后退出
+func RunReconcile(ctx context.Context, cfg *config.ReconcileConfig) {
+	if cfg == nil || cfg.Interval <= 0 {
+		return
+	}
+	logger.Ctx(ctx).Info("reconcile job start", zap.Duration("interval", cfg.Interval), zap.Bool("repair", cfg.Repair))
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reconcileOnce(ctx, cfg)
+		}
+	}
+}
+
+// reconcileOnce 执行一轮库存对账
+func reconcileOnce(ctx context.Context, cfg *config.ReconcileConfig) {
+	// 锁不主动释放，过期前其他实例的本轮任务都会跳过
+	mutex := redis.Rs.NewMutex("xx-stock-reconcile-job",
+		redsync.WithTries(1),
+		redsync.WithExpiry(cfg.Interval/2),
+	)
+	if err := mutex.TryLockContext(ctx); err != nil {
+		return
+	}
+
+	list, err := stock.ReconcileStock(ctx, nil, cfg.Repair, "reconcile-job")
+	if err != nil {
+		logger.Ctx(ctx).Error("reconcile job failed", zap.Error(err))
+		return
+	}
+	for _, m := range list {
+		logger.Ctx(ctx).Warn("预扣库存不一致",
+			zap.Int64("goods_id", m.GoodsId),
+			zap.Int64("lock", m.Lock),
+			zap.Int64("expected_lock", m.ExpectedLock),
+			zap.Bool("repaired", m.Repaired))
+	}
+	logger.Ctx(ctx).Info("reconcile job done", zap.Int("mismatches", len(list)))
+}
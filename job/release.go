@@ -0,0 +1,88 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"stock_service/biz/stock"
+	"stock_service/config"
+	"stock_service/dao/redis"
+	"stock_service/logger"
+
+	"go.uber.org/zap"
+)
+
+// RunReleaseWorkers 启动超时释放库存的协程池：
+// 一个协程定时从延迟队列领取到期任务，cfg.Workers 个协程并发释放库存。
+// ctx 取消后不再分发新的任务，等待处理中的任务完成再返回。
+func RunReleaseWorkers(ctx context.Context, cfg *config.ReleaseConfig) {
+	if cfg == nil || !cfg.Enable {
+		return
+	}
+	workers, batch, interval, lease := cfg.Workers, cfg.BatchSize, cfg.PollInterval, cfg.Lease
+	if workers <= 0 {
+		workers = 1
+	}
+	if batch <= 0 {
+		batch = 100
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if lease <= 0 {
+		lease = time.Minute
+	}
+	logger.Ctx(ctx).Info("release workers start", zap.Int("workers", workers), zap.Duration("timeout", cfg.Timeout))
+
+	jobs := make(chan redis.ReleaseJob, batch)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				release(j)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(jobs)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		list, err := redis.ClaimReleaseJobs(ctx, batch, lease)
+		if err != nil {
+			logger.Ctx(ctx).Error("ClaimReleaseJobs failed", zap.Error(err))
+			continue
+		}
+		for _, j := range list {
+			// 工作协程都在忙时不能阻塞退出，没分发的任务在租约结束后会被重新领取
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// release 释放一个到期任务，失败的任务在租约结束后会被重新领取
+func release(j redis.ReleaseJob) {
+	// 不使用 RunReleaseWorkers 的 ctx，避免退出时中断进行中的事务；
+	// 日志都带上任务信息，数据层的日志也能对应到任务
+	ctx := logger.WithContext(context.Background(), zap.L().With(zap.Any("job", j)))
+	err := stock.ReleaseExpired(ctx, j)
+	if err != nil {
+		logger.Ctx(ctx).Error("release expired stock failed", zap.Error(err))
+		return
+	}
+	logger.Ctx(ctx).Info("订单超时，库存已释放")
+}
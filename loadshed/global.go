@@ -0,0 +1,58 @@
+package loadshed
+
+import (
+	"time"
+
+	"stock_service/config"
+	"stock_service/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// std 服务端使用的并发限制，为 nil 时不限制
+var std *Limiter
+
+// Init 根据配置创建服务端的并发限制，cfg 为 nil 或未启用时不限制
+func Init(cfg *config.LoadShedConfig) {
+	if cfg == nil || !cfg.Enable {
+		std = nil
+		return
+	}
+	std = New(cfg)
+}
+
+// Acquire 获取一个并发名额，未启用时总是成功，见 Limiter.Acquire
+func Acquire() (release func(rtt time.Duration, dropped bool), ok bool) {
+	if std == nil {
+		return func(time.Duration, bool) {}, true
+	}
+	return std.Acquire()
+}
+
+// Inflight 返回处理中的请求数，未启用时为 0
+func Inflight() int {
+	if std == nil {
+		return 0
+	}
+	return std.Inflight()
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "stock_loadshed_limit",
+			Help: "Current adaptive concurrency limit (0 when disabled).",
+		}, func() float64 {
+			if std == nil {
+				return 0
+			}
+			return float64(std.Limit())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "stock_loadshed_inflight",
+			Help: "Number of in-flight requests counted by the concurrency limit.",
+		}, func() float64 {
+			return float64(Inflight())
+		}),
+	)
+}
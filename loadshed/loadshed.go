@@ -0,0 +1,169 @@
+// Package loadshed 自适应并发限制：按处理中的请求数和延迟动态调整服务端的并发上限，
+// 超过上限的请求直接返回 ErrOverloaded，避免 MySQL 变慢时请求全部堆在分布式锁和连接池上一起超时。
+//
+// 调整方法参考 Netflix concurrency-limits 的 Gradient2：以长期的平均延迟为基线，
+// 每个窗口的平均延迟超过基线的 tolerance 倍时按比例降低上限，否则逐步提高；
+// 窗口内有请求超时或依赖不可用时另外降低 10%。
+package loadshed
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"stock_service/config"
+)
+
+// 默认值
+const (
+	defaultInitialLimit = 100
+	defaultMinLimit     = 10
+	defaultMaxLimit     = 1000
+	defaultTolerance    = 1.5
+	defaultWindow       = time.Second
+)
+
+const (
+	longWindow   = 100  // 长期基线的窗口数
+	smoothing    = 0.2  // 每次调整向新上限靠近的比例
+	dropBackoff  = 0.9  // 有请求超时时上限乘以该系数
+	minSamples   = 10   // 窗口内的请求数少于该值时不调整，合并到下一个窗口
+	driftRecover = 0.95 // 基线远高于当前延迟时的回落系数
+)
+
+// Limiter 自适应并发上限
+type Limiter struct {
+	mu sync.Mutex
+
+	limit     float64
+	inflight  int
+	minLimit  float64
+	maxLimit  float64
+	tolerance float64
+	window    time.Duration
+
+	// 当前窗口的统计
+	windowStart time.Time
+	rttSum      time.Duration
+	samples     int
+	maxInflight int
+	dropped     bool
+
+	longRTT float64 // 长期平均延迟（秒）
+	longN   int
+}
+
+// New 创建并发限制，零值字段使用默认值
+func New(cfg *config.LoadShedConfig) *Limiter {
+	l := &Limiter{
+		limit:       defaultInitialLimit,
+		minLimit:    defaultMinLimit,
+		maxLimit:    defaultMaxLimit,
+		tolerance:   defaultTolerance,
+		window:      defaultWindow,
+		windowStart: time.Now(),
+	}
+	if cfg.InitialLimit > 0 {
+		l.limit = float64(cfg.InitialLimit)
+	}
+	if cfg.MinLimit > 0 {
+		l.minLimit = float64(cfg.MinLimit)
+	}
+	if cfg.MaxLimit > 0 {
+		l.maxLimit = float64(cfg.MaxLimit)
+	}
+	if cfg.Tolerance > 1 {
+		l.tolerance = cfg.Tolerance
+	}
+	if cfg.Window > 0 {
+		l.window = cfg.Window
+	}
+	l.limit = clamp(l.limit, l.minLimit, l.maxLimit)
+	return l
+}
+
+// Acquire 获取一个并发名额，达到上限时返回 false。
+// 拿到名额后必须调用 release，传入请求的耗时和是否超时（或依赖不可用）。
+func (l *Limiter) Acquire() (release func(rtt time.Duration, dropped bool), ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if float64(l.inflight) >= math.Floor(l.limit) {
+		return nil, false
+	}
+	l.inflight++
+	l.maxInflight = max(l.maxInflight, l.inflight)
+	return l.release, true
+}
+
+// Limit 返回当前的并发上限
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Inflight 返回处理中的请求数
+func (l *Limiter) Inflight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inflight
+}
+
+func (l *Limiter) release(rtt time.Duration, dropped bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+	if dropped {
+		l.dropped = true
+	} else {
+		l.rttSum += rtt
+		l.samples++
+	}
+
+	now := time.Now()
+	if now.Sub(l.windowStart) < l.window || (l.samples < minSamples && !l.dropped) {
+		return
+	}
+	l.update()
+	l.windowStart = now
+	l.rttSum, l.samples, l.dropped = 0, 0, false
+	l.maxInflight = l.inflight
+}
+
+// update 窗口结束时调整上限，调用方持有锁
+func (l *Limiter) update() {
+	if l.dropped {
+		l.limit = clamp(l.limit*dropBackoff, l.minLimit, l.maxLimit)
+		return
+	}
+	shortRTT := (l.rttSum / time.Duration(l.samples)).Seconds()
+	if shortRTT <= 0 {
+		return
+	}
+
+	// 长期基线：前 longWindow 个窗口为算术平均，之后为指数平均
+	if l.longN < longWindow {
+		l.longN++
+		l.longRTT += (shortRTT - l.longRTT) / float64(l.longN)
+	} else {
+		l.longRTT += (shortRTT - l.longRTT) / longWindow
+	}
+	// 负载下降后基线远高于当前延迟，让基线快速回落，否则上限会一直偏高
+	if l.longRTT/shortRTT > 2 {
+		l.longRTT *= driftRecover
+	}
+
+	// 并发没有用到上限的一半时，延迟不能说明上限是否合适，不调整
+	if float64(l.maxInflight) < l.limit/2 {
+		return
+	}
+
+	gradient := clamp(l.tolerance*l.longRTT/shortRTT, 0.5, 1)
+	// 延迟正常时 gradient 为 1，上限按 sqrt(limit) 增长
+	newLimit := l.limit*gradient + math.Sqrt(l.limit)
+	l.limit = clamp(l.limit*(1-smoothing)+newLimit*smoothing, l.minLimit, l.maxLimit)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(math.Max(v, lo), hi)
+}
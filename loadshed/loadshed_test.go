@@ -0,0 +1,123 @@
+package loadshed
+
+import (
+	"testing"
+	"time"
+
+	"stock_service/config"
+)
+
+// newTestLimiter 窗口很长，只在 endWindow 后结束，调整的时机是确定的
+func newTestLimiter() *Limiter {
+	return New(&config.LoadShedConfig{InitialLimit: 20, MinLimit: 10, MaxLimit: 100, Window: time.Hour})
+}
+
+// endWindow 让当前窗口在下一次 release 时结束
+func endWindow(l *Limiter) {
+	l.mu.Lock()
+	l.windowStart = time.Now().Add(-2 * l.window)
+	l.mu.Unlock()
+}
+
+// saturate 用满并发上限，所有请求的耗时都是 rtt，最后一个请求结束时窗口结束，返回调整后的上限
+func saturate(t *testing.T, l *Limiter, rtt time.Duration) int {
+	t.Helper()
+	var releases []func(time.Duration, bool)
+	for {
+		release, ok := l.Acquire()
+		if !ok {
+			break
+		}
+		releases = append(releases, release)
+	}
+	if len(releases) != l.Limit() {
+		t.Fatalf("acquired %d slots, limit is %d", len(releases), l.Limit())
+	}
+	for i, release := range releases {
+		if i == len(releases)-1 {
+			endWindow(l)
+		}
+		release(rtt, false)
+	}
+	return l.Limit()
+}
+
+func TestLimiterRejectsOverLimit(t *testing.T) {
+	l := newTestLimiter()
+	var releases []func(time.Duration, bool)
+	for i := 0; i < 20; i++ {
+		release, ok := l.Acquire()
+		if !ok {
+			t.Fatalf("request %d rejected under the limit", i)
+		}
+		releases = append(releases, release)
+	}
+	if _, ok := l.Acquire(); ok {
+		t.Fatal("request over the limit accepted")
+	}
+	releases[0](time.Millisecond, false)
+	if _, ok := l.Acquire(); !ok {
+		t.Fatal("request rejected after a slot was released")
+	}
+	if l.Inflight() != 20 {
+		t.Errorf("inflight = %d, want 20", l.Inflight())
+	}
+}
+
+func TestLimiterWindowUpdate(t *testing.T) {
+	l := newTestLimiter()
+
+	// 延迟稳定时逐步提高上限
+	limit := l.Limit()
+	for i := 0; i < 5; i++ {
+		limit = saturate(t, l, 10*time.Millisecond)
+	}
+	if limit <= 20 {
+		t.Fatalf("limit = %d after stable windows, want above 20", limit)
+	}
+
+	// 延迟远高于基线时降低上限
+	if got := saturate(t, l, 100*time.Millisecond); got >= limit {
+		t.Fatalf("limit = %d after a latency spike, want below %d", got, limit)
+	}
+
+	// 窗口内有请求超时时上限乘以 0.9，不需要凑够样本数
+	limit = l.Limit()
+	release, _ := l.Acquire()
+	endWindow(l)
+	release(time.Second, true)
+	if got, want := l.Limit(), int(float64(limit)*dropBackoff); got != want && got != want+1 {
+		t.Fatalf("limit = %d after a dropped request, want about %d", got, want)
+	}
+
+	// 上限不低于 MinLimit
+	for i := 0; i < 50; i++ {
+		release, _ := l.Acquire()
+		endWindow(l)
+		release(time.Second, true)
+	}
+	if l.Limit() != 10 {
+		t.Fatalf("limit = %d, want the minimum 10", l.Limit())
+	}
+}
+
+func TestLimiterIgnoresSmallWindows(t *testing.T) {
+	l := newTestLimiter()
+
+	// 样本数不足时不调整
+	release, _ := l.Acquire()
+	endWindow(l)
+	release(time.Millisecond, false)
+	if l.Limit() != 20 {
+		t.Fatalf("limit = %d after one sample, want 20", l.Limit())
+	}
+
+	// 并发不到上限的一半时，延迟再高也不调整
+	for i := 0; i < minSamples; i++ {
+		release, _ := l.Acquire()
+		release(time.Second, false)
+	}
+	if l.Limit() != 20 {
+		t.Fatalf("limit = %d with low concurrency, want 20", l.Limit())
+	}
+}
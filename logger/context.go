@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// WithContext 返回携带 l 的 ctx，拦截器用它把带有请求ID的 logger 传给 handler
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// Ctx 返回 ctx 中的 logger，没有时返回全局 logger。
+// ctx 中有链路追踪的 span 时，日志带上 trace_id 和 span_id，便于从日志跳转到链路。
+func Ctx(ctx context.Context) *zap.Logger {
+	l, ok := ctx.Value(ctxKey{}).(*zap.Logger)
+	if !ok {
+		l = zap.L()
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		l = l.With(zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+	}
+	return l
+}
@@ -1,23 +1,44 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
+	"stock_service/auth"
+	"stock_service/breaker"
 	"stock_service/config"
 	"stock_service/dao/mysql"
 	"stock_service/dao/redis"
+	"stock_service/gateway"
 	"stock_service/handler"
+	"stock_service/interceptor"
+	"stock_service/job"
+	"stock_service/loadshed"
 	"stock_service/logger"
+	"stock_service/metrics"
+	"stock_service/outbox"
 	"stock_service/proto"
+	"stock_service/ratelimit"
+	"stock_service/rbac"
+	stockv2 "stock_service/proto/v2"
 	"stock_service/registry"
+	"stock_service/tlsconf"
+	"stock_service/tracing"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
@@ -41,17 +62,61 @@ func main() {
 		panic(err) // 如果初始化日志模块失败，直接退出程序
 	}
 
+	// 初始化链路追踪
+	shutdownTracing, err := tracing.Init(config.Conf.TracingConfig, config.Conf.Name, config.Conf.Version)
+	if err != nil {
+		panic(err) // 如果初始化链路追踪失败，直接退出程序
+	}
+
+	// 初始化监控指标，需要在 MySQL 和 Redis 之前，连接池指标在初始化连接时注册
+	metrics.Init(config.Conf.MetricsConfig)
+
+	// 初始化熔断和并发限制，熔断器在初始化 MySQL 和 Redis 时创建
+	breaker.Init(config.Conf.BreakerConfig)
+	loadshed.Init(config.Conf.LoadShedConfig)
+
 	// 3. 初始化 MySQL 数据库连接
 	err = mysql.Init(config.Conf.MySQLConfig)
 	if err != nil {
 		panic(err) // 如果初始化 MySQL 数据库失败，直接退出程序
 	}
+	// 最先注册、最后执行：退出时 MQ 消费者、定时任务都停止之后再关闭连接池
+	defer func() {
+		if err := mysql.Close(); err != nil {
+			zap.L().Error("Failed to close mysql", zap.Error(err))
+		}
+	}()
 
 	// 初始化 Redis 连接
 	err = redis.Init(config.Conf.RedisConfig)
 	if err != nil {
 		panic(err) // 如果初始化 Redis 失败，直接退出程序
 	}
+	defer func() {
+		if err := redis.Close(); err != nil {
+			zap.L().Error("Failed to close redis", zap.Error(err))
+		}
+	}()
+
+	// 初始化限流规则（依赖 Redis），配置文件修改后重新加载
+	err = ratelimit.Init(config.Conf.RateLimitConfig)
+	if err != nil {
+		panic(err) // 如果限流配置有误，直接退出程序
+	}
+	config.OnChange(reloadRateLimit)
+
+	// 初始化调用方认证，配置文件修改后重新加载
+	err = auth.Init(config.Conf.AuthConfig)
+	if err != nil {
+		panic(err) // 如果认证配置有误，直接退出程序
+	}
+	config.OnChange(reloadAuth)
+
+	// 初始化权限校验，策略文件修改后自动重新加载
+	err = rbac.Init(config.Conf.RBACConfig)
+	if err != nil {
+		panic(err) // 如果策略文件有误，直接退出程序
+	}
 
 	err = registry.Init(config.Conf.ConsulConfig.Addr)
 	if err != nil {
@@ -66,12 +131,56 @@ func main() {
 		panic(err)
 	}
 
+	// gRPC 服务的选项，拦截器的顺序见 interceptor 包的说明
+	// 链路追踪用 stats handler 实现，在所有拦截器之前从 metadata 中提取上游的链路，健康检查不创建 span
+	srvOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryRequestID,
+			interceptor.UnaryAccessLog,
+			interceptor.UnaryMetrics,
+			interceptor.UnaryRecovery,
+			interceptor.UnaryAuth,
+			interceptor.UnaryAuthz,
+			interceptor.UnaryRateLimit,
+			interceptor.UnaryValidate,
+			interceptor.UnaryLoadShed,
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamRequestID,
+			interceptor.StreamAccessLog,
+			interceptor.StreamMetrics,
+			interceptor.StreamRecovery,
+			interceptor.StreamAuth,
+			interceptor.StreamAuthz,
+			interceptor.StreamRateLimit,
+			interceptor.StreamValidate,
+		),
+	}
+
+	// 加载 TLS 证书，未启用时使用明文连接；证书文件更新后在定时任务中重新加载
+	var certs *tlsconf.Reloader
+	var gatewayCreds credentials.TransportCredentials
+	var tags []string
+	if cfg := config.Conf.TLSConfig; cfg != nil && cfg.Enable {
+		certs, err = tlsconf.New(cfg)
+		if err != nil {
+			panic(err) // 如果证书无效，直接退出程序
+		}
+		srvOpts = append(srvOpts, grpc.Creds(certs.ServerCredentials()))
+		gatewayCreds = certs.GatewayCredentials()
+		tags = append(tags, registry.TagTLS)
+	}
+
 	// 创建 gRPC 服务
-	s := grpc.NewServer()
-	// 注册健康检查服务
-	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+	s := grpc.NewServer(srvOpts...)
+	// 注册健康检查服务，退出时先改为 NOT_SERVING，开启健康检查的客户端不再选择这个实例
+	healthSrv := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, healthSrv)
 	// 注册股票服务到 gRPC 服务
 	proto.RegisterStockServer(s, &handler.StockSrv{})
+	// 注册 v2 库存服务
+	stockv2.RegisterStockServer(s, &handler.StockV2Srv{})
 
 	// 启动 gRPC 服务
 	go func() {
@@ -82,7 +191,7 @@ func main() {
 	}()
 
 	// 注册服务到 Consul
-	err = registry.Reg.RegisterService(config.Conf.Name, config.Conf.IP, config.Conf.RpcPort, nil)
+	err = registry.Reg.RegisterService(config.Conf.Name, config.Conf.IP, config.Conf.RpcPort, tags)
 	if err != nil {
 		zap.L().Error("Failed to register service to Consul", zap.Error(err))
 		// 可以选择退出或继续运行，取决于业务需求
@@ -96,12 +205,179 @@ func main() {
 		zap.Int("port", config.Conf.RpcPort),
 	)
 
+	// 启动 MQ 消费者，监听订单取消消息回滚库存
+	if cfg := config.Conf.MQConfig; cfg != nil && cfg.Type != "" {
+		stopConsumer, err := handler.StartConsumer(cfg)
+		if err != nil {
+			zap.L().Error("Failed to start mq consumer", zap.Error(err))
+			panic(err)
+		}
+		defer stopConsumer()
+	}
+
+	// 启动定时任务，退出时等待任务返回（超时释放会等处理中的任务完成）
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var jobs sync.WaitGroup
+	for _, run := range []func(){
+		func() { job.RunReconcile(ctx, config.Conf.ReconcileConfig) },
+		func() { job.RunPurge(ctx, config.Conf.PurgeConfig) },
+		func() { job.RunReleaseWorkers(ctx, config.Conf.ReleaseConfig) },
+	} {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			run()
+		}()
+	}
+	if certs != nil {
+		go certs.Run(ctx)
+	}
+
+	// 启动 gRPC-Gateway HTTP 服务
+	var httpSrv *http.Server
+	if config.Conf.HttpPort > 0 {
+		gw, err := gateway.NewHandler(ctx, fmt.Sprintf("127.0.0.1:%d", config.Conf.RpcPort), gatewayCreds, config.Conf.GatewayConfig)
+		if err != nil {
+			zap.L().Error("Failed to create gRPC-Gateway", zap.Error(err))
+			panic(err)
+		}
+		// 没有单独配置监控端口时，/metrics 挂在网关的 HTTP 服务上
+		handler := gw
+		if cfg := config.Conf.MetricsConfig; cfg == nil || cfg.Port == 0 {
+			mux := http.NewServeMux()
+			mux.Handle(metrics.Path(cfg), metrics.Handler())
+			mux.Handle("/", gw)
+			handler = mux
+		}
+		httpSrv = &http.Server{
+			Addr:    fmt.Sprintf(":%d", config.Conf.HttpPort),
+			Handler: handler,
+		}
+		// 开启 TLS 时网关也使用 HTTPS，配置了客户端 CA 时同样要求客户端证书
+		if certs != nil {
+			httpSrv.TLSConfig = certs.HTTPConfig()
+		}
+		go func() {
+			var err error
+			if httpSrv.TLSConfig != nil {
+				err = httpSrv.ListenAndServeTLS("", "")
+			} else {
+				err = httpSrv.ListenAndServe()
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				panic(err)
+			}
+		}()
+		zap.L().Info(
+			"gRPC-Gateway HTTP Server start",
+			zap.String("ip", config.Conf.IP),
+			zap.Int("port", config.Conf.HttpPort),
+			zap.Bool("tls", httpSrv.TLSConfig != nil),
+		)
+	}
+
+	// 启动单独的监控指标 HTTP 服务
+	var metricsSrv *http.Server
+	if cfg := config.Conf.MetricsConfig; cfg != nil && cfg.Port > 0 {
+		mux := http.NewServeMux()
+		mux.Handle(metrics.Path(cfg), metrics.Handler())
+		metricsSrv = &http.Server{
+			Addr:    fmt.Sprintf(":%d", cfg.Port),
+			Handler: mux,
+		}
+		go func() {
+			err := metricsSrv.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				panic(err)
+			}
+		}()
+		zap.L().Info("metrics server start", zap.Int("port", cfg.Port), zap.String("path", metrics.Path(cfg)))
+	}
+
+	// 启动库存变更事件投递
+	if cfg := config.Conf.OutboxConfig; cfg != nil && cfg.Publisher != "" {
+		publisher, err := outbox.NewPublisher(cfg, config.Conf.MQConfig)
+		if err != nil {
+			zap.L().Error("Failed to create outbox publisher", zap.Error(err))
+			panic(err)
+		}
+		// 与定时任务一起等待退出，投递结束后再关闭发布者（MQ 生产者）和连接池
+		relay := outbox.NewRelay(cfg, publisher)
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			relay.Run(ctx)
+			if err := outbox.Close(publisher); err != nil {
+				zap.L().Error("Failed to close outbox publisher", zap.Error(err))
+			}
+		}()
+	}
+
 	// 服务退出时注销服务
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit // 等待退出信号
 
 	// 注销服务
 	serviceId := fmt.Sprintf("%s-%s-%d", config.Conf.Name, config.Conf.IP, config.Conf.RpcPort)
 	registry.Reg.Deregister(serviceId)
+	healthSrv.Shutdown()
+
+	// 先停止 HTTP 服务，等待处理中的请求完成（网关转发依赖 gRPC 服务和 ctx）
+	if httpSrv != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+			zap.L().Error("Failed to shutdown gRPC-Gateway", zap.Error(err))
+		}
+	}
+
+	if metricsSrv != nil {
+		if err := metricsSrv.Close(); err != nil {
+			zap.L().Error("Failed to close metrics server", zap.Error(err))
+		}
+	}
+
+	// 停止定时任务，等待处理中的任务完成
+	cancel()
+	jobs.Wait()
+
+	// 停止 gRPC 服务
+	s.GracefulStop()
+
+	// 上报剩余的 span
+	tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tracingCancel()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		zap.L().Error("Failed to shutdown tracing", zap.Error(err))
+	}
+}
+
+// reloadRateLimit 重新读取限流配置，配置有误时保留原来的规则
+func reloadRateLimit() {
+	var cfg *config.RateLimitConfig
+	if err := config.UnmarshalKey("ratelimit", &cfg); err != nil {
+		zap.L().Error("Failed to read ratelimit config", zap.Error(err))
+		return
+	}
+	if err := ratelimit.Init(cfg); err != nil {
+		zap.L().Error("Failed to reload ratelimit config", zap.Error(err))
+		return
+	}
+	zap.L().Info("ratelimit config reloaded")
+}
+
+// reloadAuth 重新读取认证配置，配置有误时保留原来的配置
+func reloadAuth() {
+	var cfg *config.AuthConfig
+	if err := config.UnmarshalKey("auth", &cfg); err != nil {
+		zap.L().Error("Failed to read auth config", zap.Error(err))
+		return
+	}
+	if err := auth.Init(cfg); err != nil {
+		zap.L().Error("Failed to reload auth config", zap.Error(err))
+		return
+	}
+	zap.L().Info("auth config reloaded")
 }
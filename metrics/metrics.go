@@ -0,0 +1,125 @@
+// Package metrics Prometheus 监控指标：gRPC 请求、分布式锁、数据库、Redis 连接池和库存业务事件。
+// 指标注册在单独的 Registry 中，通过 Handler 暴露给 Prometheus 抓取。
+package metrics
+
+import (
+	"net/http"
+
+	"stock_service/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace 指标名的前缀
+const namespace = "stock"
+
+// Registry 服务的指标注册表，dao 层的连接池等指标在初始化时注册到这里
+var Registry = prometheus.NewRegistry()
+
+var (
+	// GRPCRequests gRPC 请求数，按方法和状态码统计
+	GRPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Total number of gRPC requests by method and code.",
+	}, []string{"method", "code"})
+
+	// GRPCDuration gRPC 请求耗时，流式接口为整个流的时长
+	GRPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC request latency by method and code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// LockWait 获取分布式锁的等待时间，result 为 acquired 或 failed
+	LockWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "lock",
+		Name:      "wait_seconds",
+		Help:      "Time spent waiting for the redsync stock lock.",
+		Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"result"})
+
+	// LockFailures 获取分布式锁失败的次数
+	LockFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "lock",
+		Name:      "failures_total",
+		Help:      "Total number of failed redsync stock lock acquisitions.",
+	})
+
+	// RateLimited 被限流拒绝的请求数，key 为规则的分桶方式
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ratelimit",
+		Name:      "rejected_total",
+		Help:      "Total number of requests rejected by rate limit rules by method and rule key.",
+	}, []string{"method", "key"})
+
+	// AuthzDenied 没有权限被拒绝的请求数
+	AuthzDenied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "authz",
+		Name:      "denied_total",
+		Help:      "Total number of requests denied by the role-based access policy by method.",
+	}, []string{"method"})
+
+	// LoadShed 超过并发上限被丢弃的请求数
+	LoadShed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "loadshed",
+		Name:      "rejected_total",
+		Help:      "Total number of requests rejected by the adaptive concurrency limit by method.",
+	}, []string{"method"})
+
+	// DBQueryDuration GORM 执行 SQL 的耗时，按操作类型和表名统计
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "GORM query latency by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		GRPCRequests,
+		GRPCDuration,
+		LockWait,
+		LockFailures,
+		RateLimited,
+		AuthzDenied,
+		LoadShed,
+		DBQueryDuration,
+		unitsTotal,
+		understockTotal,
+	)
+}
+
+// Init 根据配置初始化按商品统计的指标，cfg 为 nil 时使用默认值
+func Init(cfg *config.MetricsConfig) {
+	if cfg != nil && cfg.MaxGoods > 0 {
+		goods.max = cfg.MaxGoods
+	}
+}
+
+// Handler 返回 /metrics 的 HTTP 处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Path 返回指标的路径，未配置时为 /metrics
+func Path(cfg *config.MetricsConfig) string {
+	if cfg == nil || cfg.Path == "" {
+		return "/metrics"
+	}
+	return cfg.Path
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 库存业务指标，按商品统计。
+// 商品数量没有上限，为了控制指标的基数，只记录最先出现的 max 个商品，其余计入 other。
+
+// 库存变动事件，作为 stock_units_total 的 event 标签
+const (
+	EventReserved   = "reserved"    // 预扣
+	EventConfirmed  = "confirmed"   // 确认扣减
+	EventRolledBack = "rolled_back" // 回滚
+	EventReleased   = "released"    // 超时自动释放
+)
+
+// otherGoods 超过上限的商品使用的标签值
+const otherGoods = "other"
+
+var (
+	unitsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "units_total",
+		Help:      "Total stock units by event and goods (goods_id is capped, overflow is reported as other).",
+	}, []string{"event", "goods_id"})
+
+	understockTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "understock_total",
+		Help:      "Total number of reductions rejected for insufficient stock by goods (goods_id is capped).",
+	}, []string{"goods_id"})
+)
+
+// goods 已记录的商品，默认最多 1000 个
+var goods = &goodsLabels{max: 1000, seen: make(map[int64]string)}
+
+type goodsLabels struct {
+	mu   sync.RWMutex
+	max  int
+	seen map[int64]string
+}
+
+// label 返回商品的标签值，已记录的商品数达到上限后新的商品返回 other
+func (g *goodsLabels) label(goodsId int64) string {
+	g.mu.RLock()
+	l, ok := g.seen[goodsId]
+	g.mu.RUnlock()
+	if ok {
+		return l
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if l, ok := g.seen[goodsId]; ok {
+		return l
+	}
+	if len(g.seen) >= g.max {
+		return otherGoods
+	}
+	l = strconv.FormatInt(goodsId, 10)
+	g.seen[goodsId] = l
+	return l
+}
+
+// AddUnits 记录商品的库存变动数量，event 为 Event 开头的常量
+func AddUnits(event string, goodsId, num int64) {
+	if num <= 0 {
+		return
+	}
+	unitsTotal.WithLabelValues(event, goods.label(goodsId)).Add(float64(num))
+}
+
+// IncUnderstock 记录一次库存不足的扣减
+func IncUnderstock(goodsId int64) {
+	understockTotal.WithLabelValues(goods.label(goodsId)).Inc()
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import "testing"
+
+func TestGoodsLabelsCap(t *testing.T) {
+	g := &goodsLabels{max: 2, seen: make(map[int64]string)}
+
+	tests := []struct {
+		name    string
+		goodsId int64
+		want    string
+	}{
+		{"first goods", 1001, "1001"},
+		{"second goods reaches the cap", 1002, "1002"},
+		{"new goods at the cap", 1003, otherGoods},
+		{"known goods keeps its label", 1001, "1001"},
+		{"known goods keeps its label after overflow", 1002, "1002"},
+		{"overflowed goods stays other", 1003, otherGoods},
+		{"another new goods", 1004, otherGoods},
+	}
+	for _, tt := range tests {
+		if got := g.label(tt.goodsId); got != tt.want {
+			t.Errorf("%s: label(%d) = %q, want %q", tt.name, tt.goodsId, got, tt.want)
+		}
+	}
+	if len(g.seen) != g.max {
+		t.Errorf("seen %d goods, want %d", len(g.seen), g.max)
+	}
+}
@@ -11,5 +11,5 @@ type BaseModel struct {
 	CreateBy string
 	UpdateBy string
 	Version  int16
-	isDel    int8 `gorm:"index"`
+	IsDel    int8 `gorm:"column:is_del;index"` // 是否删除：0正常 1删除
 }
@@ -0,0 +1,15 @@
+package model
+
+// ConsumedMessage 已消费的消息，与库存修改在同一个事务中写入，用于消息去重和 v2 接口的幂等键
+type ConsumedMessage struct {
+	BaseModel     // 嵌入默认的7个字段
+	MsgId         string
+	ConsumerGroup string
+	RequestHash   string // 请求内容的哈希，幂等键复用时用来判断是否为同一个请求
+	Response      []byte // 首次请求的响应，重复请求原样返回
+}
+
+// TableName 声明表名
+func (ConsumedMessage) TableName() string {
+	return "xx_consumed_message"
+}
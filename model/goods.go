@@ -3,6 +3,12 @@ package model
 // ORM
 // struct -> table
 
+// 商品状态
+const (
+	GoodsOffShelf int8 = 0 // 未上架
+	GoodsOnShelf  int8 = 1 // 上架
+)
+
 type Goods struct {
 	BaseModel // 嵌入默认的7个字段
 
@@ -10,7 +16,7 @@ type Goods struct {
 	CategoryId  int64
 	BrandName   string
 	Code        int64
-	Status      int8
+	Status      int8 // 0未上架 1上架
 	Title       string
 	MarketPrice int64
 	Price       int64
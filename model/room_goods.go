@@ -6,7 +6,8 @@ type RoomGoods struct {
 	RoomId    int64
 	GoodsId   int64
 	Weight    int64
-	IsCurrent int8 `gorm:"is_current"`
+	IsCurrent int8  `gorm:"is_current"`
+	Quota     int64 // 直播间可售的数量（预扣和已扣减），0 表示不限
 }
 
 // TableName 声明表名
@@ -4,8 +4,9 @@ package model
 type Stock struct {
 	BaseModel // 嵌入默认七个字段
 	GoodsId   int64
-	StockNum  int64 `gorm:"column:stocknum"` // 确保字段名与数据库一致  //库存数量
-	Lock      int64 `gorm:"column:lock"`     //预扣存数
+	StockNum  int64 `gorm:"column:stocknum"`   // 确保字段名与数据库一致  //库存数量
+	Lock      int64 `gorm:"column:lock"`       //预扣存数
+	UserLimit int64 `gorm:"column:user_limit"` // 每个用户的限购数量（预扣和已扣减），0 表示不限购
 }
 
 // TableName 声明表名
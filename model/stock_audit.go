@@ -0,0 +1,18 @@
+package model
+
+// StockAudit 库存审计记录，记录库存被系统修正的前后值
+type StockAudit struct {
+	BaseModel   // 嵌入默认的7个字段
+	GoodsId     int64
+	Action      string // 操作类型，如 reconcile
+	BeforeStock int64  // 修正前库存
+	AfterStock  int64  // 修正后库存
+	BeforeLock  int64  // 修正前预扣库存
+	AfterLock   int64  // 修正后预扣库存
+	Remark      string
+}
+
+// TableName 声明表名
+func (StockAudit) TableName() string {
+	return "xx_stock_audit"
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// 库存变更事件状态
+const (
+	OutboxPending int8 = 0 // 待投递
+	OutboxSent    int8 = 1 // 已投递
+	OutboxParked  int8 = 2 // 重试次数用完，不再投递，需要人工处理（改回待投递后会重新投递）
+)
+
+// 库存变更事件类型
+const (
+	EventStockSet        = "stock_set"         // 设置库存
+	EventStockReduced    = "stock_reduced"     // 扣减库存
+	EventStockRolledBack = "stock_rolled_back" // 回滚库存
+	EventStockReleased   = "stock_released"    // 超时释放库存
+	EventStockConfirmed  = "stock_confirmed"   // 确认扣减库存
+	EventStockAdjusted   = "stock_adjusted"    // 调整库存
+	EventLockRepaired    = "lock_repaired"     // 对账修复预扣库存
+	EventStockDeleted    = "stock_deleted"     // 删除库存
+	EventStockUndeleted  = "stock_undeleted"   // 恢复库存
+)
+
+// StockOutbox 库存变更事件发件箱，与库存修改在同一个事务中写入
+type StockOutbox struct {
+	BaseModel   // 嵌入默认的7个字段
+	GoodsId     int64
+	EventType   string
+	Payload     string    // 事件内容（JSON）
+	Status      int8      // 0待投递 1已投递 2已搁置
+	Retries     int32     // 已重试次数
+	NextRetryAt time.Time // 下次投递时间
+	LastError   string    // 最近一次投递失败的原因
+}
+
+// TableName 声明表名
+func (StockOutbox) TableName() string {
+	return "xx_stock_outbox"
+}
+
+// StockChangedEvent 库存变更事件内容，序列化后存入 StockOutbox.Payload
+type StockChangedEvent struct {
+	EventType  string `json:"event_type"`
+	GoodsId    int64  `json:"goods_id"`
+	OrderId    int64  `json:"order_id,omitempty"`
+	Num        int64  `json:"num,omitempty"`
+	Stock      int64  `json:"stock"`       // 变更后的库存数量
+	Lock       int64  `json:"lock"`        // 变更后的预扣库存数量
+	OccurredAt int64  `json:"occurred_at"` // 发生时间（Unix 毫秒）
+}
@@ -1,11 +1,21 @@
 package model
 
+// 库存记录状态
+const (
+	StockRecordReserved   int32 = 1 // 预扣减
+	StockRecordConfirmed  int32 = 2 // 已扣减
+	StockRecordRolledBack int32 = 3 // 已回滚
+	StockRecordExpired    int32 = 4 // 超时释放
+)
+
 type StockRecord struct {
 	BaseModel // 嵌入默认的7个字段
 	OrderId int64
 	GoodsId int64
 	Num     int64 //回滚数量
 	Status  int32
+	UserId  int64 // 下单的用户，0 表示未知，用于限购
+	RoomId  int64 // 下单的直播间，0 表示不是直播间订单，用于直播间配额
 }
 
 // TableName 声明表名
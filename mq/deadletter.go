@@ -0,0 +1,44 @@
+package mq
+
+import (
+	"context"
+
+	"stock_service/logger"
+
+	"go.uber.org/zap"
+)
+
+// WithDeadLetter 为 Handler 增加死信处理：
+// 处理失败且已重试 maxRetries 次（或错误不可重试）的消息转发到死信主题后确认，不再重新投递。
+// 死信发送失败时返回原错误，消息仍会重试。
+func WithDeadLetter(h Handler, p Producer, dlqTopic string, maxRetries int32) Handler {
+	return func(ctx context.Context, msg *Message) error {
+		err := h(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		if !IsPermanent(err) && msg.ReconsumeTimes < maxRetries {
+			logger.Ctx(ctx).Warn("消息处理失败，等待重试",
+				zap.String("topic", msg.Topic),
+				zap.String("msg_id", msg.ID),
+				zap.Int32("reconsume_times", msg.ReconsumeTimes),
+				zap.Error(err))
+			return err
+		}
+
+		if sendErr := p.Send(ctx, dlqTopic, msg.Body); sendErr != nil {
+			logger.Ctx(ctx).Error("发送死信消息失败",
+				zap.String("topic", dlqTopic),
+				zap.String("msg_id", msg.ID),
+				zap.Error(sendErr))
+			return err
+		}
+		logger.Ctx(ctx).Error("消息处理失败，已转入死信主题",
+			zap.String("topic", msg.Topic),
+			zap.String("dlq_topic", dlqTopic),
+			zap.String("msg_id", msg.ID),
+			zap.Int32("reconsume_times", msg.ReconsumeTimes),
+			zap.Error(err))
+		return nil
+	}
+}
@@ -0,0 +1,193 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultMemory 配置为 memory 时生产者和消费者共用的实例
+var defaultMemory = NewMemory()
+
+// 确保 Memory 同时实现了 Consumer 和 Producer
+var (
+	_ Consumer = (*Memory)(nil)
+	_ Producer = (*Memory)(nil)
+)
+
+// ErrClosed 消息队列已关闭
+var ErrClosed = errors.New("mq closed")
+
+// Memory 进程内的消息队列，同时实现 Consumer 和 Producer，用于测试和本地开发。
+// 消息按发送顺序逐条投递，处理失败时间隔 RetryDelay 后重新投递，ReconsumeTimes 加 1。
+// 队列不限长度，Send 不会阻塞（处理函数中发送消息也不会阻塞投递协程）；
+// 没有订阅的主题（例如死信主题）的消息只记录在 Messages 中，订阅之后再投递。
+type Memory struct {
+	RetryDelay time.Duration
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	sent     map[string][]*Message
+	pending  map[string][]*Message // 还没有订阅的主题的消息
+	queue    []*Message            // 等待投递的消息
+	notify   chan struct{}
+	done     chan struct{}
+	wg       sync.WaitGroup
+	seq      atomic.Int64
+	started  bool
+	closed   bool
+}
+
+// NewMemory 创建内存消息队列
+func NewMemory() *Memory {
+	return &Memory{
+		RetryDelay: 100 * time.Millisecond,
+		handlers:   make(map[string]Handler),
+		sent:       make(map[string][]*Message),
+		pending:    make(map[string][]*Message),
+		notify:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+}
+
+// Subscribe 订阅主题，订阅之前发送到该主题的消息开始投递
+func (m *Memory) Subscribe(topic string, h Handler) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[topic] = h
+	if msgs := m.pending[topic]; len(msgs) > 0 {
+		delete(m.pending, topic)
+		m.queue = append(m.queue, msgs...)
+		m.wake()
+	}
+	return nil
+}
+
+// Start 启动投递协程
+func (m *Memory) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	if m.started {
+		return nil
+	}
+	m.started = true
+	m.wg.Add(1)
+	go m.loop()
+	return nil
+}
+
+// Shutdown 停止投递，已在处理中的消息会处理完
+func (m *Memory) Shutdown() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	close(m.done)
+	m.mu.Unlock()
+
+	m.wg.Wait()
+	return nil
+}
+
+// Send 发送消息
+func (m *Memory) Send(ctx context.Context, topic string, body []byte) error {
+	return m.send(topic, "", body)
+}
+
+// SendOrdered 发送顺序消息，内存队列只有一个队列，本身就是有序的，忽略 shardingKey
+func (m *Memory) SendOrdered(ctx context.Context, topic, key, shardingKey string, body []byte) error {
+	return m.send(topic, key, body)
+}
+
+func (m *Memory) send(topic, key string, body []byte) error {
+	msg := &Message{
+		ID:    fmt.Sprintf("mem-%d", m.seq.Add(1)),
+		Topic: topic,
+		Key:   key,
+		Body:  body,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.sent[topic] = append(m.sent[topic], msg)
+	if m.handlers[topic] == nil {
+		m.pending[topic] = append(m.pending[topic], msg)
+		return nil
+	}
+	m.queue = append(m.queue, msg)
+	m.wake()
+	return nil
+}
+
+// wake 通知投递协程有新消息，调用方持有锁
+func (m *Memory) wake() {
+	select {
+	case m.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Messages 返回发送到某个主题的全部消息，便于测试断言（例如死信主题）
+func (m *Memory) Messages(topic string) []*Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Message(nil), m.sent[topic]...)
+}
+
+func (m *Memory) loop() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-m.notify:
+		}
+		for {
+			select {
+			case <-m.done:
+				return
+			default:
+			}
+			m.mu.Lock()
+			if len(m.queue) == 0 {
+				m.mu.Unlock()
+				break
+			}
+			msg := m.queue[0]
+			m.queue = m.queue[1:]
+			h := m.handlers[msg.Topic]
+			m.mu.Unlock()
+
+			if !m.deliver(msg, h) {
+				return
+			}
+		}
+	}
+}
+
+// deliver 投递一条消息直到处理成功，队列关闭时返回 false
+func (m *Memory) deliver(msg *Message, h Handler) bool {
+	// 每次投递使用副本，避免处理函数修改消息
+	for attempt := *msg; ; attempt.ReconsumeTimes++ {
+		cp := attempt
+		if err := h(context.Background(), &cp); err == nil {
+			return true
+		}
+		select {
+		case <-m.done:
+			return false
+		case <-time.After(m.RetryDelay):
+		}
+	}
+}
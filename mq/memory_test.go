@@ -0,0 +1,73 @@
+package mq
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryDeliversAfterSubscribe(t *testing.T) {
+	m := NewMemory()
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { m.Shutdown() })
+
+	// 订阅之前发送的消息不会丢失，订阅后投递
+	if err := m.Send(context.Background(), "t", []byte("early")); err != nil {
+		t.Fatal(err)
+	}
+	got := make(chan string, 1)
+	if err := m.Subscribe("t", func(_ context.Context, msg *Message) error {
+		got <- string(msg.Body)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case body := <-got:
+		if body != "early" {
+			t.Errorf("body = %q, want early", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message sent before Subscribe was not delivered")
+	}
+}
+
+func TestMemorySendFromHandlerDoesNotBlock(t *testing.T) {
+	m := NewMemory()
+	const n = 3000 // 超过原来的队列长度
+	done := make(chan error, 1)
+	// 处理函数向没有订阅的主题（死信主题）发送大量消息，不能阻塞投递协程
+	if err := m.Subscribe("t", func(ctx context.Context, msg *Message) error {
+		for i := 0; i < n; i++ {
+			if err := m.Send(ctx, "dlq", msg.Body); err != nil {
+				done <- err
+				return nil
+			}
+		}
+		done <- nil
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { m.Shutdown() })
+
+	if err := m.Send(context.Background(), "t", []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler blocked on Send")
+	}
+	if got := len(m.Messages("dlq")); got != n {
+		t.Errorf("dead letters = %d, want %d", got, n)
+	}
+}
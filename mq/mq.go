@@ -0,0 +1,87 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"stock_service/config"
+)
+
+// 面向接口开发，业务代码只依赖 Consumer/Producer，
+// 底层可以是 RocketMQ，也可以是测试用的内存实现
+
+// Message 消息
+type Message struct {
+	ID             string // 消息ID，重试时保持不变
+	Topic          string // 主题
+	Key            string // 消息的业务键（例如库存变更事件的事件ID），下游可用于去重，没有时为空
+	Body           []byte // 消息体
+	ReconsumeTimes int32  // 已重试的次数
+}
+
+// Handler 消息处理函数，返回 nil 表示处理成功，消息才会被确认（ack）；
+// 返回错误时消息会被重新投递。
+type Handler func(ctx context.Context, msg *Message) error
+
+// Consumer 消费者
+type Consumer interface {
+	// Subscribe 订阅主题，需在 Start 之前调用
+	Subscribe(topic string, h Handler) error
+	// Start 开始消费
+	Start() error
+	// Shutdown 停止消费
+	Shutdown() error
+}
+
+// Producer 生产者
+type Producer interface {
+	// Send 同步发送消息
+	Send(ctx context.Context, topic string, body []byte) error
+	// SendOrdered 同步发送顺序消息：shardingKey 相同的消息进入同一个队列，按发送顺序消费；
+	// key 为消息的业务键，设置到 Message.Key
+	SendOrdered(ctx context.Context, topic, key, shardingKey string, body []byte) error
+	// Shutdown 关闭生产者
+	Shutdown() error
+}
+
+// permanentError 不可重试的错误
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 标记错误不可重试（例如消息格式错误），消息会直接进入死信主题
+func Permanent(err error) error {
+	return &permanentError{err}
+}
+
+// IsPermanent 判断错误是否不可重试
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// NewConsumer 根据配置创建消费者
+func NewConsumer(cfg *config.MQConfig) (Consumer, error) {
+	switch cfg.Type {
+	case "rocketmq":
+		return NewRocketMQConsumer(cfg)
+	case "memory":
+		return defaultMemory, nil
+	default:
+		return nil, fmt.Errorf("unsupported mq type: %s", cfg.Type)
+	}
+}
+
+// NewProducer 根据配置创建生产者
+func NewProducer(cfg *config.MQConfig) (Producer, error) {
+	switch cfg.Type {
+	case "rocketmq":
+		return NewRocketMQProducer(cfg)
+	case "memory":
+		return defaultMemory, nil
+	default:
+		return nil, fmt.Errorf("unsupported mq type: %s", cfg.Type)
+	}
+}
@@ -0,0 +1,111 @@
+package mq
+
+import (
+	"context"
+
+	"stock_service/config"
+
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+)
+
+// rocketmq -> https://github.com/apache/rocketmq-client-go
+
+// 确保适配器实现了对应的接口
+var (
+	_ Consumer = (*rocketMQConsumer)(nil)
+	_ Producer = (*rocketMQProducer)(nil)
+)
+
+type rocketMQConsumer struct {
+	c rocketmq.PushConsumer
+}
+
+// NewRocketMQConsumer 创建 RocketMQ 推模式消费者。
+// 每次只投递一条消息，处理成功才返回 ConsumeSuccess，失败返回 ConsumeRetryLater 由 broker 重新投递。
+func NewRocketMQConsumer(cfg *config.MQConfig) (Consumer, error) {
+	c, err := rocketmq.NewPushConsumer(
+		consumer.WithGroupName(cfg.Group),
+		consumer.WithNsResolver(primitive.NewPassthroughResolver(cfg.NameServers)),
+		consumer.WithConsumeMessageBatchMaxSize(1),
+		// 死信由 WithDeadLetter 处理，broker 的最大重试次数要比它大
+		consumer.WithMaxReconsumeTimes(cfg.MaxRetries+1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rocketMQConsumer{c: c}, nil
+}
+
+func (r *rocketMQConsumer) Subscribe(topic string, h Handler) error {
+	return r.c.Subscribe(topic, consumer.MessageSelector{}, func(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+		for _, m := range msgs {
+			// 重试消息的 MsgId 会变化，优先使用原始消息ID
+			id := m.GetProperty(primitive.PropertyOriginMessageId)
+			if id == "" {
+				id = m.MsgId
+			}
+			err := h(ctx, &Message{
+				ID:             id,
+				Topic:          m.Topic,
+				Key:            m.GetKeys(),
+				Body:           m.Body,
+				ReconsumeTimes: m.ReconsumeTimes,
+			})
+			if err != nil {
+				return consumer.ConsumeRetryLater, err
+			}
+		}
+		return consumer.ConsumeSuccess, nil
+	})
+}
+
+func (r *rocketMQConsumer) Start() error {
+	return r.c.Start()
+}
+
+func (r *rocketMQConsumer) Shutdown() error {
+	return r.c.Shutdown()
+}
+
+type rocketMQProducer struct {
+	p rocketmq.Producer
+}
+
+// NewRocketMQProducer 创建并启动 RocketMQ 生产者。
+// 队列按消息的 sharding key 哈希选择，没有 sharding key 的消息随机选择队列。
+func NewRocketMQProducer(cfg *config.MQConfig) (Producer, error) {
+	p, err := rocketmq.NewProducer(
+		producer.WithGroupName(cfg.Group),
+		producer.WithNsResolver(primitive.NewPassthroughResolver(cfg.NameServers)),
+		producer.WithRetry(2),
+		producer.WithQueueSelector(producer.NewHashQueueSelector()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Start(); err != nil {
+		return nil, err
+	}
+	return &rocketMQProducer{p: p}, nil
+}
+
+func (r *rocketMQProducer) Send(ctx context.Context, topic string, body []byte) error {
+	_, err := r.p.SendSync(ctx, primitive.NewMessage(topic, body))
+	return err
+}
+
+func (r *rocketMQProducer) SendOrdered(ctx context.Context, topic, key, shardingKey string, body []byte) error {
+	msg := primitive.NewMessage(topic, body).WithShardingKey(shardingKey)
+	if key != "" {
+		msg.WithKeys([]string{key})
+	}
+	_, err := r.p.SendSync(ctx, msg)
+	return err
+}
+
+func (r *rocketMQProducer) Shutdown() error {
+	return r.p.Shutdown()
+}
@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"stock_service/config"
+	"stock_service/logger"
+	"stock_service/mq"
+
+	"go.uber.org/zap"
+)
+
+// Event 待投递的库存变更事件
+type Event struct {
+	ID      uint   // 事件ID（发件箱主键），下游可用于去重
+	GoodsId int64  // 商品ID
+	Type    string // 事件类型
+	Payload []byte // 事件内容（JSON）
+}
+
+// Publisher 事件发布者，返回 nil 表示投递成功
+type Publisher interface {
+	Publish(ctx context.Context, e *Event) error
+}
+
+// NewPublisher 根据配置创建事件发布者
+func NewPublisher(cfg *config.OutboxConfig, mqCfg *config.MQConfig) (Publisher, error) {
+	switch cfg.Publisher {
+	case "mq":
+		if mqCfg == nil {
+			return nil, fmt.Errorf("outbox publisher mq requires mq config")
+		}
+		p, err := mq.NewProducer(mqCfg)
+		if err != nil {
+			return nil, err
+		}
+		return &MQPublisher{Producer: p, Topic: cfg.Topic}, nil
+	case "webhook":
+		return &WebhookPublisher{
+			URL:    cfg.WebhookURL,
+			Client: &http.Client{Timeout: cfg.WebhookTimeout},
+		}, nil
+	case "log":
+		return LogPublisher{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported outbox publisher: %s", cfg.Publisher)
+	}
+}
+
+// Close 关闭发布者持有的资源（MQ 生产者），Relay 退出后调用；其他发布者不需要关闭
+func Close(p Publisher) error {
+	if c, ok := p.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// MQPublisher 将事件发送到消息队列：事件ID为消息的 key（下游按它去重），
+// 以商品ID为 sharding key，同一商品的事件进入同一个队列，保持投递顺序
+type MQPublisher struct {
+	Producer mq.Producer
+	Topic    string
+}
+
+func (p *MQPublisher) Publish(ctx context.Context, e *Event) error {
+	return p.Producer.SendOrdered(ctx, p.Topic,
+		strconv.FormatUint(uint64(e.ID), 10),
+		strconv.FormatInt(e.GoodsId, 10),
+		e.Payload)
+}
+
+// Close 关闭生产者
+func (p *MQPublisher) Close() error {
+	return p.Producer.Shutdown()
+}
+
+// WebhookPublisher 以 HTTP POST 的方式推送事件，响应码非 2xx 视为失败
+type WebhookPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, e *Event) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(e.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", strconv.FormatUint(uint64(e.ID), 10))
+	req.Header.Set("X-Event-Type", e.Type)
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+// LogPublisher 只把事件写入日志，用于本地开发
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(ctx context.Context, e *Event) error {
+	logger.Ctx(ctx).Info("stock changed",
+		zap.Uint("event_id", e.ID),
+		zap.Int64("goods_id", e.GoodsId),
+		zap.String("event_type", e.Type),
+		zap.ByteString("payload", e.Payload))
+	return nil
+}
+
+// backoff 第 retries 次失败后的重试间隔，指数增长，最长 max
+func backoff(retries int32, base, max time.Duration) time.Duration {
+	d := base
+	for i := int32(1); i < retries && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
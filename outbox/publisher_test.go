@@ -0,0 +1,22 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+
+	"stock_service/mq"
+)
+
+func TestMQPublisherSetsEventIdAsKey(t *testing.T) {
+	m := mq.NewMemory()
+	t.Cleanup(func() { m.Shutdown() })
+	p := &MQPublisher{Producer: m, Topic: "stock_changed"}
+
+	if err := p.Publish(context.Background(), &Event{ID: 42, GoodsId: 1001, Type: "stock_set", Payload: []byte(`{}`)}); err != nil {
+		t.Fatal(err)
+	}
+	msgs := m.Messages("stock_changed")
+	if len(msgs) != 1 || msgs[0].Key != "42" {
+		t.Fatalf("messages = %+v, want one message with key 42", msgs)
+	}
+}
@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"stock_service/config"
+	"stock_service/dao/mysql"
+	"stock_service/dao/redis"
+	"stock_service/logger"
+
+	"github.com/go-redsync/redsync/v4"
+	"go.uber.org/zap"
+)
+
+// Relay 从发件箱中读取待投递的事件并交给 Publisher。
+// 同一商品的事件严格按写入顺序投递：某个事件投递失败（或未到重试时间）时，
+// 该商品后续的所有事件都等待它，其他商品不受影响（阻塞的商品在查询时排除，不占用 BatchSize）。
+// 重试 MaxRetries 次仍然失败的事件被搁置，之后的事件继续投递。
+// 投递至少一次，下游需按事件ID去重。
+type Relay struct {
+	Publisher  Publisher
+	Interval   time.Duration // 轮询间隔
+	BatchSize  int           // 每轮最多读取的事件数
+	BaseDelay  time.Duration // 首次重试间隔
+	MaxDelay   time.Duration // 最长重试间隔
+	MaxRetries int32         // 最多重试次数
+	lockExpiry time.Duration
+}
+
+// NewRelay 根据配置创建 Relay，未配置的参数使用默认值
+func NewRelay(cfg *config.OutboxConfig, p Publisher) *Relay {
+	r := &Relay{
+		Publisher:  p,
+		Interval:   cfg.Interval,
+		BatchSize:  cfg.BatchSize,
+		BaseDelay:  cfg.BaseDelay,
+		MaxDelay:   cfg.MaxDelay,
+		MaxRetries: cfg.MaxRetries,
+	}
+	if r.Interval <= 0 {
+		r.Interval = time.Second
+	}
+	if r.BatchSize <= 0 {
+		r.BatchSize = 100
+	}
+	if r.BaseDelay <= 0 {
+		r.BaseDelay = time.Second
+	}
+	if r.MaxDelay <= 0 {
+		r.MaxDelay = 5 * time.Minute
+	}
+	if r.MaxRetries <= 0 {
+		r.MaxRetries = 20
+	}
+	// 多实例部署时同一时刻只有一个实例在投递，保证顺序
+	r.lockExpiry = 10 * r.Interval
+	if r.lockExpiry < 10*time.Second {
+		r.lockExpiry = 10 * time.Second
+	}
+	return r
+}
+
+// Run 定时投递事件，ctx 取消后退出
+func (r *Relay) Run(ctx context.Context) {
+	logger.Ctx(ctx).Info("outbox relay start", zap.Duration("interval", r.Interval))
+
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.relayOnce(ctx)
+		}
+	}
+}
+
+// relayOnce 投递一批事件
+func (r *Relay) relayOnce(ctx context.Context) {
+	mutex := redis.Rs.NewMutex("xx-stock-outbox-relay",
+		redsync.WithTries(1),
+		redsync.WithExpiry(r.lockExpiry),
+	)
+	if err := mutex.TryLockContext(ctx); err != nil {
+		return
+	}
+	defer mutex.Unlock()
+
+	now := time.Now()
+	list, err := mysql.GetPendingEvents(ctx, now, r.BatchSize)
+	if err != nil {
+		return
+	}
+
+	blocked := make(map[int64]bool) // 本轮已阻塞的商品
+	for _, e := range list {
+		if blocked[e.GoodsId] {
+			continue
+		}
+
+		err := r.Publisher.Publish(ctx, &Event{
+			ID:      e.ID,
+			GoodsId: e.GoodsId,
+			Type:    e.EventType,
+			Payload: []byte(e.Payload),
+		})
+		if err != nil {
+			blocked[e.GoodsId] = true
+			retries := e.Retries + 1
+			if retries > r.MaxRetries {
+				logger.Ctx(ctx).Error("库存变更事件重试次数用完，已搁置",
+					zap.Uint("event_id", e.ID),
+					zap.Int64("goods_id", e.GoodsId),
+					zap.Int32("retries", retries),
+					zap.Error(err))
+				if err := mysql.MarkEventParked(ctx, e.ID, retries, err.Error()); err != nil {
+					logger.Ctx(ctx).Error("搁置事件失败", zap.Uint("event_id", e.ID), zap.Error(err))
+				}
+				continue
+			}
+			next := now.Add(backoff(retries, r.BaseDelay, r.MaxDelay))
+			logger.Ctx(ctx).Warn("投递库存变更事件失败",
+				zap.Uint("event_id", e.ID),
+				zap.Int64("goods_id", e.GoodsId),
+				zap.Int32("retries", retries),
+				zap.Time("next_retry_at", next),
+				zap.Error(err))
+			if err := mysql.MarkEventFailed(ctx, e.ID, retries, next, err.Error()); err != nil {
+				logger.Ctx(ctx).Error("记录事件投递失败出错", zap.Uint("event_id", e.ID), zap.Error(err))
+			}
+			continue
+		}
+
+		if err := mysql.MarkEventSent(ctx, e.ID); err != nil {
+			// 标记失败时下一轮会重复投递，下游需按事件ID去重
+			logger.Ctx(ctx).Error("标记事件已投递失败", zap.Uint("event_id", e.ID), zap.Error(err))
+			blocked[e.GoodsId] = true
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"stock_service/config"
+	"stock_service/dao/mysql"
+	"stock_service/dao/redis"
+	"stock_service/model"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// setupStore 使用 SQLite 和 miniredis 初始化 dao 层
+func setupStore(t *testing.T) *gorm.DB {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rc := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rc.Close() })
+	redis.Use(rc, &config.RedisConfig{})
+
+	dsn := filepath.Join(t.TempDir(), "stock.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true, Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&model.StockOutbox{}); err != nil {
+		t.Fatal(err)
+	}
+	mysql.Use(db)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// recordingPublisher 记录投递成功的事件ID，fail 中的事件总是投递失败
+type recordingPublisher struct {
+	mu   sync.Mutex
+	fail map[uint]bool
+	sent []uint
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e *Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fail[e.ID] {
+		return errors.New("publish failed")
+	}
+	p.sent = append(p.sent, e.ID)
+	return nil
+}
+
+func (p *recordingPublisher) Sent() []uint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]uint(nil), p.sent...)
+}
+
+func addEvent(t *testing.T, db *gorm.DB, goodsId int64) uint {
+	t.Helper()
+	e := &model.StockOutbox{GoodsId: goodsId, EventType: model.EventStockSet, Payload: "{}", Status: model.OutboxPending, NextRetryAt: time.Now()}
+	if err := db.Create(e).Error; err != nil {
+		t.Fatal(err)
+	}
+	return e.ID
+}
+
+func eventStatus(t *testing.T, db *gorm.DB, id uint) int8 {
+	t.Helper()
+	var e model.StockOutbox
+	if err := db.First(&e, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return e.Status
+}
+
+func equalIds(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRelayOrderAndHeadOfLineBlocking(t *testing.T) {
+	db := setupStore(t)
+	// 1001 的事件数不少于 BatchSize，第一个事件一直投递失败
+	e1 := addEvent(t, db, 1001)
+	e2 := addEvent(t, db, 1001)
+	e3 := addEvent(t, db, 1001)
+	e4 := addEvent(t, db, 1002)
+
+	p := &recordingPublisher{fail: map[uint]bool{e1: true}}
+	r := NewRelay(&config.OutboxConfig{BatchSize: 2, BaseDelay: 100 * time.Millisecond, MaxDelay: 100 * time.Millisecond, MaxRetries: 1}, p)
+	ctx := context.Background()
+
+	// 第一轮只读到 1001 的两个事件，e1 失败，e2 等待 e1
+	r.relayOnce(ctx)
+	if got := p.Sent(); len(got) != 0 {
+		t.Fatalf("round 1 sent %v, want none", got)
+	}
+	// 第二轮 1001 被阻塞，不占用 BatchSize，1002 的事件可以投递
+	r.relayOnce(ctx)
+	if got := p.Sent(); !equalIds(got, []uint{e4}) {
+		t.Fatalf("round 2 sent %v, want [%d]", got, e4)
+	}
+
+	// 到了重试时间 e1 再次失败，重试次数用完后被搁置，1001 后续的事件按顺序投递
+	time.Sleep(150 * time.Millisecond)
+	r.relayOnce(ctx)
+	if got := eventStatus(t, db, e1); got != model.OutboxParked {
+		t.Fatalf("event %d status = %d, want parked", e1, got)
+	}
+	r.relayOnce(ctx)
+	if got := p.Sent(); !equalIds(got, []uint{e4, e2, e3}) {
+		t.Errorf("sent %v, want [%d %d %d]", got, e4, e2, e3)
+	}
+	for _, id := range []uint{e2, e3, e4} {
+		if got := eventStatus(t, db, id); got != model.OutboxSent {
+			t.Errorf("event %d status = %d, want sent", id, got)
+		}
+	}
+}
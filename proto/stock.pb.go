@@ -7,6 +7,9 @@
 package proto
 
 import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -21,11 +24,117 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// 鍝嶅簲娑堟伅缁撴瀯
+// 库存记录状态
+type ReservationStatus int32
+
+const (
+	ReservationStatus_RESERVATION_UNKNOWN     ReservationStatus = 0
+	ReservationStatus_RESERVATION_RESERVED    ReservationStatus = 1 // 预扣减
+	ReservationStatus_RESERVATION_CONFIRMED   ReservationStatus = 2 // 已扣减
+	ReservationStatus_RESERVATION_ROLLED_BACK ReservationStatus = 3 // 已回滚
+	ReservationStatus_RESERVATION_EXPIRED     ReservationStatus = 4 // 超时释放
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "RESERVATION_UNKNOWN",
+		1: "RESERVATION_RESERVED",
+		2: "RESERVATION_CONFIRMED",
+		3: "RESERVATION_ROLLED_BACK",
+		4: "RESERVATION_EXPIRED",
+	}
+	ReservationStatus_value = map[string]int32{
+		"RESERVATION_UNKNOWN":     0,
+		"RESERVATION_RESERVED":    1,
+		"RESERVATION_CONFIRMED":   2,
+		"RESERVATION_ROLLED_BACK": 3,
+		"RESERVATION_EXPIRED":     4,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_stock_proto_enumTypes[0].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_stock_proto_enumTypes[0]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{0}
+}
+
+// 库存列表排序字段
+type StockSortField int32
+
+const (
+	StockSortField_STOCK_SORT_GOODS_ID  StockSortField = 0 // 按商品ID
+	StockSortField_STOCK_SORT_AVAILABLE StockSortField = 1 // 按可用库存
+	StockSortField_STOCK_SORT_UPDATE_AT StockSortField = 2 // 按更新时间
+)
+
+// Enum value maps for StockSortField.
+var (
+	StockSortField_name = map[int32]string{
+		0: "STOCK_SORT_GOODS_ID",
+		1: "STOCK_SORT_AVAILABLE",
+		2: "STOCK_SORT_UPDATE_AT",
+	}
+	StockSortField_value = map[string]int32{
+		"STOCK_SORT_GOODS_ID":  0,
+		"STOCK_SORT_AVAILABLE": 1,
+		"STOCK_SORT_UPDATE_AT": 2,
+	}
+)
+
+func (x StockSortField) Enum() *StockSortField {
+	p := new(StockSortField)
+	*p = x
+	return p
+}
+
+func (x StockSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_stock_proto_enumTypes[1].Descriptor()
+}
+
+func (StockSortField) Type() protoreflect.EnumType {
+	return &file_stock_proto_enumTypes[1]
+}
+
+func (x StockSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockSortField.Descriptor instead.
+func (StockSortField) EnumDescriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{1}
+}
+
+// 响应消息结构
 type Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 鎿嶄綔鏄惁鎴愬姛
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // 鎿嶄綔缁撴灉鐨勬弿杩颁俊鎭�
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 操作是否成功
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // 操作结果的描述信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,10 +183,10 @@ func (x *Response) GetMessage() string {
 	return ""
 }
 
-// 鑾峰彇搴撳瓨璇锋眰
+// 获取库存请求
 type GetStockReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 鍟嗗搧ID
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 商品ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -119,11 +228,12 @@ func (x *GetStockReq) GetGoodsId() int64 {
 	return 0
 }
 
-// 鍟嗗搧搴撳瓨淇℃伅
+// 商品库存信息
 type GoodsStockInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 鍟嗗搧ID
-	Stock         int64                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`                    // 褰撳墠搴撳瓨鏁伴噺
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 商品ID
+	Stock         int64                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`                    // 当前库存数量
+	Exists        bool                   `protobuf:"varint,3,opt,name=exists,proto3" json:"exists,omitempty"`                  // 库存是否存在，批量查询时不存在的商品返回 false
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -172,14 +282,22 @@ func (x *GoodsStockInfo) GetStock() int64 {
 	return 0
 }
 
-// 鍑忓皯搴撳瓨璇锋眰
+func (x *GoodsStockInfo) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+// 减少库存请求
 type ReduceStockInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 鍟嗗搧ID
-	Num           int64                  `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`                        // 鍑忓皯鐨勬暟閲�
-	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // 璁㈠崟ID锛堢敤浜庡叧鑱旇鍗曪紝渚夸簬鍚庣画鍥炴粴鎴栨煡璇級
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId            int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"`                                    // 商品ID
+	Num                int64                  `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`                                                           // 减少的数量
+	OrderId            int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                                    // 订单ID（用于关联订单，便于后续回滚或查询）
+	AutoReleaseSeconds int64                  `protobuf:"varint,4,opt,name=auto_release_seconds,json=autoReleaseSeconds,proto3" json:"auto_release_seconds,omitempty"` // 超时未确认自动释放的秒数，0 表示使用服务端配置
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ReduceStockInfo) Reset() {
@@ -233,12 +351,19 @@ func (x *ReduceStockInfo) GetOrderId() int64 {
 	return 0
 }
 
-// 鍑忓皯搴撳瓨璇锋眰
+func (x *ReduceStockInfo) GetAutoReleaseSeconds() int64 {
+	if x != nil {
+		return x.AutoReleaseSeconds
+	}
+	return 0
+}
+
+// 回滚库存请求
 type RollBackStockInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"`             // 鍟嗗搧ID
-	RollbackNum   int64                  `protobuf:"varint,2,opt,name=rollback_num,json=rollbackNum,proto3" json:"rollback_num,omitempty"` // 鍥炴粴搴撳瓨鐨勬暟閲�
-	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`             // 璁㈠崟ID锛堢敤浜庡叧鑱旇鍗曪紝渚夸簬鍚庣画鍥炴粴鎴栨煡璇級
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"`             // 商品ID
+	RollbackNum   int64                  `protobuf:"varint,2,opt,name=rollback_num,json=rollbackNum,proto3" json:"rollback_num,omitempty"` // 回滚库存的数量
+	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`             // 订单ID（用于关联订单，便于后续回滚或查询）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -294,10 +419,10 @@ func (x *RollBackStockInfo) GetOrderId() int64 {
 	return 0
 }
 
-// 鎵归噺搴撳瓨淇℃伅
+// 批量库存信息
 type StockInfoList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []*GoodsStockInfo      `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"` // 搴撳瓨淇℃伅鍒楄〃锛岀敤浜庢壒閲忔搷浣�
+	Data          []*GoodsStockInfo      `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"` // 库存信息列表，用于批量操作
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -339,122 +464,1375 @@ func (x *StockInfoList) GetData() []*GoodsStockInfo {
 	return nil
 }
 
-var File_stock_proto protoreflect.FileDescriptor
+// 导出库存请求
+type ExportStockReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinGoodsId    int64                  `protobuf:"varint,1,opt,name=min_goods_id,json=minGoodsId,proto3" json:"min_goods_id,omitempty"`     // 商品ID下界（含），0 表示不限
+	MaxGoodsId    int64                  `protobuf:"varint,2,opt,name=max_goods_id,json=maxGoodsId,proto3" json:"max_goods_id,omitempty"`     // 商品ID上界（含），0 表示不限
+	UpdatedSince  int64                  `protobuf:"varint,3,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"` // 只导出该时间（Unix 秒）之后更新过的库存，0 表示不限
+	OnlyNonZero   bool                   `protobuf:"varint,4,opt,name=only_non_zero,json=onlyNonZero,proto3" json:"only_non_zero,omitempty"`  // 只导出库存或预扣库存不为 0 的商品
+	WithRecords   bool                   `protobuf:"varint,5,opt,name=with_records,json=withRecords,proto3" json:"with_records,omitempty"`    // 是否同时导出状态为 1（预扣减）的库存记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-var file_stock_proto_rawDesc = string([]byte{
-	0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x28, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b,
-	0x52, 0x65, 0x71, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x22, 0x41,
-	0x0a, 0x0e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x19, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73,
-	0x74, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x63,
-	0x6b, 0x22, 0x59, 0x0a, 0x0f, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12,
-	0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6e, 0x75,
-	0x6d, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x6c, 0x0a, 0x11,
-	0x52, 0x6f, 0x6c, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c,
-	0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x12,
-	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x0d, 0x53, 0x74,
-	0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xde, 0x02, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x63, 0x6b,
-	0x12, 0x32, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49,
-	0x6e, 0x66, 0x6f, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b,
-	0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63,
-	0x6b, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f,
-	0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x0b, 0x52,
-	0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e,
-	0x66, 0x6f, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53,
-	0x74, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x6f, 0x6c,
-	0x6c, 0x42, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x0f,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x3b, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b,
-	0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e,
-	0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x10,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b,
-	0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e,
-	0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+func (x *ExportStockReq) Reset() {
+	*x = ExportStockReq{}
+	mi := &file_stock_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_stock_proto_rawDescOnce sync.Once
-	file_stock_proto_rawDescData []byte
-)
+func (x *ExportStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_stock_proto_rawDescGZIP() []byte {
-	file_stock_proto_rawDescOnce.Do(func() {
-		file_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stock_proto_rawDesc), len(file_stock_proto_rawDesc)))
-	})
-	return file_stock_proto_rawDescData
+func (*ExportStockReq) ProtoMessage() {}
+
+func (x *ExportStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_stock_proto_goTypes = []any{
-	(*Response)(nil),          // 0: proto.Response
-	(*GetStockReq)(nil),       // 1: proto.GetStockReq
-	(*GoodsStockInfo)(nil),    // 2: proto.GoodsStockInfo
-	(*ReduceStockInfo)(nil),   // 3: proto.ReduceStockInfo
-	(*RollBackStockInfo)(nil), // 4: proto.RollBackStockInfo
-	(*StockInfoList)(nil),     // 5: proto.StockInfoList
+// Deprecated: Use ExportStockReq.ProtoReflect.Descriptor instead.
+func (*ExportStockReq) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{6}
 }
-var file_stock_proto_depIdxs = []int32{
-	2, // 0: proto.StockInfoList.data:type_name -> proto.GoodsStockInfo
-	2, // 1: proto.Stock.SetStock:input_type -> proto.GoodsStockInfo
-	1, // 2: proto.Stock.GetStock:input_type -> proto.GetStockReq
-	3, // 3: proto.Stock.ReduceStock:input_type -> proto.ReduceStockInfo
-	4, // 4: proto.Stock.RollbackStock:input_type -> proto.RollBackStockInfo
-	5, // 5: proto.Stock.BatchGetStock:input_type -> proto.StockInfoList
-	5, // 6: proto.Stock.BatchReduceStock:input_type -> proto.StockInfoList
-	0, // 7: proto.Stock.SetStock:output_type -> proto.Response
-	2, // 8: proto.Stock.GetStock:output_type -> proto.GoodsStockInfo
-	0, // 9: proto.Stock.ReduceStock:output_type -> proto.Response
-	0, // 10: proto.Stock.RollbackStock:output_type -> proto.Response
-	5, // 11: proto.Stock.BatchGetStock:output_type -> proto.StockInfoList
-	0, // 12: proto.Stock.BatchReduceStock:output_type -> proto.Response
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+func (x *ExportStockReq) GetMinGoodsId() int64 {
+	if x != nil {
+		return x.MinGoodsId
+	}
+	return 0
 }
 
-func init() { file_stock_proto_init() }
-func file_stock_proto_init() {
-	if File_stock_proto != nil {
-		return
+func (x *ExportStockReq) GetMaxGoodsId() int64 {
+	if x != nil {
+		return x.MaxGoodsId
+	}
+	return 0
+}
+
+func (x *ExportStockReq) GetUpdatedSince() int64 {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return 0
+}
+
+func (x *ExportStockReq) GetOnlyNonZero() bool {
+	if x != nil {
+		return x.OnlyNonZero
+	}
+	return false
+}
+
+func (x *ExportStockReq) GetWithRecords() bool {
+	if x != nil {
+		return x.WithRecords
+	}
+	return false
+}
+
+// 库存明细
+type StockDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"`    // 商品ID
+	Stock         int64                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`                       // 库存数量
+	Lock          int64                  `protobuf:"varint,3,opt,name=lock,proto3" json:"lock,omitempty"`                         // 预扣库存数量
+	UpdateAt      int64                  `protobuf:"varint,4,opt,name=update_at,json=updateAt,proto3" json:"update_at,omitempty"` // 更新时间（Unix 秒）
+	Available     int64                  `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`               // 可用库存，预扣时已从库存数量中减去，与 stock 相同
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockDetail) Reset() {
+	*x = StockDetail{}
+	mi := &file_stock_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockDetail) ProtoMessage() {}
+
+func (x *StockDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockDetail.ProtoReflect.Descriptor instead.
+func (*StockDetail) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StockDetail) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *StockDetail) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *StockDetail) GetLock() int64 {
+	if x != nil {
+		return x.Lock
+	}
+	return 0
+}
+
+func (x *StockDetail) GetUpdateAt() int64 {
+	if x != nil {
+		return x.UpdateAt
+	}
+	return 0
+}
+
+func (x *StockDetail) GetAvailable() int64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+// 库存记录信息
+type StockRecordInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                      // 记录ID
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`             // 订单ID
+	GoodsId       int64                  `protobuf:"varint,3,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"`             // 商品ID
+	Num           int64                  `protobuf:"varint,4,opt,name=num,proto3" json:"num,omitempty"`                                    // 数量
+	Status        ReservationStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=proto.ReservationStatus" json:"status,omitempty"` // 状态
+	CreateAt      int64                  `protobuf:"varint,6,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`          // 创建时间（Unix 秒）
+	UpdateAt      int64                  `protobuf:"varint,7,opt,name=update_at,json=updateAt,proto3" json:"update_at,omitempty"`          // 更新时间（Unix 秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockRecordInfo) Reset() {
+	*x = StockRecordInfo{}
+	mi := &file_stock_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRecordInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRecordInfo) ProtoMessage() {}
+
+func (x *StockRecordInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRecordInfo.ProtoReflect.Descriptor instead.
+func (*StockRecordInfo) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StockRecordInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetNum() int64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_RESERVATION_UNKNOWN
+}
+
+func (x *StockRecordInfo) GetCreateAt() int64 {
+	if x != nil {
+		return x.CreateAt
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetUpdateAt() int64 {
+	if x != nil {
+		return x.UpdateAt
+	}
+	return 0
+}
+
+// 导出条目，库存明细与库存记录二选一
+type ExportStockItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Item:
+	//
+	//	*ExportStockItem_Stock
+	//	*ExportStockItem_Record
+	Item          isExportStockItem_Item `protobuf_oneof:"item"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStockItem) Reset() {
+	*x = ExportStockItem{}
+	mi := &file_stock_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStockItem) ProtoMessage() {}
+
+func (x *ExportStockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStockItem.ProtoReflect.Descriptor instead.
+func (*ExportStockItem) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportStockItem) GetItem() isExportStockItem_Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *ExportStockItem) GetStock() *StockDetail {
+	if x != nil {
+		if x, ok := x.Item.(*ExportStockItem_Stock); ok {
+			return x.Stock
+		}
+	}
+	return nil
+}
+
+func (x *ExportStockItem) GetRecord() *StockRecordInfo {
+	if x != nil {
+		if x, ok := x.Item.(*ExportStockItem_Record); ok {
+			return x.Record
+		}
+	}
+	return nil
+}
+
+type isExportStockItem_Item interface {
+	isExportStockItem_Item()
+}
+
+type ExportStockItem_Stock struct {
+	Stock *StockDetail `protobuf:"bytes,1,opt,name=stock,proto3,oneof"`
+}
+
+type ExportStockItem_Record struct {
+	Record *StockRecordInfo `protobuf:"bytes,2,opt,name=record,proto3,oneof"`
+}
+
+func (*ExportStockItem_Stock) isExportStockItem_Item() {}
+
+func (*ExportStockItem_Record) isExportStockItem_Item() {}
+
+// 库存对账请求
+type ReconcileStockReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsIds      []int64                `protobuf:"varint,1,rep,packed,name=goods_ids,json=goodsIds,proto3" json:"goods_ids,omitempty"` // 需要核对的商品ID，为空表示全部商品
+	Repair        bool                   `protobuf:"varint,2,opt,name=repair,proto3" json:"repair,omitempty"`                            // 是否修复不一致的预扣库存
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileStockReq) Reset() {
+	*x = ReconcileStockReq{}
+	mi := &file_stock_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileStockReq) ProtoMessage() {}
+
+func (x *ReconcileStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileStockReq.ProtoReflect.Descriptor instead.
+func (*ReconcileStockReq) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReconcileStockReq) GetGoodsIds() []int64 {
+	if x != nil {
+		return x.GoodsIds
+	}
+	return nil
+}
+
+func (x *ReconcileStockReq) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+// 预扣库存差异
+type LockMismatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"`                // 商品ID
+	Lock          int64                  `protobuf:"varint,2,opt,name=lock,proto3" json:"lock,omitempty"`                                     // 当前预扣库存
+	ExpectedLock  int64                  `protobuf:"varint,3,opt,name=expected_lock,json=expectedLock,proto3" json:"expected_lock,omitempty"` // 状态为 1 的库存记录数量之和
+	Repaired      bool                   `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`                             // 是否已修复
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockMismatch) Reset() {
+	*x = LockMismatch{}
+	mi := &file_stock_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockMismatch) ProtoMessage() {}
+
+func (x *LockMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockMismatch.ProtoReflect.Descriptor instead.
+func (*LockMismatch) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LockMismatch) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *LockMismatch) GetLock() int64 {
+	if x != nil {
+		return x.Lock
+	}
+	return 0
+}
+
+func (x *LockMismatch) GetExpectedLock() int64 {
+	if x != nil {
+		return x.ExpectedLock
+	}
+	return 0
+}
+
+func (x *LockMismatch) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+// 库存对账响应
+type ReconcileStockResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mismatches    []*LockMismatch        `protobuf:"bytes,1,rep,name=mismatches,proto3" json:"mismatches,omitempty"` // 不一致的商品列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileStockResp) Reset() {
+	*x = ReconcileStockResp{}
+	mi := &file_stock_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileStockResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileStockResp) ProtoMessage() {}
+
+func (x *ReconcileStockResp) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileStockResp.ProtoReflect.Descriptor instead.
+func (*ReconcileStockResp) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReconcileStockResp) GetMismatches() []*LockMismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+// 删除库存请求
+type DeleteStockReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 商品ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStockReq) Reset() {
+	*x = DeleteStockReq{}
+	mi := &file_stock_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStockReq) ProtoMessage() {}
+
+func (x *DeleteStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStockReq.ProtoReflect.Descriptor instead.
+func (*DeleteStockReq) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteStockReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+// 恢复库存请求
+type UndeleteStockReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 商品ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteStockReq) Reset() {
+	*x = UndeleteStockReq{}
+	mi := &file_stock_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteStockReq) ProtoMessage() {}
+
+func (x *UndeleteStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteStockReq.ProtoReflect.Descriptor instead.
+func (*UndeleteStockReq) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UndeleteStockReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+// 查询订单库存预占记录请求
+type GetOrderReservationsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // 订单ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderReservationsReq) Reset() {
+	*x = GetOrderReservationsReq{}
+	mi := &file_stock_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderReservationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderReservationsReq) ProtoMessage() {}
+
+func (x *GetOrderReservationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderReservationsReq.ProtoReflect.Descriptor instead.
+func (*GetOrderReservationsReq) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetOrderReservationsReq) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+// 分页查询库存预占记录请求
+type ListReservationsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"`             // 商品ID，0 表示不限
+	Status        ReservationStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=proto.ReservationStatus" json:"status,omitempty"` // 状态，RESERVATION_UNKNOWN 表示不限
+	StartTime     int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`       // 创建时间下界（Unix 秒，含），0 表示不限
+	EndTime       int64                  `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`             // 创建时间上界（Unix 秒，不含），0 表示不限
+	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`                                  // 页码，从 1 开始
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`          // 每页条数，默认 20，最大 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReservationsReq) Reset() {
+	*x = ListReservationsReq{}
+	mi := &file_stock_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReservationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReservationsReq) ProtoMessage() {}
+
+func (x *ListReservationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReservationsReq.ProtoReflect.Descriptor instead.
+func (*ListReservationsReq) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListReservationsReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *ListReservationsReq) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_RESERVATION_UNKNOWN
+}
+
+func (x *ListReservationsReq) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ListReservationsReq) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ListReservationsReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReservationsReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 库存预占记录列表
+type ReservationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*StockRecordInfo     `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`    // 记录列表
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // 总条数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationList) Reset() {
+	*x = ReservationList{}
+	mi := &file_stock_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationList) ProtoMessage() {}
+
+func (x *ReservationList) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationList.ProtoReflect.Descriptor instead.
+func (*ReservationList) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReservationList) GetData() []*StockRecordInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReservationList) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 分页查询库存列表请求
+type ListStockReq struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AvailableBelow *int64                 `protobuf:"varint,1,opt,name=available_below,json=availableBelow,proto3,oneof" json:"available_below,omitempty"` // 只返回可用库存小于该值的商品
+	LockedOnly     bool                   `protobuf:"varint,2,opt,name=locked_only,json=lockedOnly,proto3" json:"locked_only,omitempty"`                   // 只返回预扣库存大于 0 的商品
+	UpdatedSince   int64                  `protobuf:"varint,3,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`             // 只返回该时间（Unix 秒）之后更新过的商品，0 表示不限
+	GoodsIds       []int64                `protobuf:"varint,4,rep,packed,name=goods_ids,json=goodsIds,proto3" json:"goods_ids,omitempty"`                  // 商品ID列表，为空表示不限
+	SortBy         StockSortField         `protobuf:"varint,5,opt,name=sort_by,json=sortBy,proto3,enum=proto.StockSortField" json:"sort_by,omitempty"`     // 排序字段
+	Desc           bool                   `protobuf:"varint,6,opt,name=desc,proto3" json:"desc,omitempty"`                                                 // 是否倒序
+	Cursor         string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`                                              // 上一页返回的 next_cursor，为空表示第一页
+	PageSize       int32                  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                         // 每页条数，默认 20，最大 100
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListStockReq) Reset() {
+	*x = ListStockReq{}
+	mi := &file_stock_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockReq) ProtoMessage() {}
+
+func (x *ListStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockReq.ProtoReflect.Descriptor instead.
+func (*ListStockReq) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListStockReq) GetAvailableBelow() int64 {
+	if x != nil && x.AvailableBelow != nil {
+		return *x.AvailableBelow
+	}
+	return 0
+}
+
+func (x *ListStockReq) GetLockedOnly() bool {
+	if x != nil {
+		return x.LockedOnly
+	}
+	return false
+}
+
+func (x *ListStockReq) GetUpdatedSince() int64 {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return 0
+}
+
+func (x *ListStockReq) GetGoodsIds() []int64 {
+	if x != nil {
+		return x.GoodsIds
+	}
+	return nil
+}
+
+func (x *ListStockReq) GetSortBy() StockSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return StockSortField_STOCK_SORT_GOODS_ID
+}
+
+func (x *ListStockReq) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ListStockReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListStockReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 分页查询库存列表响应
+type ListStockResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*StockDetail         `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`                               // 库存列表
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 下一页游标，为空表示没有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockResp) Reset() {
+	*x = ListStockResp{}
+	mi := &file_stock_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockResp) ProtoMessage() {}
+
+func (x *ListStockResp) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockResp.ProtoReflect.Descriptor instead.
+func (*ListStockResp) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListStockResp) GetData() []*StockDetail {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListStockResp) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// 确认扣减库存请求
+type ConfirmStockInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=goods_id,json=goodsId,proto3" json:"goods_id,omitempty"` // 商品ID
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // 订单ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmStockInfo) Reset() {
+	*x = ConfirmStockInfo{}
+	mi := &file_stock_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmStockInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmStockInfo) ProtoMessage() {}
+
+func (x *ConfirmStockInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmStockInfo.ProtoReflect.Descriptor instead.
+func (*ConfirmStockInfo) Descriptor() ([]byte, []int) {
+	return file_stock_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConfirmStockInfo) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *ConfirmStockInfo) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+var File_stock_proto protoreflect.FileDescriptor
+
+var file_stock_proto_rawDesc = string([]byte{
+	0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f,
+	0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3e, 0x0a, 0x08, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x31, 0x0a, 0x0b, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x22, 0x0a, 0x08, 0x67, 0x6f,
+	0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x22, 0x6b,
+	0x0a, 0x0e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x22, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x67, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28, 0x00, 0x52, 0x05, 0x73, 0x74,
+	0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x0f,
+	0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x22, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64,
+	0x73, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x12, 0x19,
+	0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x14, 0x61, 0x75, 0x74,
+	0x6f, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28, 0x00,
+	0x52, 0x12, 0x61, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x11, 0x52, 0x6f, 0x6c, 0x6c, 0x42, 0x61, 0x63,
+	0x6b, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x22, 0x0a, 0x08, 0x67, 0x6f,
+	0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x2a,
+	0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x0b, 0x72,
+	0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x12, 0x22, 0x0a, 0x08, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x46,
+	0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x35, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x49, 0x6e, 0x66, 0x6f, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x92, 0x01, 0x04, 0x08, 0x01, 0x10, 0x64,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xdb, 0x01, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x29, 0x0a, 0x0c, 0x6d, 0x69, 0x6e,
+	0x5f, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28, 0x00, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x67, 0x6f, 0x6f, 0x64,
+	0x73, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22,
+	0x02, 0x28, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12,
+	0x2c, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28, 0x00, 0x52,
+	0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x0a,
+	0x0d, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x6e, 0x6f, 0x6e, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6f, 0x6e, 0x6c, 0x79, 0x4e, 0x6f, 0x6e, 0x5a, 0x65, 0x72,
+	0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x73, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x22, 0xd5, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6e, 0x75, 0x6d,
+	0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x22, 0x77, 0x0a, 0x0f,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x12,
+	0x2a, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x30, 0x0a, 0x06, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49,
+	0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x42, 0x06, 0x0a,
+	0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0x56, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69,
+	0x6c, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x29, 0x0a, 0x09, 0x67, 0x6f,
+	0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x42, 0x0c, 0xfa,
+	0x42, 0x09, 0x92, 0x01, 0x06, 0x22, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x08, 0x67, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x22, 0x7e, 0x0a,
+	0x0c, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x0a,
+	0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4c, 0x6f, 0x63,
+	0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x22, 0x49, 0x0a,
+	0x12, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x33, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0a, 0x6d, 0x69,
+	0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x22, 0x0a, 0x08, 0x67, 0x6f,
+	0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42,
+	0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x22, 0x36,
+	0x0a, 0x10, 0x55, 0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x12, 0x22, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x67,
+	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x22, 0x3d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x22, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x84, 0x02, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x22, 0x0a,
+	0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28, 0x00, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49,
+	0x64, 0x12, 0x3a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28, 0x00, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28, 0x00,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x1a, 0x02, 0x28, 0x00,
+	0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x1a, 0x02,
+	0x28, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x53, 0x0a, 0x0f,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x22, 0xd6, 0x02, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x12, 0x2c, 0x0a, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x62, 0x65, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0e, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x65, 0x6c, 0x6f, 0x77, 0x88, 0x01, 0x01,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x4f, 0x6e, 0x6c,
+	0x79, 0x12, 0x2c, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22, 0x02, 0x28,
+	0x00, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12,
+	0x29, 0x0a, 0x09, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x03, 0x42, 0x0c, 0xfa, 0x42, 0x09, 0x92, 0x01, 0x06, 0x22, 0x04, 0x22, 0x02, 0x20, 0x00,
+	0x52, 0x08, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x07, 0x73, 0x6f,
+	0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x73, 0x6f,
+	0x72, 0x74, 0x42, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x24, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x1a, 0x02, 0x28, 0x00, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x62, 0x65, 0x6c, 0x6f, 0x77, 0x22, 0x58, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x26, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x22, 0x5a, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x53,
+	0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x22, 0x0a, 0x08, 0x67, 0x6f, 0x6f, 0x64,
+	0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x22,
+	0x02, 0x20, 0x00, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x08,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x07,
+	0xfa, 0x42, 0x04, 0x22, 0x02, 0x20, 0x00, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x2a, 0x97, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
+	0x18, 0x0a, 0x14, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52,
+	0x45, 0x53, 0x45, 0x52, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x53,
+	0x45, 0x52, 0x56, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x45, 0x44, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10,
+	0x03, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x5d, 0x0a, 0x0e, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x17, 0x0a, 0x13,
+	0x53, 0x54, 0x4f, 0x43, 0x4b, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x47, 0x4f, 0x4f, 0x44, 0x53,
+	0x5f, 0x49, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x54, 0x4f, 0x43, 0x4b, 0x5f, 0x53,
+	0x4f, 0x52, 0x54, 0x5f, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x53, 0x54, 0x4f, 0x43, 0x4b, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x55, 0x50,
+	0x44, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x54, 0x10, 0x02, 0x32, 0x95, 0x0a, 0x0a, 0x05, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x12, 0x53, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12,
+	0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a,
+	0x01, 0x2a, 0x1a, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x7b, 0x67,
+	0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x53, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x22,
+	0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f,
+	0x63, 0x6b, 0x2f, 0x7b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x5e, 0x0a,
+	0x0b, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x3a, 0x01, 0x2a,
+	0x22, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x7b, 0x67, 0x6f, 0x6f,
+	0x64, 0x73, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x64, 0x75, 0x63, 0x65, 0x12, 0x64, 0x0a,
+	0x0d, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x53,
+	0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x22, 0x3a, 0x01, 0x2a, 0x22, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f,
+	0x7b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x6f, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x12, 0x5a, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74,
+	0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x3a, 0x01, 0x2a, 0x22, 0x12, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x12,
+	0x39, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0b, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53,
+	0x74, 0x6f, 0x63, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12,
+	0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x3a, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x30, 0x01, 0x12, 0x65, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c,
+	0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69,
+	0x6c, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x1e, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x18, 0x3a, 0x01, 0x2a, 0x22, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63,
+	0x6b, 0x3a, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x12, 0x53, 0x0a, 0x0b, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x2a, 0x14, 0x2f, 0x76, 0x31, 0x2f,
+	0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x7b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x7d,
+	0x12, 0x63, 0x0a, 0x0d, 0x55, 0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x6e, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x22, 0x3a, 0x01, 0x2a, 0x22, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63,
+	0x6b, 0x2f, 0x7b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x7d, 0x3a, 0x75, 0x6e, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x7a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x2a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x12, 0x22, 0x2f,
+	0x76, 0x31, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x60, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x11, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x61,
+	0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x17,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21,
+	0x3a, 0x01, 0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x7b,
+	0x67, 0x6f, 0x6f, 0x64, 0x73, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x42, 0x92, 0x01, 0x92, 0x41, 0x85, 0x01, 0x12, 0x5b, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x20, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46, 0xe5, 0xba, 0x93, 0xe5, 0xad,
+	0x98, 0xe6, 0x9c, 0x8d, 0xe5, 0x8a, 0xa1, 0x20, 0x48, 0x54, 0x54, 0x50, 0x2f, 0x4a, 0x53, 0x4f,
+	0x4e, 0x20, 0xe6, 0x8e, 0xa5, 0xe5, 0x8f, 0xa3, 0xef, 0xbc, 0x8c, 0xe7, 0x94, 0xb1, 0x20, 0x67,
+	0x52, 0x50, 0x43, 0x2d, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x20, 0xe8, 0xbd, 0xac, 0xe5,
+	0x8f, 0x91, 0xe5, 0x88, 0xb0, 0x20, 0x67, 0x52, 0x50, 0x43, 0x20, 0xe6, 0x9c, 0x8d, 0xe5, 0x8a,
+	0xa1, 0x32, 0x02, 0x76, 0x31, 0x2a, 0x02, 0x01, 0x02, 0x32, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x3a, 0x10, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x5a, 0x07, 0x2e,
+	0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_stock_proto_rawDescOnce sync.Once
+	file_stock_proto_rawDescData []byte
+)
+
+func file_stock_proto_rawDescGZIP() []byte {
+	file_stock_proto_rawDescOnce.Do(func() {
+		file_stock_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stock_proto_rawDesc), len(file_stock_proto_rawDesc)))
+	})
+	return file_stock_proto_rawDescData
+}
+
+var file_stock_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_stock_proto_goTypes = []any{
+	(ReservationStatus)(0),          // 0: proto.ReservationStatus
+	(StockSortField)(0),             // 1: proto.StockSortField
+	(*Response)(nil),                // 2: proto.Response
+	(*GetStockReq)(nil),             // 3: proto.GetStockReq
+	(*GoodsStockInfo)(nil),          // 4: proto.GoodsStockInfo
+	(*ReduceStockInfo)(nil),         // 5: proto.ReduceStockInfo
+	(*RollBackStockInfo)(nil),       // 6: proto.RollBackStockInfo
+	(*StockInfoList)(nil),           // 7: proto.StockInfoList
+	(*ExportStockReq)(nil),          // 8: proto.ExportStockReq
+	(*StockDetail)(nil),             // 9: proto.StockDetail
+	(*StockRecordInfo)(nil),         // 10: proto.StockRecordInfo
+	(*ExportStockItem)(nil),         // 11: proto.ExportStockItem
+	(*ReconcileStockReq)(nil),       // 12: proto.ReconcileStockReq
+	(*LockMismatch)(nil),            // 13: proto.LockMismatch
+	(*ReconcileStockResp)(nil),      // 14: proto.ReconcileStockResp
+	(*DeleteStockReq)(nil),          // 15: proto.DeleteStockReq
+	(*UndeleteStockReq)(nil),        // 16: proto.UndeleteStockReq
+	(*GetOrderReservationsReq)(nil), // 17: proto.GetOrderReservationsReq
+	(*ListReservationsReq)(nil),     // 18: proto.ListReservationsReq
+	(*ReservationList)(nil),         // 19: proto.ReservationList
+	(*ListStockReq)(nil),            // 20: proto.ListStockReq
+	(*ListStockResp)(nil),           // 21: proto.ListStockResp
+	(*ConfirmStockInfo)(nil),        // 22: proto.ConfirmStockInfo
+}
+var file_stock_proto_depIdxs = []int32{
+	4,  // 0: proto.StockInfoList.data:type_name -> proto.GoodsStockInfo
+	0,  // 1: proto.StockRecordInfo.status:type_name -> proto.ReservationStatus
+	9,  // 2: proto.ExportStockItem.stock:type_name -> proto.StockDetail
+	10, // 3: proto.ExportStockItem.record:type_name -> proto.StockRecordInfo
+	13, // 4: proto.ReconcileStockResp.mismatches:type_name -> proto.LockMismatch
+	0,  // 5: proto.ListReservationsReq.status:type_name -> proto.ReservationStatus
+	10, // 6: proto.ReservationList.data:type_name -> proto.StockRecordInfo
+	1,  // 7: proto.ListStockReq.sort_by:type_name -> proto.StockSortField
+	9,  // 8: proto.ListStockResp.data:type_name -> proto.StockDetail
+	4,  // 9: proto.Stock.SetStock:input_type -> proto.GoodsStockInfo
+	3,  // 10: proto.Stock.GetStock:input_type -> proto.GetStockReq
+	5,  // 11: proto.Stock.ReduceStock:input_type -> proto.ReduceStockInfo
+	6,  // 12: proto.Stock.RollbackStock:input_type -> proto.RollBackStockInfo
+	7,  // 13: proto.Stock.BatchGetStock:input_type -> proto.StockInfoList
+	7,  // 14: proto.Stock.BatchReduceStock:input_type -> proto.StockInfoList
+	8,  // 15: proto.Stock.ExportStock:input_type -> proto.ExportStockReq
+	12, // 16: proto.Stock.ReconcileStock:input_type -> proto.ReconcileStockReq
+	15, // 17: proto.Stock.DeleteStock:input_type -> proto.DeleteStockReq
+	16, // 18: proto.Stock.UndeleteStock:input_type -> proto.UndeleteStockReq
+	17, // 19: proto.Stock.GetOrderReservations:input_type -> proto.GetOrderReservationsReq
+	18, // 20: proto.Stock.ListReservations:input_type -> proto.ListReservationsReq
+	20, // 21: proto.Stock.ListStock:input_type -> proto.ListStockReq
+	22, // 22: proto.Stock.ConfirmStock:input_type -> proto.ConfirmStockInfo
+	2,  // 23: proto.Stock.SetStock:output_type -> proto.Response
+	4,  // 24: proto.Stock.GetStock:output_type -> proto.GoodsStockInfo
+	2,  // 25: proto.Stock.ReduceStock:output_type -> proto.Response
+	2,  // 26: proto.Stock.RollbackStock:output_type -> proto.Response
+	7,  // 27: proto.Stock.BatchGetStock:output_type -> proto.StockInfoList
+	2,  // 28: proto.Stock.BatchReduceStock:output_type -> proto.Response
+	11, // 29: proto.Stock.ExportStock:output_type -> proto.ExportStockItem
+	14, // 30: proto.Stock.ReconcileStock:output_type -> proto.ReconcileStockResp
+	2,  // 31: proto.Stock.DeleteStock:output_type -> proto.Response
+	2,  // 32: proto.Stock.UndeleteStock:output_type -> proto.Response
+	19, // 33: proto.Stock.GetOrderReservations:output_type -> proto.ReservationList
+	19, // 34: proto.Stock.ListReservations:output_type -> proto.ReservationList
+	21, // 35: proto.Stock.ListStock:output_type -> proto.ListStockResp
+	2,  // 36: proto.Stock.ConfirmStock:output_type -> proto.Response
+	23, // [23:37] is the sub-list for method output_type
+	9,  // [9:23] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_stock_proto_init() }
+func file_stock_proto_init() {
+	if File_stock_proto != nil {
+		return
+	}
+	file_stock_proto_msgTypes[9].OneofWrappers = []any{
+		(*ExportStockItem_Stock)(nil),
+		(*ExportStockItem_Record)(nil),
 	}
+	file_stock_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stock_proto_rawDesc), len(file_stock_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_stock_proto_goTypes,
 		DependencyIndexes: file_stock_proto_depIdxs,
+		EnumInfos:         file_stock_proto_enumTypes,
 		MessageInfos:      file_stock_proto_msgTypes,
 	}.Build()
 	File_stock_proto = out.File
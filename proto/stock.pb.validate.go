@@ -0,0 +1,2753 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: stock.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Response with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Response) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Response with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResponseMultiError, or nil
+// if none found.
+func (m *Response) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Response) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	// no validation rules for Message
+
+	if len(errors) > 0 {
+		return ResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResponseMultiError is an error wrapping multiple validation errors returned
+// by Response.ValidateAll() if the designated constraints aren't met.
+type ResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResponseMultiError) AllErrors() []error { return m }
+
+// ResponseValidationError is the validation error returned by
+// Response.Validate if the designated constraints aren't met.
+type ResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResponseValidationError) ErrorName() string { return "ResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResponseValidationError{}
+
+// Validate checks the field values on GetStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetStockReqMultiError, or
+// nil if none found.
+func (m *GetStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := GetStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetStockReqMultiError is an error wrapping multiple validation errors
+// returned by GetStockReq.ValidateAll() if the designated constraints aren't met.
+type GetStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetStockReqMultiError) AllErrors() []error { return m }
+
+// GetStockReqValidationError is the validation error returned by
+// GetStockReq.Validate if the designated constraints aren't met.
+type GetStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetStockReqValidationError) ErrorName() string { return "GetStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetStockReqValidationError{}
+
+// Validate checks the field values on GoodsStockInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GoodsStockInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GoodsStockInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GoodsStockInfoMultiError,
+// or nil if none found.
+func (m *GoodsStockInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GoodsStockInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := GoodsStockInfoValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetStock() < 0 {
+		err := GoodsStockInfoValidationError{
+			field:  "Stock",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Exists
+
+	if len(errors) > 0 {
+		return GoodsStockInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// GoodsStockInfoMultiError is an error wrapping multiple validation errors
+// returned by GoodsStockInfo.ValidateAll() if the designated constraints
+// aren't met.
+type GoodsStockInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GoodsStockInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GoodsStockInfoMultiError) AllErrors() []error { return m }
+
+// GoodsStockInfoValidationError is the validation error returned by
+// GoodsStockInfo.Validate if the designated constraints aren't met.
+type GoodsStockInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GoodsStockInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GoodsStockInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GoodsStockInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GoodsStockInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GoodsStockInfoValidationError) ErrorName() string { return "GoodsStockInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GoodsStockInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGoodsStockInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GoodsStockInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GoodsStockInfoValidationError{}
+
+// Validate checks the field values on ReduceStockInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReduceStockInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReduceStockInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReduceStockInfoMultiError, or nil if none found.
+func (m *ReduceStockInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReduceStockInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := ReduceStockInfoValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetNum() <= 0 {
+		err := ReduceStockInfoValidationError{
+			field:  "Num",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for OrderId
+
+	if m.GetAutoReleaseSeconds() < 0 {
+		err := ReduceStockInfoValidationError{
+			field:  "AutoReleaseSeconds",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReduceStockInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReduceStockInfoMultiError is an error wrapping multiple validation errors
+// returned by ReduceStockInfo.ValidateAll() if the designated constraints
+// aren't met.
+type ReduceStockInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReduceStockInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReduceStockInfoMultiError) AllErrors() []error { return m }
+
+// ReduceStockInfoValidationError is the validation error returned by
+// ReduceStockInfo.Validate if the designated constraints aren't met.
+type ReduceStockInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReduceStockInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReduceStockInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReduceStockInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReduceStockInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReduceStockInfoValidationError) ErrorName() string { return "ReduceStockInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReduceStockInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReduceStockInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReduceStockInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReduceStockInfoValidationError{}
+
+// Validate checks the field values on RollBackStockInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RollBackStockInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RollBackStockInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RollBackStockInfoMultiError, or nil if none found.
+func (m *RollBackStockInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RollBackStockInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := RollBackStockInfoValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRollbackNum() <= 0 {
+		err := RollBackStockInfoValidationError{
+			field:  "RollbackNum",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetOrderId() <= 0 {
+		err := RollBackStockInfoValidationError{
+			field:  "OrderId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RollBackStockInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// RollBackStockInfoMultiError is an error wrapping multiple validation errors
+// returned by RollBackStockInfo.ValidateAll() if the designated constraints
+// aren't met.
+type RollBackStockInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RollBackStockInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RollBackStockInfoMultiError) AllErrors() []error { return m }
+
+// RollBackStockInfoValidationError is the validation error returned by
+// RollBackStockInfo.Validate if the designated constraints aren't met.
+type RollBackStockInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RollBackStockInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RollBackStockInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RollBackStockInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RollBackStockInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RollBackStockInfoValidationError) ErrorName() string {
+	return "RollBackStockInfoValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RollBackStockInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRollBackStockInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RollBackStockInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RollBackStockInfoValidationError{}
+
+// Validate checks the field values on StockInfoList with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StockInfoList) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StockInfoList with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StockInfoListMultiError, or
+// nil if none found.
+func (m *StockInfoList) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StockInfoList) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetData()); l < 1 || l > 100 {
+		err := StockInfoListValidationError{
+			field:  "Data",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, StockInfoListValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, StockInfoListValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return StockInfoListValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return StockInfoListMultiError(errors)
+	}
+
+	return nil
+}
+
+// StockInfoListMultiError is an error wrapping multiple validation errors
+// returned by StockInfoList.ValidateAll() if the designated constraints
+// aren't met.
+type StockInfoListMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StockInfoListMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StockInfoListMultiError) AllErrors() []error { return m }
+
+// StockInfoListValidationError is the validation error returned by
+// StockInfoList.Validate if the designated constraints aren't met.
+type StockInfoListValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StockInfoListValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StockInfoListValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StockInfoListValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StockInfoListValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StockInfoListValidationError) ErrorName() string { return "StockInfoListValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StockInfoListValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStockInfoList.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StockInfoListValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StockInfoListValidationError{}
+
+// Validate checks the field values on ExportStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ExportStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExportStockReqMultiError,
+// or nil if none found.
+func (m *ExportStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMinGoodsId() < 0 {
+		err := ExportStockReqValidationError{
+			field:  "MinGoodsId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMaxGoodsId() < 0 {
+		err := ExportStockReqValidationError{
+			field:  "MaxGoodsId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetUpdatedSince() < 0 {
+		err := ExportStockReqValidationError{
+			field:  "UpdatedSince",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for OnlyNonZero
+
+	// no validation rules for WithRecords
+
+	if len(errors) > 0 {
+		return ExportStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportStockReqMultiError is an error wrapping multiple validation errors
+// returned by ExportStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type ExportStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportStockReqMultiError) AllErrors() []error { return m }
+
+// ExportStockReqValidationError is the validation error returned by
+// ExportStockReq.Validate if the designated constraints aren't met.
+type ExportStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportStockReqValidationError) ErrorName() string { return "ExportStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportStockReqValidationError{}
+
+// Validate checks the field values on StockDetail with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StockDetail) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StockDetail with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StockDetailMultiError, or
+// nil if none found.
+func (m *StockDetail) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StockDetail) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for GoodsId
+
+	// no validation rules for Stock
+
+	// no validation rules for Lock
+
+	// no validation rules for UpdateAt
+
+	// no validation rules for Available
+
+	if len(errors) > 0 {
+		return StockDetailMultiError(errors)
+	}
+
+	return nil
+}
+
+// StockDetailMultiError is an error wrapping multiple validation errors
+// returned by StockDetail.ValidateAll() if the designated constraints aren't met.
+type StockDetailMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StockDetailMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StockDetailMultiError) AllErrors() []error { return m }
+
+// StockDetailValidationError is the validation error returned by
+// StockDetail.Validate if the designated constraints aren't met.
+type StockDetailValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StockDetailValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StockDetailValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StockDetailValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StockDetailValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StockDetailValidationError) ErrorName() string { return "StockDetailValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StockDetailValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStockDetail.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StockDetailValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StockDetailValidationError{}
+
+// Validate checks the field values on StockRecordInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *StockRecordInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StockRecordInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StockRecordInfoMultiError, or nil if none found.
+func (m *StockRecordInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StockRecordInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for OrderId
+
+	// no validation rules for GoodsId
+
+	// no validation rules for Num
+
+	// no validation rules for Status
+
+	// no validation rules for CreateAt
+
+	// no validation rules for UpdateAt
+
+	if len(errors) > 0 {
+		return StockRecordInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// StockRecordInfoMultiError is an error wrapping multiple validation errors
+// returned by StockRecordInfo.ValidateAll() if the designated constraints
+// aren't met.
+type StockRecordInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StockRecordInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StockRecordInfoMultiError) AllErrors() []error { return m }
+
+// StockRecordInfoValidationError is the validation error returned by
+// StockRecordInfo.Validate if the designated constraints aren't met.
+type StockRecordInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StockRecordInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StockRecordInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StockRecordInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StockRecordInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StockRecordInfoValidationError) ErrorName() string { return "StockRecordInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StockRecordInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStockRecordInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StockRecordInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StockRecordInfoValidationError{}
+
+// Validate checks the field values on ExportStockItem with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ExportStockItem) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportStockItem with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportStockItemMultiError, or nil if none found.
+func (m *ExportStockItem) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportStockItem) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	switch v := m.Item.(type) {
+	case *ExportStockItem_Stock:
+		if v == nil {
+			err := ExportStockItemValidationError{
+				field:  "Item",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetStock()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ExportStockItemValidationError{
+						field:  "Stock",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ExportStockItemValidationError{
+						field:  "Stock",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetStock()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ExportStockItemValidationError{
+					field:  "Stock",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ExportStockItem_Record:
+		if v == nil {
+			err := ExportStockItemValidationError{
+				field:  "Item",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetRecord()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ExportStockItemValidationError{
+						field:  "Record",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ExportStockItemValidationError{
+						field:  "Record",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRecord()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ExportStockItemValidationError{
+					field:  "Record",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return ExportStockItemMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportStockItemMultiError is an error wrapping multiple validation errors
+// returned by ExportStockItem.ValidateAll() if the designated constraints
+// aren't met.
+type ExportStockItemMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportStockItemMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportStockItemMultiError) AllErrors() []error { return m }
+
+// ExportStockItemValidationError is the validation error returned by
+// ExportStockItem.Validate if the designated constraints aren't met.
+type ExportStockItemValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportStockItemValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportStockItemValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportStockItemValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportStockItemValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportStockItemValidationError) ErrorName() string { return "ExportStockItemValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportStockItemValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportStockItem.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportStockItemValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportStockItemValidationError{}
+
+// Validate checks the field values on ReconcileStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReconcileStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReconcileStockReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReconcileStockReqMultiError, or nil if none found.
+func (m *ReconcileStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReconcileStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetGoodsIds() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := ReconcileStockReqValidationError{
+				field:  fmt.Sprintf("GoodsIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Repair
+
+	if len(errors) > 0 {
+		return ReconcileStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReconcileStockReqMultiError is an error wrapping multiple validation errors
+// returned by ReconcileStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type ReconcileStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReconcileStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReconcileStockReqMultiError) AllErrors() []error { return m }
+
+// ReconcileStockReqValidationError is the validation error returned by
+// ReconcileStockReq.Validate if the designated constraints aren't met.
+type ReconcileStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReconcileStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReconcileStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReconcileStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReconcileStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReconcileStockReqValidationError) ErrorName() string {
+	return "ReconcileStockReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReconcileStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReconcileStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReconcileStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReconcileStockReqValidationError{}
+
+// Validate checks the field values on LockMismatch with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LockMismatch) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LockMismatch with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LockMismatchMultiError, or
+// nil if none found.
+func (m *LockMismatch) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LockMismatch) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for GoodsId
+
+	// no validation rules for Lock
+
+	// no validation rules for ExpectedLock
+
+	// no validation rules for Repaired
+
+	if len(errors) > 0 {
+		return LockMismatchMultiError(errors)
+	}
+
+	return nil
+}
+
+// LockMismatchMultiError is an error wrapping multiple validation errors
+// returned by LockMismatch.ValidateAll() if the designated constraints aren't met.
+type LockMismatchMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LockMismatchMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LockMismatchMultiError) AllErrors() []error { return m }
+
+// LockMismatchValidationError is the validation error returned by
+// LockMismatch.Validate if the designated constraints aren't met.
+type LockMismatchValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LockMismatchValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LockMismatchValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LockMismatchValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LockMismatchValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LockMismatchValidationError) ErrorName() string { return "LockMismatchValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LockMismatchValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLockMismatch.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LockMismatchValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LockMismatchValidationError{}
+
+// Validate checks the field values on ReconcileStockResp with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReconcileStockResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReconcileStockResp with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReconcileStockRespMultiError, or nil if none found.
+func (m *ReconcileStockResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReconcileStockResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetMismatches() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ReconcileStockRespValidationError{
+						field:  fmt.Sprintf("Mismatches[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ReconcileStockRespValidationError{
+						field:  fmt.Sprintf("Mismatches[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ReconcileStockRespValidationError{
+					field:  fmt.Sprintf("Mismatches[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ReconcileStockRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReconcileStockRespMultiError is an error wrapping multiple validation errors
+// returned by ReconcileStockResp.ValidateAll() if the designated constraints
+// aren't met.
+type ReconcileStockRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReconcileStockRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReconcileStockRespMultiError) AllErrors() []error { return m }
+
+// ReconcileStockRespValidationError is the validation error returned by
+// ReconcileStockResp.Validate if the designated constraints aren't met.
+type ReconcileStockRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReconcileStockRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReconcileStockRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReconcileStockRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReconcileStockRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReconcileStockRespValidationError) ErrorName() string {
+	return "ReconcileStockRespValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReconcileStockRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReconcileStockResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReconcileStockRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReconcileStockRespValidationError{}
+
+// Validate checks the field values on DeleteStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DeleteStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DeleteStockReqMultiError,
+// or nil if none found.
+func (m *DeleteStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := DeleteStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteStockReqMultiError is an error wrapping multiple validation errors
+// returned by DeleteStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteStockReqMultiError) AllErrors() []error { return m }
+
+// DeleteStockReqValidationError is the validation error returned by
+// DeleteStockReq.Validate if the designated constraints aren't met.
+type DeleteStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteStockReqValidationError) ErrorName() string { return "DeleteStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DeleteStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteStockReqValidationError{}
+
+// Validate checks the field values on UndeleteStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UndeleteStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UndeleteStockReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UndeleteStockReqMultiError, or nil if none found.
+func (m *UndeleteStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UndeleteStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := UndeleteStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UndeleteStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// UndeleteStockReqMultiError is an error wrapping multiple validation errors
+// returned by UndeleteStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type UndeleteStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UndeleteStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UndeleteStockReqMultiError) AllErrors() []error { return m }
+
+// UndeleteStockReqValidationError is the validation error returned by
+// UndeleteStockReq.Validate if the designated constraints aren't met.
+type UndeleteStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UndeleteStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UndeleteStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UndeleteStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UndeleteStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UndeleteStockReqValidationError) ErrorName() string { return "UndeleteStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UndeleteStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUndeleteStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UndeleteStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UndeleteStockReqValidationError{}
+
+// Validate checks the field values on GetOrderReservationsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOrderReservationsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOrderReservationsReq with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOrderReservationsReqMultiError, or nil if none found.
+func (m *GetOrderReservationsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOrderReservationsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetOrderId() <= 0 {
+		err := GetOrderReservationsReqValidationError{
+			field:  "OrderId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetOrderReservationsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetOrderReservationsReqMultiError is an error wrapping multiple validation
+// errors returned by GetOrderReservationsReq.ValidateAll() if the designated
+// constraints aren't met.
+type GetOrderReservationsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOrderReservationsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOrderReservationsReqMultiError) AllErrors() []error { return m }
+
+// GetOrderReservationsReqValidationError is the validation error returned by
+// GetOrderReservationsReq.Validate if the designated constraints aren't met.
+type GetOrderReservationsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOrderReservationsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOrderReservationsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOrderReservationsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOrderReservationsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOrderReservationsReqValidationError) ErrorName() string {
+	return "GetOrderReservationsReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOrderReservationsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOrderReservationsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOrderReservationsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOrderReservationsReqValidationError{}
+
+// Validate checks the field values on ListReservationsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListReservationsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListReservationsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListReservationsReqMultiError, or nil if none found.
+func (m *ListReservationsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListReservationsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() < 0 {
+		err := ListReservationsReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := ReservationStatus_name[int32(m.GetStatus())]; !ok {
+		err := ListReservationsReqValidationError{
+			field:  "Status",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetStartTime() < 0 {
+		err := ListReservationsReqValidationError{
+			field:  "StartTime",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEndTime() < 0 {
+		err := ListReservationsReqValidationError{
+			field:  "EndTime",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPage() < 0 {
+		err := ListReservationsReqValidationError{
+			field:  "Page",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPageSize() < 0 {
+		err := ListReservationsReqValidationError{
+			field:  "PageSize",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListReservationsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListReservationsReqMultiError is an error wrapping multiple validation
+// errors returned by ListReservationsReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListReservationsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListReservationsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListReservationsReqMultiError) AllErrors() []error { return m }
+
+// ListReservationsReqValidationError is the validation error returned by
+// ListReservationsReq.Validate if the designated constraints aren't met.
+type ListReservationsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListReservationsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListReservationsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListReservationsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListReservationsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListReservationsReqValidationError) ErrorName() string {
+	return "ListReservationsReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListReservationsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListReservationsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListReservationsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListReservationsReqValidationError{}
+
+// Validate checks the field values on ReservationList with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ReservationList) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReservationList with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReservationListMultiError, or nil if none found.
+func (m *ReservationList) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReservationList) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ReservationListValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ReservationListValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ReservationListValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ReservationListMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReservationListMultiError is an error wrapping multiple validation errors
+// returned by ReservationList.ValidateAll() if the designated constraints
+// aren't met.
+type ReservationListMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReservationListMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReservationListMultiError) AllErrors() []error { return m }
+
+// ReservationListValidationError is the validation error returned by
+// ReservationList.Validate if the designated constraints aren't met.
+type ReservationListValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReservationListValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReservationListValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReservationListValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReservationListValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReservationListValidationError) ErrorName() string { return "ReservationListValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReservationListValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReservationList.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReservationListValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReservationListValidationError{}
+
+// Validate checks the field values on ListStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ListStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ListStockReqMultiError, or
+// nil if none found.
+func (m *ListStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for LockedOnly
+
+	if m.GetUpdatedSince() < 0 {
+		err := ListStockReqValidationError{
+			field:  "UpdatedSince",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetGoodsIds() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := ListStockReqValidationError{
+				field:  fmt.Sprintf("GoodsIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if _, ok := StockSortField_name[int32(m.GetSortBy())]; !ok {
+		err := ListStockReqValidationError{
+			field:  "SortBy",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Desc
+
+	// no validation rules for Cursor
+
+	if m.GetPageSize() < 0 {
+		err := ListStockReqValidationError{
+			field:  "PageSize",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.AvailableBelow != nil {
+		// no validation rules for AvailableBelow
+	}
+
+	if len(errors) > 0 {
+		return ListStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListStockReqMultiError is an error wrapping multiple validation errors
+// returned by ListStockReq.ValidateAll() if the designated constraints aren't met.
+type ListStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListStockReqMultiError) AllErrors() []error { return m }
+
+// ListStockReqValidationError is the validation error returned by
+// ListStockReq.Validate if the designated constraints aren't met.
+type ListStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListStockReqValidationError) ErrorName() string { return "ListStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListStockReqValidationError{}
+
+// Validate checks the field values on ListStockResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ListStockResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListStockResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ListStockRespMultiError, or
+// nil if none found.
+func (m *ListStockResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListStockResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListStockRespValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListStockRespValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListStockRespValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextCursor
+
+	if len(errors) > 0 {
+		return ListStockRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListStockRespMultiError is an error wrapping multiple validation errors
+// returned by ListStockResp.ValidateAll() if the designated constraints
+// aren't met.
+type ListStockRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListStockRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListStockRespMultiError) AllErrors() []error { return m }
+
+// ListStockRespValidationError is the validation error returned by
+// ListStockResp.Validate if the designated constraints aren't met.
+type ListStockRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListStockRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListStockRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListStockRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListStockRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListStockRespValidationError) ErrorName() string { return "ListStockRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListStockRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListStockResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListStockRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListStockRespValidationError{}
+
+// Validate checks the field values on ConfirmStockInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmStockInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfirmStockInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmStockInfoMultiError, or nil if none found.
+func (m *ConfirmStockInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfirmStockInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := ConfirmStockInfoValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetOrderId() <= 0 {
+		err := ConfirmStockInfoValidationError{
+			field:  "OrderId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ConfirmStockInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfirmStockInfoMultiError is an error wrapping multiple validation errors
+// returned by ConfirmStockInfo.ValidateAll() if the designated constraints
+// aren't met.
+type ConfirmStockInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmStockInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmStockInfoMultiError) AllErrors() []error { return m }
+
+// ConfirmStockInfoValidationError is the validation error returned by
+// ConfirmStockInfo.Validate if the designated constraints aren't met.
+type ConfirmStockInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmStockInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmStockInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmStockInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmStockInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmStockInfoValidationError) ErrorName() string { return "ConfirmStockInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ConfirmStockInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmStockInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmStockInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmStockInfoValidationError{}
@@ -19,32 +19,56 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Stock_SetStock_FullMethodName         = "/proto.Stock/SetStock"
-	Stock_GetStock_FullMethodName         = "/proto.Stock/GetStock"
-	Stock_ReduceStock_FullMethodName      = "/proto.Stock/ReduceStock"
-	Stock_RollbackStock_FullMethodName    = "/proto.Stock/RollbackStock"
-	Stock_BatchGetStock_FullMethodName    = "/proto.Stock/BatchGetStock"
-	Stock_BatchReduceStock_FullMethodName = "/proto.Stock/BatchReduceStock"
+	Stock_SetStock_FullMethodName             = "/proto.Stock/SetStock"
+	Stock_GetStock_FullMethodName             = "/proto.Stock/GetStock"
+	Stock_ReduceStock_FullMethodName          = "/proto.Stock/ReduceStock"
+	Stock_RollbackStock_FullMethodName        = "/proto.Stock/RollbackStock"
+	Stock_BatchGetStock_FullMethodName        = "/proto.Stock/BatchGetStock"
+	Stock_BatchReduceStock_FullMethodName     = "/proto.Stock/BatchReduceStock"
+	Stock_ExportStock_FullMethodName          = "/proto.Stock/ExportStock"
+	Stock_ReconcileStock_FullMethodName       = "/proto.Stock/ReconcileStock"
+	Stock_DeleteStock_FullMethodName          = "/proto.Stock/DeleteStock"
+	Stock_UndeleteStock_FullMethodName        = "/proto.Stock/UndeleteStock"
+	Stock_GetOrderReservations_FullMethodName = "/proto.Stock/GetOrderReservations"
+	Stock_ListReservations_FullMethodName     = "/proto.Stock/ListReservations"
+	Stock_ListStock_FullMethodName            = "/proto.Stock/ListStock"
+	Stock_ConfirmStock_FullMethodName         = "/proto.Stock/ConfirmStock"
 )
 
 // StockClient is the client API for Stock service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// 搴撳瓨鏈嶅姟
+// 库存服务
 type StockClient interface {
-	// 璁剧疆搴撳瓨
+	// 设置库存
 	SetStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*Response, error)
-	// 鑾峰彇搴撳瓨
+	// 获取库存
 	GetStock(ctx context.Context, in *GetStockReq, opts ...grpc.CallOption) (*GoodsStockInfo, error)
-	// 鍑忓皯搴撳瓨
+	// 减少库存
 	ReduceStock(ctx context.Context, in *ReduceStockInfo, opts ...grpc.CallOption) (*Response, error)
-	// 鍥炴粴搴撳瓨
+	// 回滚库存
 	RollbackStock(ctx context.Context, in *RollBackStockInfo, opts ...grpc.CallOption) (*Response, error)
-	// 鎵归噺鑾峰彇搴撳瓨
+	// 批量获取库存
 	BatchGetStock(ctx context.Context, in *StockInfoList, opts ...grpc.CallOption) (*StockInfoList, error)
-	// 鎵归噺鍑忓皯搴撳瓨
+	// 批量减少库存（未实现，返回 Unimplemented；请求中没有订单ID，无法生成库存记录，不提供 HTTP 接口）
 	BatchReduceStock(ctx context.Context, in *StockInfoList, opts ...grpc.CallOption) (*Response, error)
+	// 导出库存快照（服务端流式）
+	ExportStock(ctx context.Context, in *ExportStockReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportStockItem], error)
+	// 核对预扣库存与未结库存记录，可选修复
+	ReconcileStock(ctx context.Context, in *ReconcileStockReq, opts ...grpc.CallOption) (*ReconcileStockResp, error)
+	// 删除库存（软删除）
+	DeleteStock(ctx context.Context, in *DeleteStockReq, opts ...grpc.CallOption) (*Response, error)
+	// 恢复已删除的库存
+	UndeleteStock(ctx context.Context, in *UndeleteStockReq, opts ...grpc.CallOption) (*Response, error)
+	// 查询订单的库存预占记录
+	GetOrderReservations(ctx context.Context, in *GetOrderReservationsReq, opts ...grpc.CallOption) (*ReservationList, error)
+	// 分页查询库存预占记录
+	ListReservations(ctx context.Context, in *ListReservationsReq, opts ...grpc.CallOption) (*ReservationList, error)
+	// 分页查询库存列表
+	ListStock(ctx context.Context, in *ListStockReq, opts ...grpc.CallOption) (*ListStockResp, error)
+	// 确认扣减库存（订单支付后调用）
+	ConfirmStock(ctx context.Context, in *ConfirmStockInfo, opts ...grpc.CallOption) (*Response, error)
 }
 
 type stockClient struct {
@@ -115,24 +139,129 @@ func (c *stockClient) BatchReduceStock(ctx context.Context, in *StockInfoList, o
 	return out, nil
 }
 
+func (c *stockClient) ExportStock(ctx context.Context, in *ExportStockReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportStockItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Stock_ServiceDesc.Streams[0], Stock_ExportStock_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportStockReq, ExportStockItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Stock_ExportStockClient = grpc.ServerStreamingClient[ExportStockItem]
+
+func (c *stockClient) ReconcileStock(ctx context.Context, in *ReconcileStockReq, opts ...grpc.CallOption) (*ReconcileStockResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileStockResp)
+	err := c.cc.Invoke(ctx, Stock_ReconcileStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) DeleteStock(ctx context.Context, in *DeleteStockReq, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Stock_DeleteStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) UndeleteStock(ctx context.Context, in *UndeleteStockReq, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Stock_UndeleteStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) GetOrderReservations(ctx context.Context, in *GetOrderReservationsReq, opts ...grpc.CallOption) (*ReservationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationList)
+	err := c.cc.Invoke(ctx, Stock_GetOrderReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ListReservations(ctx context.Context, in *ListReservationsReq, opts ...grpc.CallOption) (*ReservationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationList)
+	err := c.cc.Invoke(ctx, Stock_ListReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ListStock(ctx context.Context, in *ListStockReq, opts ...grpc.CallOption) (*ListStockResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockResp)
+	err := c.cc.Invoke(ctx, Stock_ListStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ConfirmStock(ctx context.Context, in *ConfirmStockInfo, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Stock_ConfirmStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StockServer is the server API for Stock service.
 // All implementations must embed UnimplementedStockServer
 // for forward compatibility.
 //
-// 搴撳瓨鏈嶅姟
+// 库存服务
 type StockServer interface {
-	// 璁剧疆搴撳瓨
+	// 设置库存
 	SetStock(context.Context, *GoodsStockInfo) (*Response, error)
-	// 鑾峰彇搴撳瓨
+	// 获取库存
 	GetStock(context.Context, *GetStockReq) (*GoodsStockInfo, error)
-	// 鍑忓皯搴撳瓨
+	// 减少库存
 	ReduceStock(context.Context, *ReduceStockInfo) (*Response, error)
-	// 鍥炴粴搴撳瓨
+	// 回滚库存
 	RollbackStock(context.Context, *RollBackStockInfo) (*Response, error)
-	// 鎵归噺鑾峰彇搴撳瓨
+	// 批量获取库存
 	BatchGetStock(context.Context, *StockInfoList) (*StockInfoList, error)
-	// 鎵归噺鍑忓皯搴撳瓨
+	// 批量减少库存（未实现，返回 Unimplemented；请求中没有订单ID，无法生成库存记录，不提供 HTTP 接口）
 	BatchReduceStock(context.Context, *StockInfoList) (*Response, error)
+	// 导出库存快照（服务端流式）
+	ExportStock(*ExportStockReq, grpc.ServerStreamingServer[ExportStockItem]) error
+	// 核对预扣库存与未结库存记录，可选修复
+	ReconcileStock(context.Context, *ReconcileStockReq) (*ReconcileStockResp, error)
+	// 删除库存（软删除）
+	DeleteStock(context.Context, *DeleteStockReq) (*Response, error)
+	// 恢复已删除的库存
+	UndeleteStock(context.Context, *UndeleteStockReq) (*Response, error)
+	// 查询订单的库存预占记录
+	GetOrderReservations(context.Context, *GetOrderReservationsReq) (*ReservationList, error)
+	// 分页查询库存预占记录
+	ListReservations(context.Context, *ListReservationsReq) (*ReservationList, error)
+	// 分页查询库存列表
+	ListStock(context.Context, *ListStockReq) (*ListStockResp, error)
+	// 确认扣减库存（订单支付后调用）
+	ConfirmStock(context.Context, *ConfirmStockInfo) (*Response, error)
 	mustEmbedUnimplementedStockServer()
 }
 
@@ -161,6 +290,30 @@ func (UnimplementedStockServer) BatchGetStock(context.Context, *StockInfoList) (
 func (UnimplementedStockServer) BatchReduceStock(context.Context, *StockInfoList) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchReduceStock not implemented")
 }
+func (UnimplementedStockServer) ExportStock(*ExportStockReq, grpc.ServerStreamingServer[ExportStockItem]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStock not implemented")
+}
+func (UnimplementedStockServer) ReconcileStock(context.Context, *ReconcileStockReq) (*ReconcileStockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileStock not implemented")
+}
+func (UnimplementedStockServer) DeleteStock(context.Context, *DeleteStockReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStock not implemented")
+}
+func (UnimplementedStockServer) UndeleteStock(context.Context, *UndeleteStockReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteStock not implemented")
+}
+func (UnimplementedStockServer) GetOrderReservations(context.Context, *GetOrderReservationsReq) (*ReservationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderReservations not implemented")
+}
+func (UnimplementedStockServer) ListReservations(context.Context, *ListReservationsReq) (*ReservationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (UnimplementedStockServer) ListStock(context.Context, *ListStockReq) (*ListStockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStock not implemented")
+}
+func (UnimplementedStockServer) ConfirmStock(context.Context, *ConfirmStockInfo) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmStock not implemented")
+}
 func (UnimplementedStockServer) mustEmbedUnimplementedStockServer() {}
 func (UnimplementedStockServer) testEmbeddedByValue()               {}
 
@@ -290,6 +443,143 @@ func _Stock_BatchReduceStock_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Stock_ExportStock_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStockReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServer).ExportStock(m, &grpc.GenericServerStream[ExportStockReq, ExportStockItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Stock_ExportStockServer = grpc.ServerStreamingServer[ExportStockItem]
+
+func _Stock_ReconcileStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ReconcileStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_ReconcileStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ReconcileStock(ctx, req.(*ReconcileStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_DeleteStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).DeleteStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_DeleteStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).DeleteStock(ctx, req.(*DeleteStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_UndeleteStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).UndeleteStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_UndeleteStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).UndeleteStock(ctx, req.(*UndeleteStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_GetOrderReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderReservationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).GetOrderReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_GetOrderReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).GetOrderReservations(ctx, req.(*GetOrderReservationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_ListReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ListReservations(ctx, req.(*ListReservationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ListStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ListStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_ListStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ListStock(ctx, req.(*ListStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ConfirmStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmStockInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ConfirmStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_ConfirmStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ConfirmStock(ctx, req.(*ConfirmStockInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Stock_ServiceDesc is the grpc.ServiceDesc for Stock service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -321,7 +611,41 @@ var Stock_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchReduceStock",
 			Handler:    _Stock_BatchReduceStock_Handler,
 		},
+		{
+			MethodName: "ReconcileStock",
+			Handler:    _Stock_ReconcileStock_Handler,
+		},
+		{
+			MethodName: "DeleteStock",
+			Handler:    _Stock_DeleteStock_Handler,
+		},
+		{
+			MethodName: "UndeleteStock",
+			Handler:    _Stock_UndeleteStock_Handler,
+		},
+		{
+			MethodName: "GetOrderReservations",
+			Handler:    _Stock_GetOrderReservations_Handler,
+		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _Stock_ListReservations_Handler,
+		},
+		{
+			MethodName: "ListStock",
+			Handler:    _Stock_ListStock_Handler,
+		},
+		{
+			MethodName: "ConfirmStock",
+			Handler:    _Stock_ConfirmStock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportStock",
+			Handler:       _Stock_ExportStock_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "stock.proto",
 }
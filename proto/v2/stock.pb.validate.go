@@ -0,0 +1,2522 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: v2/stock.proto
+
+package stockv2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on RequestMeta with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RequestMeta) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestMeta with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RequestMetaMultiError, or
+// nil if none found.
+func (m *RequestMeta) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestMeta) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RequestId
+
+	if utf8.RuneCountInString(m.GetIdempotencyKey()) > 128 {
+		err := RequestMetaValidationError{
+			field:  "IdempotencyKey",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RequestMetaMultiError(errors)
+	}
+
+	return nil
+}
+
+// RequestMetaMultiError is an error wrapping multiple validation errors
+// returned by RequestMeta.ValidateAll() if the designated constraints aren't met.
+type RequestMetaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestMetaMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestMetaMultiError) AllErrors() []error { return m }
+
+// RequestMetaValidationError is the validation error returned by
+// RequestMeta.Validate if the designated constraints aren't met.
+type RequestMetaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestMetaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestMetaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestMetaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestMetaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestMetaValidationError) ErrorName() string { return "RequestMetaValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RequestMetaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestMeta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestMetaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestMetaValidationError{}
+
+// Validate checks the field values on StockState with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StockState) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StockState with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StockStateMultiError, or
+// nil if none found.
+func (m *StockState) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StockState) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for GoodsId
+
+	// no validation rules for Available
+
+	// no validation rules for Locked
+
+	// no validation rules for Total
+
+	// no validation rules for UpdateAt
+
+	if len(errors) > 0 {
+		return StockStateMultiError(errors)
+	}
+
+	return nil
+}
+
+// StockStateMultiError is an error wrapping multiple validation errors
+// returned by StockState.ValidateAll() if the designated constraints aren't met.
+type StockStateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StockStateMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StockStateMultiError) AllErrors() []error { return m }
+
+// StockStateValidationError is the validation error returned by
+// StockState.Validate if the designated constraints aren't met.
+type StockStateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StockStateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StockStateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StockStateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StockStateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StockStateValidationError) ErrorName() string { return "StockStateValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StockStateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStockState.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StockStateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StockStateValidationError{}
+
+// Validate checks the field values on MutationResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *MutationResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MutationResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in MutationRespMultiError, or
+// nil if none found.
+func (m *MutationResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MutationResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RequestId
+
+	if all {
+		switch v := interface{}(m.GetStock()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MutationRespValidationError{
+					field:  "Stock",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MutationRespValidationError{
+					field:  "Stock",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStock()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MutationRespValidationError{
+				field:  "Stock",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for RecordId
+
+	// no validation rules for RecordStatus
+
+	// no validation rules for Replayed
+
+	if len(errors) > 0 {
+		return MutationRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// MutationRespMultiError is an error wrapping multiple validation errors
+// returned by MutationResp.ValidateAll() if the designated constraints aren't met.
+type MutationRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MutationRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MutationRespMultiError) AllErrors() []error { return m }
+
+// MutationRespValidationError is the validation error returned by
+// MutationResp.Validate if the designated constraints aren't met.
+type MutationRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MutationRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MutationRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MutationRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MutationRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MutationRespValidationError) ErrorName() string { return "MutationRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MutationRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMutationResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MutationRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MutationRespValidationError{}
+
+// Validate checks the field values on GetStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetStockReqMultiError, or
+// nil if none found.
+func (m *GetStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := GetStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetStockReqMultiError is an error wrapping multiple validation errors
+// returned by GetStockReq.ValidateAll() if the designated constraints aren't met.
+type GetStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetStockReqMultiError) AllErrors() []error { return m }
+
+// GetStockReqValidationError is the validation error returned by
+// GetStockReq.Validate if the designated constraints aren't met.
+type GetStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetStockReqValidationError) ErrorName() string { return "GetStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetStockReqValidationError{}
+
+// Validate checks the field values on BatchGetStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetStockReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetStockReqMultiError, or nil if none found.
+func (m *BatchGetStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetGoodsIds()); l < 1 || l > 100 {
+		err := BatchGetStockReqValidationError{
+			field:  "GoodsIds",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetGoodsIds() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := BatchGetStockReqValidationError{
+				field:  fmt.Sprintf("GoodsIds[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetStockReqMultiError is an error wrapping multiple validation errors
+// returned by BatchGetStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type BatchGetStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetStockReqMultiError) AllErrors() []error { return m }
+
+// BatchGetStockReqValidationError is the validation error returned by
+// BatchGetStockReq.Validate if the designated constraints aren't met.
+type BatchGetStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetStockReqValidationError) ErrorName() string { return "BatchGetStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BatchGetStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetStockReqValidationError{}
+
+// Validate checks the field values on BatchStockItem with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BatchStockItem) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchStockItem with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BatchStockItemMultiError,
+// or nil if none found.
+func (m *BatchStockItem) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchStockItem) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for GoodsId
+
+	// no validation rules for Exists
+
+	if all {
+		switch v := interface{}(m.GetStock()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BatchStockItemValidationError{
+					field:  "Stock",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BatchStockItemValidationError{
+					field:  "Stock",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStock()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchStockItemValidationError{
+				field:  "Stock",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return BatchStockItemMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchStockItemMultiError is an error wrapping multiple validation errors
+// returned by BatchStockItem.ValidateAll() if the designated constraints
+// aren't met.
+type BatchStockItemMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchStockItemMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchStockItemMultiError) AllErrors() []error { return m }
+
+// BatchStockItemValidationError is the validation error returned by
+// BatchStockItem.Validate if the designated constraints aren't met.
+type BatchStockItemValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchStockItemValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchStockItemValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchStockItemValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchStockItemValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchStockItemValidationError) ErrorName() string { return "BatchStockItemValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BatchStockItemValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchStockItem.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchStockItemValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchStockItemValidationError{}
+
+// Validate checks the field values on BatchGetStockResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetStockResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchGetStockResp with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetStockRespMultiError, or nil if none found.
+func (m *BatchGetStockResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchGetStockResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetStockRespValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetStockRespValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchGetStockRespValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchGetStockRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetStockRespMultiError is an error wrapping multiple validation errors
+// returned by BatchGetStockResp.ValidateAll() if the designated constraints
+// aren't met.
+type BatchGetStockRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetStockRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetStockRespMultiError) AllErrors() []error { return m }
+
+// BatchGetStockRespValidationError is the validation error returned by
+// BatchGetStockResp.Validate if the designated constraints aren't met.
+type BatchGetStockRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetStockRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetStockRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetStockRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetStockRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetStockRespValidationError) ErrorName() string {
+	return "BatchGetStockRespValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchGetStockRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetStockResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetStockRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetStockRespValidationError{}
+
+// Validate checks the field values on CheckItem with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CheckItem) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckItem with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CheckItemMultiError, or nil
+// if none found.
+func (m *CheckItem) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckItem) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetGoodsId() <= 0 {
+		err := CheckItemValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetNum() <= 0 {
+		err := CheckItemValidationError{
+			field:  "Num",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CheckItemMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckItemMultiError is an error wrapping multiple validation errors returned
+// by CheckItem.ValidateAll() if the designated constraints aren't met.
+type CheckItemMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckItemMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckItemMultiError) AllErrors() []error { return m }
+
+// CheckItemValidationError is the validation error returned by
+// CheckItem.Validate if the designated constraints aren't met.
+type CheckItemValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckItemValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckItemValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckItemValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckItemValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckItemValidationError) ErrorName() string { return "CheckItemValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CheckItemValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckItem.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckItemValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckItemValidationError{}
+
+// Validate checks the field values on CheckStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CheckStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CheckStockReqMultiError, or
+// nil if none found.
+func (m *CheckStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetItems()); l < 1 || l > 100 {
+		err := CheckStockReqValidationError{
+			field:  "Items",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CheckStockReqValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CheckStockReqValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CheckStockReqValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.GetUserId() < 0 {
+		err := CheckStockReqValidationError{
+			field:  "UserId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRoomId() < 0 {
+		err := CheckStockReqValidationError{
+			field:  "RoomId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CheckStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckStockReqMultiError is an error wrapping multiple validation errors
+// returned by CheckStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type CheckStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckStockReqMultiError) AllErrors() []error { return m }
+
+// CheckStockReqValidationError is the validation error returned by
+// CheckStockReq.Validate if the designated constraints aren't met.
+type CheckStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckStockReqValidationError) ErrorName() string { return "CheckStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CheckStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckStockReqValidationError{}
+
+// Validate checks the field values on CheckVerdict with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CheckVerdict) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckVerdict with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CheckVerdictMultiError, or
+// nil if none found.
+func (m *CheckVerdict) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckVerdict) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for GoodsId
+
+	// no validation rules for Num
+
+	// no validation rules for Ok
+
+	// no validation rules for Reason
+
+	// no validation rules for Message
+
+	// no validation rules for Available
+
+	// no validation rules for Shortfall
+
+	if len(errors) > 0 {
+		return CheckVerdictMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckVerdictMultiError is an error wrapping multiple validation errors
+// returned by CheckVerdict.ValidateAll() if the designated constraints aren't met.
+type CheckVerdictMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckVerdictMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckVerdictMultiError) AllErrors() []error { return m }
+
+// CheckVerdictValidationError is the validation error returned by
+// CheckVerdict.Validate if the designated constraints aren't met.
+type CheckVerdictValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckVerdictValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckVerdictValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckVerdictValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckVerdictValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckVerdictValidationError) ErrorName() string { return "CheckVerdictValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CheckVerdictValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckVerdict.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckVerdictValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckVerdictValidationError{}
+
+// Validate checks the field values on CheckStockResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CheckStockResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckStockResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CheckStockRespMultiError,
+// or nil if none found.
+func (m *CheckStockResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckStockResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Ok
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CheckStockRespValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CheckStockRespValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CheckStockRespValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CheckStockRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckStockRespMultiError is an error wrapping multiple validation errors
+// returned by CheckStockResp.ValidateAll() if the designated constraints
+// aren't met.
+type CheckStockRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckStockRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckStockRespMultiError) AllErrors() []error { return m }
+
+// CheckStockRespValidationError is the validation error returned by
+// CheckStockResp.Validate if the designated constraints aren't met.
+type CheckStockRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckStockRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckStockRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckStockRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckStockRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckStockRespValidationError) ErrorName() string { return "CheckStockRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CheckStockRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckStockResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckStockRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckStockRespValidationError{}
+
+// Validate checks the field values on SetStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SetStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SetStockReqMultiError, or
+// nil if none found.
+func (m *SetStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SetStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SetStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SetStockReqValidationError{
+				field:  "Meta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGoodsId() <= 0 {
+		err := SetStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTotal() < 0 {
+		err := SetStockReqValidationError{
+			field:  "Total",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SetStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetStockReqMultiError is an error wrapping multiple validation errors
+// returned by SetStockReq.ValidateAll() if the designated constraints aren't met.
+type SetStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetStockReqMultiError) AllErrors() []error { return m }
+
+// SetStockReqValidationError is the validation error returned by
+// SetStockReq.Validate if the designated constraints aren't met.
+type SetStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetStockReqValidationError) ErrorName() string { return "SetStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SetStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetStockReqValidationError{}
+
+// Validate checks the field values on ReduceStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ReduceStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReduceStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ReduceStockReqMultiError,
+// or nil if none found.
+func (m *ReduceStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReduceStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ReduceStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ReduceStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ReduceStockReqValidationError{
+				field:  "Meta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGoodsId() <= 0 {
+		err := ReduceStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetNum() <= 0 {
+		err := ReduceStockReqValidationError{
+			field:  "Num",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for OrderId
+
+	if m.GetAutoReleaseSeconds() < 0 {
+		err := ReduceStockReqValidationError{
+			field:  "AutoReleaseSeconds",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetUserId() < 0 {
+		err := ReduceStockReqValidationError{
+			field:  "UserId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRoomId() < 0 {
+		err := ReduceStockReqValidationError{
+			field:  "RoomId",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReduceStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReduceStockReqMultiError is an error wrapping multiple validation errors
+// returned by ReduceStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type ReduceStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReduceStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReduceStockReqMultiError) AllErrors() []error { return m }
+
+// ReduceStockReqValidationError is the validation error returned by
+// ReduceStockReq.Validate if the designated constraints aren't met.
+type ReduceStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReduceStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReduceStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReduceStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReduceStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReduceStockReqValidationError) ErrorName() string { return "ReduceStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ReduceStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReduceStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReduceStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReduceStockReqValidationError{}
+
+// Validate checks the field values on RollbackStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RollbackStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RollbackStockReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RollbackStockReqMultiError, or nil if none found.
+func (m *RollbackStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RollbackStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RollbackStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RollbackStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RollbackStockReqValidationError{
+				field:  "Meta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGoodsId() <= 0 {
+		err := RollbackStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetNum() <= 0 {
+		err := RollbackStockReqValidationError{
+			field:  "Num",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetOrderId() <= 0 {
+		err := RollbackStockReqValidationError{
+			field:  "OrderId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RollbackStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// RollbackStockReqMultiError is an error wrapping multiple validation errors
+// returned by RollbackStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type RollbackStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RollbackStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RollbackStockReqMultiError) AllErrors() []error { return m }
+
+// RollbackStockReqValidationError is the validation error returned by
+// RollbackStockReq.Validate if the designated constraints aren't met.
+type RollbackStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RollbackStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RollbackStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RollbackStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RollbackStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RollbackStockReqValidationError) ErrorName() string { return "RollbackStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RollbackStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRollbackStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RollbackStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RollbackStockReqValidationError{}
+
+// Validate checks the field values on ConfirmStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfirmStockReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmStockReqMultiError, or nil if none found.
+func (m *ConfirmStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfirmStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfirmStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfirmStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfirmStockReqValidationError{
+				field:  "Meta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGoodsId() <= 0 {
+		err := ConfirmStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetOrderId() <= 0 {
+		err := ConfirmStockReqValidationError{
+			field:  "OrderId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ConfirmStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfirmStockReqMultiError is an error wrapping multiple validation errors
+// returned by ConfirmStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type ConfirmStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmStockReqMultiError) AllErrors() []error { return m }
+
+// ConfirmStockReqValidationError is the validation error returned by
+// ConfirmStockReq.Validate if the designated constraints aren't met.
+type ConfirmStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmStockReqValidationError) ErrorName() string { return "ConfirmStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ConfirmStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmStockReqValidationError{}
+
+// Validate checks the field values on AdjustStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AdjustStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AdjustStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AdjustStockReqMultiError,
+// or nil if none found.
+func (m *AdjustStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AdjustStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AdjustStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AdjustStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AdjustStockReqValidationError{
+				field:  "Meta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGoodsId() <= 0 {
+		err := AdjustStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _AdjustStockReq_Delta_NotInLookup[m.GetDelta()]; ok {
+		err := AdjustStockReqValidationError{
+			field:  "Delta",
+			reason: "value must not be in list [0]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Reason
+
+	if len(errors) > 0 {
+		return AdjustStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// AdjustStockReqMultiError is an error wrapping multiple validation errors
+// returned by AdjustStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type AdjustStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AdjustStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AdjustStockReqMultiError) AllErrors() []error { return m }
+
+// AdjustStockReqValidationError is the validation error returned by
+// AdjustStockReq.Validate if the designated constraints aren't met.
+type AdjustStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AdjustStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AdjustStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AdjustStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AdjustStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AdjustStockReqValidationError) ErrorName() string { return "AdjustStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AdjustStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAdjustStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AdjustStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AdjustStockReqValidationError{}
+
+var _AdjustStockReq_Delta_NotInLookup = map[int64]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on DeleteStockReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DeleteStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DeleteStockReqMultiError,
+// or nil if none found.
+func (m *DeleteStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DeleteStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DeleteStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DeleteStockReqValidationError{
+				field:  "Meta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGoodsId() <= 0 {
+		err := DeleteStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteStockReqMultiError is an error wrapping multiple validation errors
+// returned by DeleteStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteStockReqMultiError) AllErrors() []error { return m }
+
+// DeleteStockReqValidationError is the validation error returned by
+// DeleteStockReq.Validate if the designated constraints aren't met.
+type DeleteStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteStockReqValidationError) ErrorName() string { return "DeleteStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DeleteStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteStockReqValidationError{}
+
+// Validate checks the field values on UndeleteStockReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UndeleteStockReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UndeleteStockReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UndeleteStockReqMultiError, or nil if none found.
+func (m *UndeleteStockReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UndeleteStockReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UndeleteStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UndeleteStockReqValidationError{
+					field:  "Meta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UndeleteStockReqValidationError{
+				field:  "Meta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetGoodsId() <= 0 {
+		err := UndeleteStockReqValidationError{
+			field:  "GoodsId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UndeleteStockReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// UndeleteStockReqMultiError is an error wrapping multiple validation errors
+// returned by UndeleteStockReq.ValidateAll() if the designated constraints
+// aren't met.
+type UndeleteStockReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UndeleteStockReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UndeleteStockReqMultiError) AllErrors() []error { return m }
+
+// UndeleteStockReqValidationError is the validation error returned by
+// UndeleteStockReq.Validate if the designated constraints aren't met.
+type UndeleteStockReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UndeleteStockReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UndeleteStockReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UndeleteStockReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UndeleteStockReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UndeleteStockReqValidationError) ErrorName() string { return "UndeleteStockReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UndeleteStockReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUndeleteStockReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UndeleteStockReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UndeleteStockReqValidationError{}
@@ -0,0 +1,487 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.20.1
+// source: v2/stock.proto
+
+package stockv2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Stock_GetStock_FullMethodName      = "/stock.v2.Stock/GetStock"
+	Stock_BatchGetStock_FullMethodName = "/stock.v2.Stock/BatchGetStock"
+	Stock_CheckStock_FullMethodName    = "/stock.v2.Stock/CheckStock"
+	Stock_SetStock_FullMethodName      = "/stock.v2.Stock/SetStock"
+	Stock_ReduceStock_FullMethodName   = "/stock.v2.Stock/ReduceStock"
+	Stock_RollbackStock_FullMethodName = "/stock.v2.Stock/RollbackStock"
+	Stock_ConfirmStock_FullMethodName  = "/stock.v2.Stock/ConfirmStock"
+	Stock_AdjustStock_FullMethodName   = "/stock.v2.Stock/AdjustStock"
+	Stock_DeleteStock_FullMethodName   = "/stock.v2.Stock/DeleteStock"
+	Stock_UndeleteStock_FullMethodName = "/stock.v2.Stock/UndeleteStock"
+)
+
+// StockClient is the client API for Stock service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 库存服务 v2
+type StockClient interface {
+	// 获取库存
+	GetStock(ctx context.Context, in *GetStockReq, opts ...grpc.CallOption) (*StockState, error)
+	// 批量获取库存
+	BatchGetStock(ctx context.Context, in *BatchGetStockReq, opts ...grpc.CallOption) (*BatchGetStockResp, error)
+	// 预检一组商品能否扣减，不预扣库存
+	CheckStock(ctx context.Context, in *CheckStockReq, opts ...grpc.CallOption) (*CheckStockResp, error)
+	// 设置库存
+	SetStock(ctx context.Context, in *SetStockReq, opts ...grpc.CallOption) (*MutationResp, error)
+	// 预扣库存
+	ReduceStock(ctx context.Context, in *ReduceStockReq, opts ...grpc.CallOption) (*MutationResp, error)
+	// 回滚预扣的库存
+	RollbackStock(ctx context.Context, in *RollbackStockReq, opts ...grpc.CallOption) (*MutationResp, error)
+	// 确认扣减库存（订单支付后调用）
+	ConfirmStock(ctx context.Context, in *ConfirmStockReq, opts ...grpc.CallOption) (*MutationResp, error)
+	// 按增量调整库存数量
+	AdjustStock(ctx context.Context, in *AdjustStockReq, opts ...grpc.CallOption) (*MutationResp, error)
+	// 删除库存（软删除）
+	DeleteStock(ctx context.Context, in *DeleteStockReq, opts ...grpc.CallOption) (*MutationResp, error)
+	// 恢复已删除的库存
+	UndeleteStock(ctx context.Context, in *UndeleteStockReq, opts ...grpc.CallOption) (*MutationResp, error)
+}
+
+type stockClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockClient(cc grpc.ClientConnInterface) StockClient {
+	return &stockClient{cc}
+}
+
+func (c *stockClient) GetStock(ctx context.Context, in *GetStockReq, opts ...grpc.CallOption) (*StockState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockState)
+	err := c.cc.Invoke(ctx, Stock_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) BatchGetStock(ctx context.Context, in *BatchGetStockReq, opts ...grpc.CallOption) (*BatchGetStockResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetStockResp)
+	err := c.cc.Invoke(ctx, Stock_BatchGetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) CheckStock(ctx context.Context, in *CheckStockReq, opts ...grpc.CallOption) (*CheckStockResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckStockResp)
+	err := c.cc.Invoke(ctx, Stock_CheckStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) SetStock(ctx context.Context, in *SetStockReq, opts ...grpc.CallOption) (*MutationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutationResp)
+	err := c.cc.Invoke(ctx, Stock_SetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ReduceStock(ctx context.Context, in *ReduceStockReq, opts ...grpc.CallOption) (*MutationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutationResp)
+	err := c.cc.Invoke(ctx, Stock_ReduceStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) RollbackStock(ctx context.Context, in *RollbackStockReq, opts ...grpc.CallOption) (*MutationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutationResp)
+	err := c.cc.Invoke(ctx, Stock_RollbackStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ConfirmStock(ctx context.Context, in *ConfirmStockReq, opts ...grpc.CallOption) (*MutationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutationResp)
+	err := c.cc.Invoke(ctx, Stock_ConfirmStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) AdjustStock(ctx context.Context, in *AdjustStockReq, opts ...grpc.CallOption) (*MutationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutationResp)
+	err := c.cc.Invoke(ctx, Stock_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) DeleteStock(ctx context.Context, in *DeleteStockReq, opts ...grpc.CallOption) (*MutationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutationResp)
+	err := c.cc.Invoke(ctx, Stock_DeleteStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) UndeleteStock(ctx context.Context, in *UndeleteStockReq, opts ...grpc.CallOption) (*MutationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MutationResp)
+	err := c.cc.Invoke(ctx, Stock_UndeleteStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockServer is the server API for Stock service.
+// All implementations must embed UnimplementedStockServer
+// for forward compatibility.
+//
+// 库存服务 v2
+type StockServer interface {
+	// 获取库存
+	GetStock(context.Context, *GetStockReq) (*StockState, error)
+	// 批量获取库存
+	BatchGetStock(context.Context, *BatchGetStockReq) (*BatchGetStockResp, error)
+	// 预检一组商品能否扣减，不预扣库存
+	CheckStock(context.Context, *CheckStockReq) (*CheckStockResp, error)
+	// 设置库存
+	SetStock(context.Context, *SetStockReq) (*MutationResp, error)
+	// 预扣库存
+	ReduceStock(context.Context, *ReduceStockReq) (*MutationResp, error)
+	// 回滚预扣的库存
+	RollbackStock(context.Context, *RollbackStockReq) (*MutationResp, error)
+	// 确认扣减库存（订单支付后调用）
+	ConfirmStock(context.Context, *ConfirmStockReq) (*MutationResp, error)
+	// 按增量调整库存数量
+	AdjustStock(context.Context, *AdjustStockReq) (*MutationResp, error)
+	// 删除库存（软删除）
+	DeleteStock(context.Context, *DeleteStockReq) (*MutationResp, error)
+	// 恢复已删除的库存
+	UndeleteStock(context.Context, *UndeleteStockReq) (*MutationResp, error)
+	mustEmbedUnimplementedStockServer()
+}
+
+// UnimplementedStockServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockServer struct{}
+
+func (UnimplementedStockServer) GetStock(context.Context, *GetStockReq) (*StockState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedStockServer) BatchGetStock(context.Context, *BatchGetStockReq) (*BatchGetStockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetStock not implemented")
+}
+func (UnimplementedStockServer) CheckStock(context.Context, *CheckStockReq) (*CheckStockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckStock not implemented")
+}
+func (UnimplementedStockServer) SetStock(context.Context, *SetStockReq) (*MutationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStock not implemented")
+}
+func (UnimplementedStockServer) ReduceStock(context.Context, *ReduceStockReq) (*MutationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReduceStock not implemented")
+}
+func (UnimplementedStockServer) RollbackStock(context.Context, *RollbackStockReq) (*MutationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackStock not implemented")
+}
+func (UnimplementedStockServer) ConfirmStock(context.Context, *ConfirmStockReq) (*MutationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmStock not implemented")
+}
+func (UnimplementedStockServer) AdjustStock(context.Context, *AdjustStockReq) (*MutationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedStockServer) DeleteStock(context.Context, *DeleteStockReq) (*MutationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStock not implemented")
+}
+func (UnimplementedStockServer) UndeleteStock(context.Context, *UndeleteStockReq) (*MutationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteStock not implemented")
+}
+func (UnimplementedStockServer) mustEmbedUnimplementedStockServer() {}
+func (UnimplementedStockServer) testEmbeddedByValue()               {}
+
+// UnsafeStockServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockServer will
+// result in compilation errors.
+type UnsafeStockServer interface {
+	mustEmbedUnimplementedStockServer()
+}
+
+func RegisterStockServer(s grpc.ServiceRegistrar, srv StockServer) {
+	// If the following call pancis, it indicates UnimplementedStockServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Stock_ServiceDesc, srv)
+}
+
+func _Stock_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).GetStock(ctx, req.(*GetStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_BatchGetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).BatchGetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_BatchGetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).BatchGetStock(ctx, req.(*BatchGetStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_CheckStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).CheckStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_CheckStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).CheckStock(ctx, req.(*CheckStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_SetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).SetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_SetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).SetStock(ctx, req.(*SetStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ReduceStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReduceStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ReduceStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_ReduceStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ReduceStock(ctx, req.(*ReduceStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_RollbackStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).RollbackStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_RollbackStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).RollbackStock(ctx, req.(*RollbackStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ConfirmStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ConfirmStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_ConfirmStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ConfirmStock(ctx, req.(*ConfirmStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).AdjustStock(ctx, req.(*AdjustStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_DeleteStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).DeleteStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_DeleteStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).DeleteStock(ctx, req.(*DeleteStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_UndeleteStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).UndeleteStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Stock_UndeleteStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).UndeleteStock(ctx, req.(*UndeleteStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Stock_ServiceDesc is the grpc.ServiceDesc for Stock service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Stock_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stock.v2.Stock",
+	HandlerType: (*StockServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStock",
+			Handler:    _Stock_GetStock_Handler,
+		},
+		{
+			MethodName: "BatchGetStock",
+			Handler:    _Stock_BatchGetStock_Handler,
+		},
+		{
+			MethodName: "CheckStock",
+			Handler:    _Stock_CheckStock_Handler,
+		},
+		{
+			MethodName: "SetStock",
+			Handler:    _Stock_SetStock_Handler,
+		},
+		{
+			MethodName: "ReduceStock",
+			Handler:    _Stock_ReduceStock_Handler,
+		},
+		{
+			MethodName: "RollbackStock",
+			Handler:    _Stock_RollbackStock_Handler,
+		},
+		{
+			MethodName: "ConfirmStock",
+			Handler:    _Stock_ConfirmStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _Stock_AdjustStock_Handler,
+		},
+		{
+			MethodName: "DeleteStock",
+			Handler:    _Stock_DeleteStock_Handler,
+		},
+		{
+			MethodName: "UndeleteStock",
+			Handler:    _Stock_UndeleteStock_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v2/stock.proto",
+}
@@ -0,0 +1,156 @@
+// Package ratelimit 令牌桶限流：按方法、调用方和商品分桶，令牌桶可以放在本机或 Redis 中。
+// 规则来自配置文件，修改后调用 Init 生效；本机的令牌桶在规则修改后保留，只调整速率和容量。
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stock_service/config"
+
+	"go.uber.org/zap"
+)
+
+// 分桶方式
+const (
+	KeyMethod = "method" // 每个方法一个桶，规则的 method 为 * 时所有方法一个桶
+	KeyCaller = "caller" // 每个调用方一个桶
+	KeyGoods  = "goods"  // 每个商品一个桶
+)
+
+// anyMethod 匹配所有方法的规则
+const anyMethod = "*"
+
+// Request 需要限流的请求
+type Request struct {
+	Method  string // gRPC 完整方法名
+	Caller  string // 调用方
+	GoodsId int64  // 商品ID，请求不针对单个商品时为 0
+}
+
+// Rejection 被拒绝的原因
+type Rejection struct {
+	Key        string        // 拒绝请求的规则的分桶方式
+	RetryAfter time.Duration // 建议的重试间隔
+}
+
+// store 令牌桶的存储，返回 0 表示拿到了令牌，否则返回需要等待的时间
+type store interface {
+	take(ctx context.Context, key string, rate float64, burst int) (time.Duration, error)
+}
+
+// limiter 一份生效中的限流配置
+type limiter struct {
+	backend string
+	store   store
+	rules   []config.RateLimitRule
+}
+
+var (
+	mu      sync.RWMutex
+	current *limiter // 为 nil 时不限流
+)
+
+// Init 根据配置创建或更新限流规则，cfg 为 nil 或没有配置 backend 时关闭限流。
+// 配置有误时返回错误，原来的规则继续生效。
+func Init(cfg *config.RateLimitConfig) error {
+	if cfg == nil || cfg.Backend == "" {
+		mu.Lock()
+		current = nil
+		mu.Unlock()
+		return nil
+	}
+
+	rules := make([]config.RateLimitRule, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		if r.Method == "" {
+			return fmt.Errorf("ratelimit rule %d: method is required", i)
+		}
+		switch r.Key {
+		case KeyMethod, KeyCaller, KeyGoods:
+		default:
+			return fmt.Errorf("ratelimit rule %d: unknown key %q", i, r.Key)
+		}
+		if r.Rate <= 0 {
+			return fmt.Errorf("ratelimit rule %d: rate must be positive", i)
+		}
+		if r.Burst <= 0 {
+			r.Burst = max(int(r.Rate), 1)
+		}
+		rules[i] = r
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	l := &limiter{backend: cfg.Backend, rules: rules}
+	switch {
+	case current != nil && current.backend == cfg.Backend:
+		// 后端不变时沿用原来的令牌桶
+		l.store = current.store
+	case cfg.Backend == "local":
+		l.store = newLocalStore()
+	case cfg.Backend == "redis":
+		l.store = redisStore{}
+	default:
+		return fmt.Errorf("unknown ratelimit backend %q", cfg.Backend)
+	}
+	current = l
+	return nil
+}
+
+// Allow 按匹配的规则依次取令牌，有一条规则拿不到令牌就拒绝，返回 nil 表示放行。
+// 前面的规则已经消耗的令牌不退还。Redis 不可用时放行，不因为限流影响正常的请求。
+func Allow(ctx context.Context, req Request) *Rejection {
+	mu.RLock()
+	l := current
+	mu.RUnlock()
+	if l == nil {
+		return nil
+	}
+
+	for _, r := range l.rules {
+		key, ok := bucket(r, req)
+		if !ok {
+			continue
+		}
+		wait, err := l.store.take(ctx, key, r.Rate, r.Burst)
+		if err != nil {
+			zap.L().Warn("ratelimit take token failed", zap.String("bucket", key), zap.Error(err))
+			continue
+		}
+		if wait > 0 {
+			return &Rejection{Key: r.Key, RetryAfter: wait}
+		}
+	}
+	return nil
+}
+
+// bucket 返回请求在规则下的令牌桶，规则不适用于请求时返回 false
+func bucket(r config.RateLimitRule, req Request) (string, bool) {
+	if r.Method != anyMethod && r.Method != req.Method {
+		return "", false
+	}
+	if r.Caller != "" && r.Caller != req.Caller {
+		return "", false
+	}
+	// 规则本身作为前缀，同一个请求在不同规则下使用不同的桶；
+	// method 为 "*" 的规则所有方法共用桶，例如按调用方限制所有方法的总请求数
+	parts := []string{r.Method, r.Caller, r.Key}
+	if r.Method != anyMethod {
+		parts = append(parts, req.Method)
+	}
+	switch r.Key {
+	case KeyCaller:
+		parts = append(parts, req.Caller)
+	case KeyGoods:
+		if req.GoodsId == 0 {
+			return "", false
+		}
+		parts = append(parts, strconv.FormatInt(req.GoodsId, 10))
+	}
+	return strings.Join(parts, "|"), true
+}
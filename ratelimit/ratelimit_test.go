@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stock_service/config"
+	"stock_service/dao/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+)
+
+const reduceMethod = "/stock.v2.Stock/ReduceStock"
+
+func TestAllowLocalRefill(t *testing.T) {
+	t.Cleanup(func() { Init(nil) })
+	cfg := &config.RateLimitConfig{
+		Backend: "local",
+		Rules:   []config.RateLimitRule{{Method: anyMethod, Key: KeyCaller, Rate: 20, Burst: 2}},
+	}
+	if err := Init(cfg); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	req := Request{Method: reduceMethod, Caller: "order"}
+
+	for i := 0; i < 2; i++ {
+		if rej := Allow(ctx, req); rej != nil {
+			t.Fatalf("request %d rejected: %+v", i, rej)
+		}
+	}
+	rej := Allow(ctx, req)
+	if rej == nil || rej.Key != KeyCaller || rej.RetryAfter <= 0 || rej.RetryAfter > 50*time.Millisecond {
+		t.Fatalf("third request: %+v, want a caller rejection within 50ms", rej)
+	}
+	// method 为 * 的规则所有方法共用一个桶，其他调用方不受影响
+	if rej := Allow(ctx, Request{Method: "/stock.v2.Stock/GetStock", Caller: "order"}); rej == nil {
+		t.Fatal("other method of the same caller was not limited")
+	}
+	if rej := Allow(ctx, Request{Method: reduceMethod, Caller: "report"}); rej != nil {
+		t.Fatalf("other caller rejected: %+v", rej)
+	}
+
+	// 等待建议的时间后补充了令牌
+	time.Sleep(rej.RetryAfter)
+	if rej := Allow(ctx, req); rej != nil {
+		t.Fatalf("request after refill rejected: %+v", rej)
+	}
+
+	// 修改规则后沿用原来的令牌桶（令牌已经用完），按新的速率计算等待时间
+	cfg.Rules[0].Rate = 1000
+	if err := Init(cfg); err != nil {
+		t.Fatal(err)
+	}
+	rej = Allow(ctx, req)
+	if rej == nil || rej.RetryAfter > time.Millisecond {
+		t.Fatalf("request after raising the rate: %+v, want a rejection within 1ms", rej)
+	}
+	time.Sleep(rej.RetryAfter)
+	if rej := Allow(ctx, req); rej != nil {
+		t.Fatalf("request after raising the rate rejected: %+v", rej)
+	}
+}
+
+func TestAllowRedis(t *testing.T) {
+	t.Cleanup(func() { Init(nil) })
+	mr := miniredis.RunT(t)
+	rc := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rc.Close() })
+	redis.Use(rc, &config.RedisConfig{})
+
+	err := Init(&config.RateLimitConfig{
+		Backend: "redis",
+		Rules:   []config.RateLimitRule{{Method: reduceMethod, Key: KeyGoods, Rate: 1, Burst: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if rej := Allow(ctx, Request{Method: reduceMethod, GoodsId: 1001}); rej != nil {
+		t.Fatalf("first request rejected: %+v", rej)
+	}
+	if rej := Allow(ctx, Request{Method: reduceMethod, GoodsId: 1001}); rej == nil || rej.Key != KeyGoods {
+		t.Fatalf("second request: %+v, want a goods rejection", rej)
+	}
+	if rej := Allow(ctx, Request{Method: reduceMethod, GoodsId: 1002}); rej != nil {
+		t.Fatalf("other goods rejected: %+v", rej)
+	}
+
+	// Redis 不可用时放行
+	mr.Close()
+	if rej := Allow(ctx, Request{Method: reduceMethod, GoodsId: 1001}); rej != nil {
+		t.Fatalf("request with Redis down rejected: %+v", rej)
+	}
+}
+
+func TestInitInvalidRuleKeepsCurrent(t *testing.T) {
+	t.Cleanup(func() { Init(nil) })
+	valid := &config.RateLimitConfig{
+		Backend: "local",
+		Rules:   []config.RateLimitRule{{Method: reduceMethod, Key: KeyMethod, Rate: 1}},
+	}
+	if err := Init(valid); err != nil {
+		t.Fatal(err)
+	}
+	invalid := &config.RateLimitConfig{
+		Backend: "local",
+		Rules:   []config.RateLimitRule{{Method: reduceMethod, Key: "user", Rate: 1}},
+	}
+	if err := Init(invalid); err == nil {
+		t.Fatal("Init accepted an unknown key")
+	}
+	ctx := context.Background()
+	Allow(ctx, Request{Method: reduceMethod})
+	if rej := Allow(ctx, Request{Method: reduceMethod}); rej == nil {
+		t.Fatal("the previous rules are no longer in effect")
+	}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"stock_service/dao/redis"
+
+	"golang.org/x/time/rate"
+)
+
+// maxLocalBuckets 本机令牌桶数量超过这个值时清理已经补满的桶。
+// 补满的桶和新建的桶没有区别，清理不影响限流的效果。
+const maxLocalBuckets = 10000
+
+// localStore 本机的令牌桶，每个实例单独计数
+type localStore struct {
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+func newLocalStore() *localStore {
+	return &localStore{buckets: make(map[string]*rate.Limiter)}
+}
+
+func (s *localStore) take(_ context.Context, key string, r float64, burst int) (time.Duration, error) {
+	now := time.Now()
+	limit := rate.Limit(r)
+
+	s.mu.Lock()
+	l, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= maxLocalBuckets {
+			s.sweep(now)
+		}
+		l = rate.NewLimiter(limit, burst)
+		s.buckets[key] = l
+	}
+	s.mu.Unlock()
+
+	// 规则修改后调整速率和容量
+	if l.Limit() != limit {
+		l.SetLimitAt(now, limit)
+	}
+	if l.Burst() != burst {
+		l.SetBurstAt(now, burst)
+	}
+
+	res := l.ReserveN(now, 1)
+	if !res.OK() {
+		return time.Second, nil
+	}
+	if wait := res.DelayFrom(now); wait > 0 {
+		res.CancelAt(now)
+		return wait, nil
+	}
+	return 0, nil
+}
+
+// sweep 删除已经补满的桶，调用方持有锁
+func (s *localStore) sweep(now time.Time) {
+	for k, l := range s.buckets {
+		if l.TokensAt(now) >= float64(l.Burst()) {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+// redisStore 所有实例共享的令牌桶
+type redisStore struct{}
+
+func (redisStore) take(ctx context.Context, key string, r float64, burst int) (time.Duration, error) {
+	return redis.TakeToken(ctx, key, r, burst)
+}
@@ -0,0 +1,216 @@
+// Package rbac 基于角色的权限校验：策略文件定义每个角色可以调用的方法，可以限定商品范围。
+// 策略文件由单独的 viper 实例加载，修改后自动生效；新策略有误时记录日志，原来的策略继续生效。
+//
+// 暂不支持按仓库限定范围：库存只按商品记录，请求和数据中都没有仓库。
+// 引入分仓库存后在 Rule 中增加 warehouses，与 goods 一样按请求涉及的仓库校验。
+//
+// 策略文件示例：
+//
+//	roles:
+//	  admin:
+//	    - methods: ["*"]
+//	  order:
+//	    - methods: ["/stock.v2.Stock/ReduceStock", "/stock.v2.Stock/ConfirmStock", "/stock.v2.Stock/RollbackStock"]
+//	  merchant_1001:
+//	    - methods: ["/stock.v2.Stock/SetStock"]
+//	      goods: [1001, 1002]
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"stock_service/config"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// AnonymousRole 没有认证的请求（未开启认证或方法不需要认证）使用的角色
+const AnonymousRole = "anonymous"
+
+// RepairSuffix 修复数据的请求（例如 repair 为 true 的对账）需要的权限为方法名加上这个后缀，
+// 只有方法名的规则不能修复数据；"*" 和以 "*" 结尾的前缀规则同时匹配修复权限。
+const RepairSuffix = ":repair"
+
+// Policy 策略文件的内容，角色名不区分大小写
+type Policy struct {
+	Roles map[string][]Rule `mapstructure:"roles"`
+}
+
+// Rule 一条授权规则
+type Rule struct {
+	Methods []string `mapstructure:"methods"` // gRPC 完整方法名，"*" 表示所有方法，以 "*" 结尾时按前缀匹配
+	Goods   []int64  `mapstructure:"goods"`   // 允许操作的商品，为空时不限商品
+}
+
+// Request 需要校验权限的请求
+type Request struct {
+	Method   string   // gRPC 完整方法名
+	Repair   bool     // 是否修复数据
+	Roles    []string // 调用方的角色，为空时使用 AnonymousRole
+	GoodsIds []int64  // 请求涉及的商品，为空表示不针对特定商品（或针对所有商品），只有不限商品的规则能放行
+}
+
+// rule 解析后的规则
+type rule struct {
+	methods []string
+	goods   map[int64]bool // 为 nil 时不限商品
+}
+
+// policy 解析后的策略，角色名为小写
+type policy struct {
+	roles map[string][]rule
+}
+
+var (
+	mu      sync.RWMutex
+	current *policy // 为 nil 时不校验权限
+)
+
+// Init 加载策略文件并监听文件修改，cfg 为 nil 或未启用时不校验权限
+func Init(cfg *config.RBACConfig) error {
+	if cfg == nil || !cfg.Enable {
+		return nil
+	}
+	if cfg.PolicyFile == "" {
+		return errors.New("rbac: policy_file is required")
+	}
+
+	v := viper.New()
+	v.SetConfigFile(cfg.PolicyFile)
+	p, err := load(v)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	current = p
+	mu.Unlock()
+
+	v.WatchConfig()
+	v.OnConfigChange(func(fsnotify.Event) {
+		p, err := load(v)
+		if err != nil {
+			zap.L().Error("reload rbac policy failed", zap.String("file", cfg.PolicyFile), zap.Error(err))
+			return
+		}
+		mu.Lock()
+		current = p
+		mu.Unlock()
+		zap.L().Info("rbac policy reloaded", zap.String("file", cfg.PolicyFile))
+	})
+	return nil
+}
+
+// load 读取并解析策略文件
+func load(v *viper.Viper) (*policy, error) {
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("rbac: %w", err)
+	}
+	var raw Policy
+	if err := v.Unmarshal(&raw); err != nil {
+		return nil, fmt.Errorf("rbac: %w", err)
+	}
+
+	// 文件被截断后重新写入时会先读到空文件，空策略会拒绝所有请求，按错误处理
+	if len(raw.Roles) == 0 {
+		return nil, errors.New("rbac: no roles defined")
+	}
+
+	p := &policy{roles: make(map[string][]rule, len(raw.Roles))}
+	for role, rules := range raw.Roles {
+		compiled := make([]rule, len(rules))
+		for i, r := range rules {
+			if len(r.Methods) == 0 {
+				return nil, fmt.Errorf("rbac: role %q rule %d: methods is required", role, i)
+			}
+			compiled[i].methods = r.Methods
+			if len(r.Goods) > 0 {
+				compiled[i].goods = make(map[int64]bool, len(r.Goods))
+				for _, id := range r.Goods {
+					compiled[i].goods[id] = true
+				}
+			}
+		}
+		role = strings.ToLower(role)
+		p.roles[role] = append(p.roles[role], compiled...)
+	}
+	return p, nil
+}
+
+// Enabled 是否校验权限
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current != nil
+}
+
+// Authorize 校验请求的权限，未启用时总是放行；没有权限时返回拒绝的原因
+func Authorize(r Request) error {
+	mu.RLock()
+	p := current
+	mu.RUnlock()
+	if p == nil {
+		return nil
+	}
+
+	perm := r.Method
+	if r.Repair {
+		perm += RepairSuffix
+	}
+	roles := r.Roles
+	if len(roles) == 0 {
+		roles = []string{AnonymousRole}
+	}
+
+	var matched []rule
+	for _, role := range roles {
+		for _, ru := range p.roles[strings.ToLower(role)] {
+			if !ru.allows(perm) {
+				continue
+			}
+			if ru.goods == nil {
+				return nil
+			}
+			matched = append(matched, ru)
+		}
+	}
+	if len(matched) == 0 {
+		return fmt.Errorf("roles %v are not allowed to call %s", roles, perm)
+	}
+	if len(r.GoodsIds) == 0 {
+		return fmt.Errorf("roles %v are only allowed to call %s on specific goods", roles, perm)
+	}
+	for _, id := range r.GoodsIds {
+		if !anyGoods(matched, id) {
+			return fmt.Errorf("roles %v are not allowed to call %s on goods %d", roles, perm, id)
+		}
+	}
+	return nil
+}
+
+// allows 规则是否匹配权限
+func (ru rule) allows(perm string) bool {
+	for _, m := range ru.methods {
+		if m == perm {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(m, "*"); ok && strings.HasPrefix(perm, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyGoods 是否有规则允许操作这个商品
+func anyGoods(rules []rule, id int64) bool {
+	for _, ru := range rules {
+		if ru.goods[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package rbac
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const testPolicy = `
+roles:
+  admin:
+    - methods: ["*"]
+  order:
+    - methods: ["/stock.v2.Stock/ReduceStock", "/stock.v2.Stock/RollbackStock"]
+  reconciler:
+    - methods: ["/proto.Stock/ReconcileStock"]
+  repairer:
+    - methods: ["/proto.Stock/ReconcileStock:repair"]
+  reader:
+    - methods: ["/stock.v2.Stock/Get*"]
+  merchant:
+    - methods: ["/stock.v2.Stock/SetStock"]
+      goods: [1001, 1002]
+    - methods: ["/stock.v2.Stock/SetStock"]
+      goods: [1003]
+  anonymous:
+    - methods: ["/proto.Stock/GetStock"]
+`
+
+// setPolicy 加载策略并在测试结束后恢复原来的策略
+func setPolicy(t *testing.T, content string) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "rbac.yaml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	v := viper.New()
+	v.SetConfigFile(file)
+	p, err := load(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	prev := current
+	current = p
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		current = prev
+		mu.Unlock()
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	setPolicy(t, testPolicy)
+
+	tests := []struct {
+		name  string
+		req   Request
+		allow bool
+	}{
+		{"admin any method", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"admin"}}, true},
+		{"admin repair", Request{Method: "/proto.Stock/ReconcileStock", Repair: true, Roles: []string{"admin"}}, true},
+		{"role names are case insensitive", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"Admin"}}, true},
+		{"order reduce", Request{Method: "/stock.v2.Stock/ReduceStock", Roles: []string{"order"}, GoodsIds: []int64{1}}, true},
+		{"order cannot set stock", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"order"}, GoodsIds: []int64{1}}, false},
+		{"prefix rule", Request{Method: "/stock.v2.Stock/GetStock", Roles: []string{"reader"}}, true},
+		{"prefix rule does not match other methods", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"reader"}}, false},
+		{"reconcile without repair", Request{Method: "/proto.Stock/ReconcileStock", Roles: []string{"reconciler"}}, true},
+		{"repair needs the repair suffix", Request{Method: "/proto.Stock/ReconcileStock", Repair: true, Roles: []string{"reconciler"}}, false},
+		{"repair suffix rule", Request{Method: "/proto.Stock/ReconcileStock", Repair: true, Roles: []string{"repairer"}}, true},
+		{"repair suffix rule does not allow plain calls", Request{Method: "/proto.Stock/ReconcileStock", Roles: []string{"repairer"}}, false},
+		{"goods in scope", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"merchant"}, GoodsIds: []int64{1001}}, true},
+		{"goods across rules of the same role", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"merchant"}, GoodsIds: []int64{1002, 1003}}, true},
+		{"goods out of scope", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"merchant"}, GoodsIds: []int64{1001, 2001}}, false},
+		{"scoped rule needs goods", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"merchant"}}, false},
+		{"roles are combined", Request{Method: "/stock.v2.Stock/SetStock", Roles: []string{"order", "merchant"}, GoodsIds: []int64{1003}}, true},
+		{"unknown role", Request{Method: "/stock.v2.Stock/GetStock", Roles: []string{"nobody"}}, false},
+		{"anonymous role", Request{Method: "/proto.Stock/GetStock"}, true},
+		{"anonymous role is limited", Request{Method: "/stock.v2.Stock/GetStock"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Authorize(tt.req)
+			if (err == nil) != tt.allow {
+				t.Errorf("Authorize(%+v) = %v, want allow %v", tt.req, err, tt.allow)
+			}
+		})
+	}
+}
+
+func TestAuthorizeDisabled(t *testing.T) {
+	mu.Lock()
+	prev := current
+	current = nil
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		current = prev
+		mu.Unlock()
+	})
+
+	if Enabled() {
+		t.Fatal("Enabled() = true without a policy")
+	}
+	if err := Authorize(Request{Method: "/stock.v2.Stock/SetStock"}); err != nil {
+		t.Errorf("Authorize without a policy = %v, want nil", err)
+	}
+}
+
+func TestLoadRejectsInvalidPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty file", ""},
+		{"rule without methods", "roles:\n  admin:\n    - goods: [1]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "rbac.yaml")
+			if err := os.WriteFile(file, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			v := viper.New()
+			v.SetConfigFile(file)
+			if _, err := load(v); err == nil {
+				t.Error("load succeeded, want error")
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package registry
 import (
 	"fmt"
 	"net"
+	"slices"
 
 	"github.com/hashicorp/consul/api"
 )
@@ -13,6 +14,9 @@ type consul struct {
 
 var Reg Register
 
+// TagTLS 服务开启了 TLS，注册时带上这个标签，Consul 的健康检查使用 TLS 连接（按 Consul agent 的 TLS 配置校验证书，mTLS 时使用 agent 的证书）
+const TagTLS = "tls"
+
 // 确保某个结构体实现了对应的接口
 var _ Register = (*consul)(nil)
 
@@ -49,6 +53,7 @@ func (c *consul) RegisterService(serviceName string, ip string, port int, tags [
 		Timeout:                        "5s",
 		Interval:                       "5s",
 		DeregisterCriticalServiceAfter: "10s",
+		GRPCUseTLS:                     slices.Contains(tags, TagTLS),
 	}
 	srv := &api.AgentServiceRegistration{
 		ID:      fmt.Sprintf("%s-%s-%d", serviceName, ip, port), // 服务唯一ID
@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// memory 内存中的注册中心，不做健康检查，用于测试和本地调试
+type memory struct {
+	mu       sync.RWMutex
+	services map[string]*api.AgentService // key 为服务ID
+}
+
+// 确保某个结构体实现了对应的接口
+var _ Register = (*memory)(nil)
+
+// NewMemory 创建内存中的注册中心
+func NewMemory() Register {
+	return &memory{services: make(map[string]*api.AgentService)}
+}
+
+// RegisterService 注册服务，服务ID与 consul 的规则相同，重复注册会覆盖
+func (m *memory) RegisterService(serviceName string, ip string, port int, tags []string) error {
+	id := fmt.Sprintf("%s-%s-%d", serviceName, ip, port)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.services[id] = &api.AgentService{
+		ID:      id,
+		Service: serviceName,
+		Tags:    tags,
+		Address: ip,
+		Port:    port,
+	}
+	return nil
+}
+
+// ListService 服务发现，返回的是副本
+func (m *memory) ListService(serviceName string) (map[string]*api.AgentService, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	list := make(map[string]*api.AgentService)
+	for id, s := range m.services {
+		if s.Service == serviceName {
+			c := *s
+			list[id] = &c
+		}
+	}
+	return list, nil
+}
+
+// Deregister 注销服务
+func (m *memory) Deregister(serviceID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.services, serviceID)
+	return nil
+}
@@ -0,0 +1,17 @@
+CREATE TABLE `xx_consumed_message`(
+                           `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                           `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                           `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                           `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                           `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                           `msg_id` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '消息id',
+                           `consumer_group` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '消费者组',
+                           `request_hash` CHAR(64) NOT NULL DEFAULT '' COMMENT '请求内容的 SHA-256，仅 v2 接口的幂等键使用',
+                           `response` VARBINARY(1024) NOT NULL DEFAULT '' COMMENT '首次请求的响应（protobuf），仅 v2 接口的幂等键使用',
+                           UNIQUE (msg_id, consumer_group),
+                           INDEX (create_at),
+                           INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '已消费消息表';
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE `xx_goods`(
+                           `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                           `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                           `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                           `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                           `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                           `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT 'goods id',
+                           `category_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '分类id',
+                           `brand_name` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '品牌名',
+                           `code` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '商品编码',
+                           `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：0未上架 1上架',
+                           `title` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '标题',
+                           `market_price` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '市场价（分）',
+                           `price` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '售价（分）',
+                           `brief` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '简介',
+                           `head_imgs` TEXT NOT NULL COMMENT '头图',
+                           `videos` TEXT NOT NULL COMMENT '视频',
+                           `detail` TEXT NOT NULL COMMENT '详情',
+                           `ext_json` TEXT NOT NULL COMMENT '扩展信息',
+                           UNIQUE (goods_id),
+                           INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '商品表';
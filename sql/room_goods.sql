@@ -0,0 +1,17 @@
+CREATE TABLE `xx_room_goods`(
+                           `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                           `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                           `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                           `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                           `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                           `room_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '直播间id',
+                           `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT 'goods id',
+                           `weight` BIGINT(20) NOT NULL DEFAULT '0' COMMENT '排序权重',
+                           `is_current` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否为当前讲解的商品：0否1是',
+                           `quota` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '直播间可售的数量（预扣和已扣减）：0不限',
+                           UNIQUE (room_id, goods_id),
+                           INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '直播间商品表';
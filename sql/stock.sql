@@ -10,6 +10,7 @@ CREATE TABLE `xx_stock`(
                            `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT 'goods id',
                            `stocknum` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '库存',
                            `lock` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '预扣库存',
+                           `user_limit` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '每个用户的限购数量：0不限购',
                            UNIQUE (goods_id),
                            INDEX (is_del)
 )ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '库存表';
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE `xx_stock_audit`(
+                           `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                           `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                           `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                           `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                           `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                           `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT 'goods id',
+                           `action` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '操作类型',
+                           `before_stock` BIGINT(20) NOT NULL DEFAULT '0' COMMENT '修正前库存',
+                           `after_stock` BIGINT(20) NOT NULL DEFAULT '0' COMMENT '修正后库存',
+                           `before_lock` BIGINT(20) NOT NULL DEFAULT '0' COMMENT '修正前预扣库存',
+                           `after_lock` BIGINT(20) NOT NULL DEFAULT '0' COMMENT '修正后预扣库存',
+                           `remark` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '备注',
+                           INDEX (goods_id),
+                           INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '库存审计表';
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE `xx_stock_outbox`(
+                           `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                           `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                           `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                           `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                           `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                           `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT 'goods id',
+                           `event_type` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '事件类型',
+                           `payload` TEXT NOT NULL COMMENT '事件内容',
+                           `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：0待投递 1已投递 2已搁置',
+                           `retries` INT(11) UNSIGNED NOT NULL DEFAULT '0' COMMENT '重试次数',
+                           `next_retry_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '下次投递时间',
+                           `last_error` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最近一次投递失败原因',
+                           INDEX (status, id),
+                           INDEX (goods_id, status, id),
+                           INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '库存变更事件发件箱';
\ No newline at end of file
@@ -10,7 +10,12 @@ CREATE TABLE `xx_stock_record`(
                            `order_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '订单id',
                            `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT 'goods id',
                            `num` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT 'num',
-                           `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：1预扣减 2扣减 3已回滚',
+                           `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：1预扣减 2扣减 3已回滚 4超时释放',
+                           `user_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '用户id',
+                           `room_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '直播间id',
                            UNIQUE (order_id, goods_id),
+                           INDEX (goods_id, create_at),
+                           INDEX (goods_id, user_id),
+                           INDEX (goods_id, room_id),
                            INDEX (is_del)
 )ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '库存记录表';
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- 已有库升级：扣减规则（商品上架、每人限购、直播间配额）用到的列和索引。
+-- 新建的库直接执行 stock.sql、stock_records.sql、goods.sql、room_goods.sql，不需要执行本文件。
+-- xx_goods、xx_room_goods 由商品服务维护，只需要补上 quota 列；开启上架校验（stock_rule.check_on_shelf）前确认 xx_goods 可以访问。
+
+ALTER TABLE `xx_stock`
+    ADD COLUMN `user_limit` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '每个用户的限购数量：0不限购';
+
+ALTER TABLE `xx_stock_record`
+    ADD COLUMN `user_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '用户id',
+    ADD COLUMN `room_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '直播间id',
+    ADD INDEX (goods_id, user_id),
+    ADD INDEX (goods_id, room_id);
+
+ALTER TABLE `xx_room_goods`
+    ADD COLUMN `quota` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '直播间可售的数量（预扣和已扣减）：0不限';
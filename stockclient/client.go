@@ -0,0 +1,123 @@
+// Package stockclient 库存服务的 Go 客户端：通过注册中心发现实例并做负载均衡，
+// 幂等的请求失败后按退避重试，错误转换为 errno 中的业务错误。
+//
+//	registry.Init("127.0.0.1:8500")
+//	c, err := stockclient.New(stockclient.Config{Registry: registry.Reg})
+//	...
+//	s, err := c.V2.GetStock(ctx, &stockv2.GetStockReq{GoodsId: 1001})
+//	if errors.Is(err, errno.ErrQueryEmpty) { ... }
+package stockclient
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
+	"stock_service/registry"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/leastrequest"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health" // 客户端健康检查
+)
+
+// 负载均衡策略
+const (
+	RoundRobin   = "round_robin"
+	LeastRequest = "least_request"
+)
+
+// 默认值
+const (
+	DefaultService         = "stock_srv"
+	DefaultResolveInterval = 10 * time.Second
+	DefaultTimeout         = 3 * time.Second
+	DefaultMaxAttempts     = 3
+)
+
+// Config 客户端配置，零值字段使用默认值
+type Config struct {
+	Addr            string            // 固定地址，设置后不使用服务发现
+	Registry        registry.Register // 注册中心，Addr 为空时必填
+	Service         string            // 注册中心中的服务名，默认 stock_srv
+	Balancer        string            // 负载均衡策略：round_robin（默认）或 least_request
+	ResolveInterval time.Duration     // 刷新实例列表的间隔，默认 10s
+	Timeout         time.Duration     // ctx 没有截止时间时使用的超时时间，默认 3s
+	MaxAttempts     int               // 幂等请求的最大尝试次数（含第一次），默认 3，1 表示不重试
+	DialOptions     []grpc.DialOption // 额外的连接选项，例如 TLS 凭证和认证信息；默认使用明文连接
+}
+
+// Client 库存服务客户端，可以在多个 goroutine 中共用
+type Client struct {
+	V1 proto.StockClient
+	V2 stockv2.StockClient
+
+	conn *grpc.ClientConn
+}
+
+// New 创建客户端，实例列表在后台刷新，只选择健康检查为 SERVING 的实例；没有可用实例时请求会等到截止时间
+func New(cfg Config) (*Client, error) {
+	if cfg.Service == "" {
+		cfg.Service = DefaultService
+	}
+	if cfg.ResolveInterval <= 0 {
+		cfg.ResolveInterval = DefaultResolveInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+
+	var policy string
+	switch cfg.Balancer {
+	case "", RoundRobin:
+		policy = roundrobin.Name
+	case LeastRequest:
+		policy = leastrequest.Name
+	default:
+		return nil, fmt.Errorf("unsupported balancer: %s", cfg.Balancer)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// 按 gRPC 健康检查服务的状态选择实例，NOT_SERVING 的实例（例如正在退出）不再分配请求
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingConfig": [{%q: {}}], "healthCheckConfig": {"serviceName": ""}}`, policy)),
+		grpc.WithChainUnaryInterceptor(unaryInterceptor(cfg.Timeout, cfg.MaxAttempts)),
+		grpc.WithChainStreamInterceptor(streamInterceptor),
+	}
+	target := cfg.Addr
+	if target == "" {
+		if cfg.Registry == nil {
+			return nil, errors.New("either Addr or Registry is required")
+		}
+		target = Scheme + ":///" + cfg.Service
+		opts = append(opts, grpc.WithResolvers(&resolverBuilder{reg: cfg.Registry, interval: cfg.ResolveInterval}))
+	}
+	// 调用方的选项放在最后，可以覆盖默认的明文连接
+	opts = append(opts, cfg.DialOptions...)
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		V1:   proto.NewStockClient(conn),
+		V2:   stockv2.NewStockClient(conn),
+		conn: conn,
+	}, nil
+}
+
+// Conn 返回底层连接，用于注册其他服务的客户端（例如健康检查）
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close 关闭连接并停止刷新实例列表
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,162 @@
+package stockclient
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	stockv2 "stock_service/proto/v2"
+	"stock_service/registry"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const testService = "stock_srv_test"
+
+// testServer 返回的 Total 为实例编号，用来判断请求落在哪个实例上
+type testServer struct {
+	stockv2.UnimplementedStockServer
+	id int64
+}
+
+func (s *testServer) GetStock(_ context.Context, req *stockv2.GetStockReq) (*stockv2.StockState, error) {
+	return &stockv2.StockState{GoodsId: req.GetGoodsId(), Total: s.id}, nil
+}
+
+// instance 测试用的服务实例
+type instance struct {
+	ip     string
+	port   int
+	health *health.Server
+}
+
+// startInstance 启动一个实例，测试结束时停止
+func startInstance(t *testing.T, id int64) *instance {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	hs := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, hs)
+	stockv2.RegisterStockServer(s, &testServer{id: id})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	addr := lis.Addr().(*net.TCPAddr)
+	return &instance{ip: addr.IP.String(), port: addr.Port, health: hs}
+}
+
+func (i *instance) register(t *testing.T, reg registry.Register) {
+	t.Helper()
+	if err := reg.RegisterService(testService, i.ip, i.port, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (i *instance) serviceID() string {
+	return testService + "-" + i.ip + "-" + strconv.Itoa(i.port)
+}
+
+func newTestClient(t *testing.T, reg registry.Register) *Client {
+	t.Helper()
+	c, err := New(Config{Registry: reg, Service: testService, ResolveInterval: 20 * time.Millisecond, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// hits 发送 n 个请求，返回每个实例收到的请求数
+func hits(t *testing.T, c *Client, n int) map[int64]int {
+	t.Helper()
+	got := make(map[int64]int)
+	for range n {
+		s, err := c.V2.GetStock(context.Background(), &stockv2.GetStockReq{GoodsId: 1})
+		if err != nil {
+			t.Fatalf("GetStock: %v", err)
+		}
+		got[s.GetTotal()]++
+	}
+	return got
+}
+
+// eventually 在超时前反复检查条件，条件满足时返回
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestResolverFollowsRegistry(t *testing.T) {
+	reg := registry.NewMemory()
+	a, b := startInstance(t, 1), startInstance(t, 2)
+	a.register(t, reg)
+	c := newTestClient(t, reg)
+
+	if got := hits(t, c, 10); got[1] != 10 {
+		t.Fatalf("with only instance 1 registered got %v", got)
+	}
+
+	// 新注册的实例开始分到请求
+	b.register(t, reg)
+	eventually(t, "instance 2 to receive requests", func() bool {
+		got := hits(t, c, 10)
+		return got[1] > 0 && got[2] > 0
+	})
+
+	// 注销的实例不再分到请求
+	if err := reg.Deregister(a.serviceID()); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "instance 1 to stop receiving requests", func() bool {
+		return hits(t, c, 10)[2] == 10
+	})
+}
+
+func TestBalancerSkipsUnhealthyInstances(t *testing.T) {
+	reg := registry.NewMemory()
+	a, b := startInstance(t, 1), startInstance(t, 2)
+	a.register(t, reg)
+	b.register(t, reg)
+
+	// 已经注册但无法连接的实例
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dead := lis.Addr().(*net.TCPAddr)
+	lis.Close()
+	if err := reg.RegisterService(testService, dead.IP.String(), dead.Port, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestClient(t, reg)
+	eventually(t, "both live instances to receive requests", func() bool {
+		got := hits(t, c, 20)
+		return got[1] > 0 && got[2] > 0
+	})
+
+	// 健康检查为 NOT_SERVING 的实例（例如正在退出）不再分到请求
+	a.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	eventually(t, "instance 1 to be skipped", func() bool {
+		return hits(t, c, 20)[2] == 20
+	})
+
+	// 恢复后重新分到请求
+	a.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	eventually(t, "instance 1 to receive requests again", func() bool {
+		return hits(t, c, 20)[1] > 0
+	})
+}
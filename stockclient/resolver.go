@@ -0,0 +1,119 @@
+package stockclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"stock_service/registry"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// Scheme 基于注册中心的服务发现，target 为 consul:///<服务名>
+const Scheme = "consul"
+
+// resolverBuilder 每个客户端一个，通过 grpc.WithResolvers 注册，不影响全局的 resolver
+type resolverBuilder struct {
+	reg      registry.Register
+	interval time.Duration
+}
+
+func (b *resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	service := target.Endpoint()
+	if service == "" {
+		return nil, fmt.Errorf("stockclient: missing service name in target %q", target.String())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &registryResolver{
+		reg:      b.reg,
+		service:  service,
+		interval: b.interval,
+		cc:       cc,
+		cancel:   cancel,
+		now:      make(chan struct{}, 1),
+	}
+	r.wg.Add(1)
+	go r.watch(ctx)
+	return r, nil
+}
+
+func (b *resolverBuilder) Scheme() string {
+	return Scheme
+}
+
+// registryResolver 定时从注册中心拉取实例列表，实例变化时更新连接
+type registryResolver struct {
+	reg      registry.Register
+	service  string
+	interval time.Duration
+	cc       resolver.ClientConn
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	now      chan struct{} // ResolveNow 的通知
+	last     []string      // 上一次的实例地址，已排序
+}
+
+func (r *registryResolver) watch(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.resolve()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.now:
+		}
+	}
+}
+
+// resolve 拉取一次实例列表，失败或没有实例时通知 gRPC，由 gRPC 按退避再次调用 ResolveNow
+func (r *registryResolver) resolve() {
+	services, err := r.reg.ListService(r.service)
+	if err != nil {
+		r.cc.ReportError(fmt.Errorf("stockclient: list service %s: %w", r.service, err))
+		return
+	}
+	addrs := make([]string, 0, len(services))
+	for _, s := range services {
+		addrs = append(addrs, net.JoinHostPort(s.Address, strconv.Itoa(s.Port)))
+	}
+	if len(addrs) == 0 {
+		r.last = nil
+		r.cc.ReportError(fmt.Errorf("stockclient: no instances of service %s", r.service))
+		return
+	}
+	slices.Sort(addrs)
+	if slices.Equal(addrs, r.last) {
+		return
+	}
+
+	state := resolver.State{Addresses: make([]resolver.Address, len(addrs))}
+	for i, addr := range addrs {
+		state.Addresses[i] = resolver.Address{Addr: addr}
+	}
+	if err := r.cc.UpdateState(state); err != nil {
+		// 更新失败时下次仍然推送
+		r.last = nil
+		return
+	}
+	r.last = addrs
+}
+
+func (r *registryResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.now <- struct{}{}:
+	default:
+	}
+}
+
+func (r *registryResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
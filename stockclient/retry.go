@@ -0,0 +1,141 @@
+package stockclient
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"stock_service/errno"
+	"stock_service/proto"
+	stockv2 "stock_service/proto/v2"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 重试的退避时间：initialBackoff * 2^n，最大 maxBackoff，再加上随机抖动
+const (
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = time.Second
+)
+
+// readMethods 只读的方法，失败后可以直接重试
+var readMethods = map[string]bool{
+	proto.Stock_GetStock_FullMethodName:             true,
+	proto.Stock_BatchGetStock_FullMethodName:        true,
+	proto.Stock_ListStock_FullMethodName:            true,
+	proto.Stock_GetOrderReservations_FullMethodName: true,
+	proto.Stock_ListReservations_FullMethodName:     true,
+	stockv2.Stock_GetStock_FullMethodName:           true,
+	stockv2.Stock_BatchGetStock_FullMethodName:      true,
+	stockv2.Stock_CheckStock_FullMethodName:         true,
+}
+
+// metaRequest v2 修改类请求都带有 RequestMeta
+type metaRequest interface {
+	GetMeta() *stockv2.RequestMeta
+}
+
+// idempotent 判断请求能否重试：只读的方法，或者带有幂等键的 v2 修改类请求
+// （服务端对相同的幂等键只执行一次，重试不会重复扣减）
+func idempotent(method string, req any) bool {
+	if readMethods[method] {
+		return true
+	}
+	m, ok := req.(metaRequest)
+	return ok && m.GetMeta().GetIdempotencyKey() != ""
+}
+
+// retryable 判断错误能否重试：实例不可用，或者服务端加锁失败等可以重新执行的错误
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// backoff 第 attempt 次重试前的等待时间
+func backoff(attempt int) time.Duration {
+	d := initialBackoff << attempt
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	// 抖动 ±20%，避免多个客户端同时重试
+	return d * time.Duration(80+rand.Intn(41)) / 100
+}
+
+// retryDelay 服务端在错误详情中建议的重试间隔（被限流时），没有时返回 0
+func retryDelay(err error) time.Duration {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
+// unaryInterceptor ctx 没有截止时间时加上默认超时，重试和错误转换都在截止时间内进行
+func unaryInterceptor(timeout time.Duration, maxAttempts int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		attempts := 1
+		if idempotent(method, req) {
+			attempts = maxAttempts
+		}
+		var err error
+		for i := 0; i < attempts; i++ {
+			if i > 0 {
+				t := time.NewTimer(max(backoff(i-1), retryDelay(err)))
+				select {
+				case <-ctx.Done():
+					t.Stop()
+					return toError(err)
+				case <-t.C:
+				}
+			}
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !retryable(err) {
+				break
+			}
+		}
+		return toError(err)
+	}
+}
+
+// streamInterceptor 流式接口（导出）不设默认超时也不重试，只转换建立流时的错误
+func streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	s, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return s, nil
+}
+
+// toError 把服务端返回的业务错误转换为 *errno.Error，调用方可以用 errors.Is 与
+// errno 中的哨兵错误比较；*errno.Error 实现了 GRPCStatus，status.Code 等照常可用。
+// 其他错误（连接失败、超时等）原样返回。
+func toError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if e := errno.FromStatus(st); e != nil {
+		return e
+	}
+	return err
+}
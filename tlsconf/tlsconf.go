@@ -0,0 +1,196 @@
+// Package tlsconf gRPC 服务端的 TLS 配置：证书和客户端 CA 从文件加载，Run 定期检查文件是否更新，
+// 更新后新的连接使用新证书，已经建立的连接不受影响。
+package tlsconf
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"stock_service/config"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+)
+
+// defaultReloadInterval 默认检查证书文件的间隔
+const defaultReloadInterval = time.Minute
+
+// state 一次加载的证书
+type state struct {
+	cert      *tls.Certificate
+	prevLeaf  []byte         // 上一次加载的证书，网关在证书更新期间也接受它
+	clientCAs *x509.CertPool // 为 nil 时不要求客户端证书
+	modTimes  []time.Time    // 证书文件的修改时间，用于判断是否需要重新加载
+}
+
+// Reloader 从文件加载证书，证书文件更新后自动重新加载
+type Reloader struct {
+	cfg   config.TLSConfig
+	state atomic.Pointer[state]
+}
+
+// New 加载证书，证书无效时返回错误
+func New(cfg *config.TLSConfig) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("tls: cert_file and key_file are required")
+	}
+	r := &Reloader{cfg: *cfg}
+	if r.cfg.ReloadInterval <= 0 {
+		r.cfg.ReloadInterval = defaultReloadInterval
+	}
+	s, err := r.load(nil)
+	if err != nil {
+		return nil, err
+	}
+	r.state.Store(s)
+	return r, nil
+}
+
+// files 需要监听的证书文件
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// modTimes 返回证书文件的修改时间
+func (r *Reloader) modTimes() ([]time.Time, error) {
+	files := r.files()
+	times := make([]time.Time, len(files))
+	for i, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		times[i] = fi.ModTime()
+	}
+	return times, nil
+}
+
+// load 读取证书文件，prev 为上一次加载的结果
+func (r *Reloader) load(prev *state) (*state, error) {
+	times, err := r.modTimes()
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+	s := &state{cert: &cert, modTimes: times}
+	if prev != nil {
+		s.prevLeaf = prev.cert.Certificate[0]
+	}
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %w", err)
+		}
+		s.clientCAs = x509.NewCertPool()
+		if !s.clientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates in %s", r.cfg.ClientCAFile)
+		}
+	}
+	return s, nil
+}
+
+// changed 证书文件的修改时间是否变化
+func (r *Reloader) changed(s *state) bool {
+	times, err := r.modTimes()
+	if err != nil {
+		// 文件暂时不存在（例如正在替换），下次再检查
+		return false
+	}
+	for i, t := range times {
+		if !t.Equal(s.modTimes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run 定期检查证书文件，文件更新后重新加载；新证书无效时记录日志，继续使用原来的证书
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		prev := r.state.Load()
+		if !r.changed(prev) {
+			continue
+		}
+		s, err := r.load(prev)
+		if err != nil {
+			zap.L().Error("reload tls certificate failed", zap.Error(err))
+			continue
+		}
+		r.state.Store(s)
+		zap.L().Info("tls certificate reloaded", zap.String("cert_file", r.cfg.CertFile))
+	}
+}
+
+// serverConfig 每次握手使用当前的证书和客户端 CA，配置了客户端 CA 时要求客户端证书
+func (r *Reloader) serverConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			s := r.state.Load()
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*s.cert},
+				NextProtos:   nextProtos,
+			}
+			if s.clientCAs != nil {
+				cfg.ClientCAs = s.clientCAs
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// ServerCredentials gRPC 服务端的凭证
+func (r *Reloader) ServerCredentials() credentials.TransportCredentials {
+	return credentials.NewTLS(r.serverConfig("h2"))
+}
+
+// HTTPConfig HTTP 网关的 TLS 配置，与 gRPC 服务使用相同的证书，同样要求客户端证书，
+// 开启 mTLS 后网关端口不能绕过客户端证书的校验
+func (r *Reloader) HTTPConfig() *tls.Config {
+	return r.serverConfig("h2", "http/1.1")
+}
+
+// GatewayCredentials 本机 HTTP 网关连接 gRPC 服务的凭证。
+// 网关连接的是自己，不按域名校验服务端证书，而是要求对方的证书就是当前加载的证书；
+// 开启 mTLS 时网关用服务端证书作为客户端证书完成握手，证书需要由 client_ca_file 签发并包含 clientAuth 用途。
+// 服务端不把这个证书当作调用方：经过网关的请求按网关转发的 HTTP 客户端证书认证。
+func (r *Reloader) GatewayCredentials() credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// 证书在 VerifyPeerCertificate 中按内容比较
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			s := r.state.Load()
+			if len(rawCerts) > 0 && (bytes.Equal(rawCerts[0], s.cert.Certificate[0]) || bytes.Equal(rawCerts[0], s.prevLeaf)) {
+				return nil
+			}
+			return errors.New("tls: gateway peer certificate does not match the server certificate")
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.state.Load().cert, nil
+		},
+	})
+}
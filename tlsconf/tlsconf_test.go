@@ -0,0 +1,147 @@
+package tlsconf
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"stock_service/config"
+)
+
+// writeCert 生成 CN 为 cn 的自签名证书，写入 certFile 和 keyFile，修改时间为 mod
+func writeCert(t *testing.T, certFile, keyFile, cn string, mod time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// servedCN 与服务端握手，返回服务端证书的 CN
+func servedCN(t *testing.T, r *Reloader) string {
+	t.Helper()
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+	server := tls.Server(c1, r.HTTPConfig())
+	go server.Handshake()
+	client := tls.Client(c2, &tls.Config{InsecureSkipVerify: true})
+	if err := client.Handshake(); err != nil {
+		t.Fatal(err)
+	}
+	return client.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	start := time.Now().Add(-time.Minute)
+	writeCert(t, certFile, keyFile, "v1", start)
+
+	r, err := New(&config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ReloadInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	if cn := servedCN(t, r); cn != "v1" {
+		t.Fatalf("served %q, want v1", cn)
+	}
+
+	// 证书文件更新后，新的连接使用新证书
+	writeCert(t, certFile, keyFile, "v2", start.Add(time.Second))
+	waitFor(t, func() bool { return servedCN(t, r) == "v2" })
+	if prev := r.state.Load().prevLeaf; prev == nil {
+		t.Error("previous certificate was not kept for the gateway")
+	}
+
+	// 新证书无效时继续使用原来的证书
+	if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(certFile, start.Add(2*time.Second), start.Add(2*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if cn := servedCN(t, r); cn != "v2" {
+		t.Fatalf("served %q after an invalid update, want v2", cn)
+	}
+
+	// 修复后重新加载
+	writeCert(t, certFile, keyFile, "v3", start.Add(3*time.Second))
+	waitFor(t, func() bool { return servedCN(t, r) == "v3" })
+}
+
+func TestNewInvalidCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	if _, err := New(&config.TLSConfig{CertFile: certFile, KeyFile: keyFile}); err == nil {
+		t.Fatal("New accepted missing certificate files")
+	}
+	writeCert(t, certFile, keyFile, "v1", time.Now())
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(&config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile}); err == nil {
+		t.Fatal("New accepted an invalid client CA")
+	}
+}
+
+// waitFor 等待 cond 成立，最多 2 秒
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the certificate to reload")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
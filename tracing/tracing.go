@@ -0,0 +1,98 @@
+// Package tracing OpenTelemetry 链路追踪：初始化 TracerProvider 和上下文传播，
+// gRPC、GORM、Redis 和 biz 层的 span 都通过全局的 TracerProvider 创建，未启用时为空操作。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"stock_service/config"
+	"stock_service/errno"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Init 根据配置创建 exporter 并设置全局的 TracerProvider，返回退出时调用的 shutdown（上报剩余的 span）。
+// 无论是否启用都会设置 W3C trace context 传播，上游的链路信息可以继续传给下游。
+func Init(cfg *config.TracingConfig, name, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	noop := func(context.Context) error { return nil }
+	if cfg == nil {
+		return noop, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "", "none":
+		return noop, nil
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		// 只创建客户端，不等待连接成功，collector 暂时不可用不影响服务启动
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(name),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// End 结束 span，err 不为 nil 时记录错误。
+// 与 otelgrpc 服务端一致，只有服务端的问题（Internal、Unavailable 等）才把 span 标记为失败，
+// 库存不足、商品不存在等业务错误只记录事件。
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if serverError(err) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+// serverError 判断错误对应的 gRPC 状态码是否为服务端的问题
+func serverError(err error) bool {
+	switch status.Code(errno.ToStatus(err)) {
+	case grpccodes.Unknown, grpccodes.DeadlineExceeded, grpccodes.Unimplemented,
+		grpccodes.Internal, grpccodes.Unavailable, grpccodes.DataLoss:
+		return true
+	}
+	return false
+}